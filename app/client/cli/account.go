@@ -54,7 +54,7 @@ func accountCommands() []*cobra.Command {
 					Amount:      amount,
 				}
 
-				tx, err := prepareTxBytes(msg, pk)
+				tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 				if err != nil {
 					return err
 				}
@@ -72,5 +72,6 @@ func accountCommands() []*cobra.Command {
 			},
 		},
 	}
+	applySubcommandOptions(cmds, attachFeeFlagToSubcommands())
 	return cmds
 }
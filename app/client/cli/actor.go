@@ -12,7 +12,7 @@ import (
 )
 
 func init() {
-	rootCmd.AddCommand(NewActorCommands(attachPwdFlagToSubcommands())...)
+	rootCmd.AddCommand(NewActorCommands(append(attachPwdFlagToSubcommands(), attachFeeFlagToSubcommands()...))...)
 
 	rawChainCleanupRegex = regexp.MustCompile(rawChainCleanupExpr)
 
@@ -27,6 +27,8 @@ const (
 
 var (
 	pwd                  string
+	fee                  string
+	sequence             uint64
 	rawChainCleanupRegex *regexp.Regexp
 	oneMillion           *big.Int
 )
@@ -67,9 +69,10 @@ func newActorCommands(cmdDef actorCmdDef) []*cobra.Command {
 		newStakeCmd(cmdDef),
 		newEditStakeCmd(cmdDef),
 		newUnstakeCmd(cmdDef),
+		newPauseCmd(cmdDef),
 		newUnpauseCmd(cmdDef),
 	}
-	applySubcommandOptions(cmds, cmdDef)
+	applySubcommandOptions(cmds, cmdDef.Options)
 	return cmds
 }
 
@@ -121,7 +124,7 @@ If no changes are desired for the parameter, just enter the current param value
 				ActorType:     cmdDef.ActorType,
 			}
 
-			tx, err := prepareTxBytes(msg, pk)
+			tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 			if err != nil {
 				return err
 			}
@@ -176,7 +179,7 @@ func newEditStakeCmd(cmdDef actorCmdDef) *cobra.Command {
 				ActorType:  cmdDef.ActorType,
 			}
 
-			tx, err := prepareTxBytes(msg, pk)
+			tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 			if err != nil {
 				return err
 			}
@@ -217,7 +220,7 @@ func newUnstakeCmd(cmdDef actorCmdDef) *cobra.Command {
 				ActorType: cmdDef.ActorType,
 			}
 
-			tx, err := prepareTxBytes(msg, pk)
+			tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 			if err != nil {
 				return err
 			}
@@ -236,6 +239,47 @@ func newUnstakeCmd(cmdDef actorCmdDef) *cobra.Command {
 	return unstakeCmd
 }
 
+func newPauseCmd(cmdDef actorCmdDef) *cobra.Command {
+	pauseCmd := &cobra.Command{
+		Use:   "Pause <fromAddr>",
+		Short: "Pause <fromAddr>",
+		Long:  fmt.Sprintf(`Pauses the %s actor with address <fromAddr>`, cmdDef.Name),
+		Args:  cobra.ExactArgs(1), // REFACTOR(#150): Not being used at the moment. Update once a keybase is implemented.
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// TODO(#150): update when we have keybase
+			pk, err := readEd25519PrivateKeyFromFile(privateKeyFilePath)
+			if err != nil {
+				return err
+			}
+
+			// TODO (team): passphrase is currently not used since there's no keybase yet, the prompt is here to mimick the real world UX
+			pwd = readPassphrase(pwd)
+
+			msg := &typesUtil.MessagePause{
+				Address:   pk.Address(),
+				Signer:    pk.Address(),
+				ActorType: cmdDef.ActorType,
+			}
+
+			tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
+			if err != nil {
+				return err
+			}
+
+			resp, err := postRawTx(cmd.Context(), pk, tx)
+			if err != nil {
+				return err
+			}
+			// DISCUSS(#310): define UX for return values - should we return the raw response or a parsed/human readable response? For now, I am simply printing to stdout
+			fmt.Printf("HTTP status code: %d\n", resp.StatusCode())
+			fmt.Println(string(resp.Body))
+
+			return nil
+		},
+	}
+	return pauseCmd
+}
+
 func newUnpauseCmd(cmdDef actorCmdDef) *cobra.Command {
 	unpauseCmd := &cobra.Command{
 		Use:   "Unpause <fromAddr>",
@@ -258,7 +302,7 @@ func newUnpauseCmd(cmdDef actorCmdDef) *cobra.Command {
 				ActorType: cmdDef.ActorType,
 			}
 
-			tx, err := prepareTxBytes(msg, pk)
+			tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	genesisConfigPath  string
+	genesisGenesisPath string
+)
+
+func init() {
+	rootCmd.AddCommand(NewGenesisCommand())
+}
+
+func NewGenesisCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Genesis",
+		Short:   "Genesis specific commands",
+		Long:    "Exports the state of a node as a genesis state, e.g. for a network upgrade or a forked testnet. The node must be stopped since its databases are opened by the command.",
+		Aliases: []string{"genesis"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.PersistentFlags().StringVar(&genesisConfigPath, "config", defaultConfigPath, "Relative or absolute path to the config file of the node")
+	cmd.PersistentFlags().StringVar(&genesisGenesisPath, "genesis", defaultGenesisPath, "Relative or absolute path to the genesis file of the node")
+
+	cmd.AddCommand(genesisCommands()...)
+
+	return cmd
+}
+
+func genesisCommands() []*cobra.Command {
+	cmds := []*cobra.Command{
+		{
+			Use:     "Export <height> <file>",
+			Short:   "Export <height> <file>",
+			Long:    "Exports the accounts, pools, actors, params and flags of the node at <height> as a genesis state to the JSON file <file>",
+			Aliases: []string{"export"},
+			Args:    cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				height, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+
+				persistenceMod, err := newLocalPersistenceModule(genesisConfigPath, genesisGenesisPath)
+				if err != nil {
+					return err
+				}
+				defer persistenceMod.Stop()
+
+				genesisState, err := persistenceMod.ExportGenesis(height)
+				if err != nil {
+					return err
+				}
+				genesisJson, err := json.MarshalIndent(genesisState, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(args[1], genesisJson, 0o600); err != nil {
+					return err
+				}
+				fmt.Printf("Exported the genesis state at height %d to %s\n", height, args[1])
+				return nil
+			},
+		},
+	}
+	return cmds
+}
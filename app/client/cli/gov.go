@@ -59,7 +59,7 @@ func govCommands() []*cobra.Command {
 					ParameterValue: pbValue,
 				}
 
-				tx, err := prepareTxBytes(msg, pk)
+				tx, err := prepareTxBytes(cmd.Context(), msg, pk, fee)
 				if err != nil {
 					return err
 				}
@@ -76,5 +76,6 @@ func govCommands() []*cobra.Command {
 			},
 		},
 	}
+	applySubcommandOptions(cmds, attachFeeFlagToSubcommands())
 	return cmds
 }
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	indexerConfigPath  string
+	indexerGenesisPath string
+	indexerRestart     bool
+)
+
+func init() {
+	rootCmd.AddCommand(NewIndexerCommand())
+}
+
+func NewIndexerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Indexer",
+		Short:   "Transaction indexer specific commands",
+		Long:    "Maintains the transaction indexer of a node. The node must be stopped since its databases are opened by the command.",
+		Aliases: []string{"indexer"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.PersistentFlags().StringVar(&indexerConfigPath, "config", defaultConfigPath, "Relative or absolute path to the config file of the node")
+	cmd.PersistentFlags().StringVar(&indexerGenesisPath, "genesis", defaultGenesisPath, "Relative or absolute path to the genesis file of the node")
+
+	cmd.AddCommand(indexerCommands()...)
+
+	return cmd
+}
+
+func indexerCommands() []*cobra.Command {
+	cmds := []*cobra.Command{
+		{
+			Use:     "Rebuild",
+			Short:   "Rebuild",
+			Long:    "Rebuilds the transaction indexer from the blocks in the block store. An interrupted rebuild is resumed from its last reindexed height unless --restart is set.",
+			Aliases: []string{"rebuild"},
+			Args:    cobra.ExactArgs(0),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				persistenceMod, err := newLocalPersistenceModule(indexerConfigPath, indexerGenesisPath)
+				if err != nil {
+					return err
+				}
+				defer persistenceMod.Stop()
+
+				numTxs := 0
+				progress := func(height, toHeight int64, blockNumTxs int) {
+					numTxs += blockNumTxs
+					fmt.Printf("Reindexed height %d/%d (%d transactions)\n", height, toHeight, blockNumTxs)
+				}
+				if err := persistenceMod.RebuildTxIndexer(indexerRestart, progress); err != nil {
+					return err
+				}
+				fmt.Printf("Rebuilt the transaction indexer, %d transactions reindexed\n", numTxs)
+				return nil
+			},
+		},
+	}
+	cmds[0].Flags().BoolVar(&indexerRestart, "restart", false, "Discard the progress of an interrupted rebuild and start over")
+	return cmds
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrationsConfigPath  string
+	migrationsGenesisPath string
+)
+
+func init() {
+	rootCmd.AddCommand(NewMigrationsCommand())
+}
+
+func NewMigrationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Migrations",
+		Short:   "Database migrations specific commands",
+		Long:    "Shows and applies the migrations of the schema of the SQL database of a node. The node must be stopped to apply them.",
+		Aliases: []string{"migrations"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.PersistentFlags().StringVar(&migrationsConfigPath, "config", defaultConfigPath, "Relative or absolute path to the config file of the node")
+	cmd.PersistentFlags().StringVar(&migrationsGenesisPath, "genesis", defaultGenesisPath, "Relative or absolute path to the genesis file of the node")
+
+	cmd.AddCommand(migrationsCommands()...)
+
+	return cmd
+}
+
+func migrationsCommands() []*cobra.Command {
+	cmds := []*cobra.Command{
+		{
+			Use:     "Status",
+			Short:   "Status",
+			Long:    "Shows the schema version of the database of the node and its pending migrations",
+			Aliases: []string{"status"},
+			Args:    cobra.ExactArgs(0),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				runtimeMgr := runtime.NewManagerFromFiles(migrationsConfigPath, migrationsGenesisPath)
+				status, err := persistence.GetMigrationStatus(runtimeMgr.GetConfig().Persistence)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Schema version: %d (latest: %d)\n", status.CurrentVersion, status.LatestVersion)
+				if status.CurrentVersion > status.LatestVersion {
+					fmt.Println("The database was migrated by a newer version of the node")
+					return nil
+				}
+				fmt.Printf("Pending migrations: %d\n", len(status.Pending))
+				for _, migration := range status.Pending {
+					fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+				}
+				return nil
+			},
+		},
+		{
+			Use:     "Apply",
+			Short:   "Apply",
+			Long:    "Applies the pending migrations to the database of the node, in order",
+			Aliases: []string{"apply"},
+			Args:    cobra.ExactArgs(0),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				runtimeMgr := runtime.NewManagerFromFiles(migrationsConfigPath, migrationsGenesisPath)
+				applied, err := persistence.ApplyMigrations(runtimeMgr.GetConfig().Persistence)
+				if err != nil {
+					return err
+				}
+
+				if len(applied) == 0 {
+					fmt.Println("The database is up to date")
+					return nil
+				}
+				for _, migration := range applied {
+					fmt.Printf("Applied migration %d: %s\n", migration.Version, migration.Description)
+				}
+				return nil
+			},
+		},
+	}
+	return cmds
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func init() {
+	rootCmd.AddCommand(NewMultisigCommand())
+}
+
+func NewMultisigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Multisig",
+		Short:   "Multisig account specific commands",
+		Long:    "Creates the transactions of a multisig account, which are signed offline by its members before being combined and broadcast",
+		Aliases: []string{"multisig"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.AddCommand(multisigCommands()...)
+
+	return cmd
+}
+
+func multisigCommands() []*cobra.Command {
+	txCmds := []*cobra.Command{
+		{
+			Use:     "Send <multisigPublicKey> <to> <amount>",
+			Short:   "Send <multisigPublicKey> <to> <amount>",
+			Long:    "Prints the unsigned transaction sending <amount> to address <to> from the multisig account of <multisigPublicKey>",
+			Aliases: []string{"send"},
+			Args:    cobra.ExactArgs(3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				multisigPublicKey, err := crypto.NewMultisigPublicKeyFromString(args[0])
+				if err != nil {
+					return err
+				}
+
+				msg := &typesUtil.MessageSend{
+					FromAddress: multisigPublicKey.Address(),
+					ToAddress:   crypto.AddressFromString(args[1]),
+					Amount:      args[2],
+				}
+
+				return printUnsignedMultisigTx(cmd, msg, multisigPublicKey)
+			},
+		},
+		{
+			Use:     "ChangeParameter <multisigPublicKey> <key> <value>",
+			Short:   "ChangeParameter <multisigPublicKey> <key> <value>",
+			Long:    "Prints the unsigned transaction changing the Governance parameter with <key> owned by the multisig account of <multisigPublicKey> to <value>",
+			Aliases: []string{},
+			Args:    cobra.ExactArgs(3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				multisigPublicKey, err := crypto.NewMultisigPublicKeyFromString(args[0])
+				if err != nil {
+					return err
+				}
+
+				pbValue, err := anypb.New(wrapperspb.String(args[2]))
+				if err != nil {
+					return err
+				}
+
+				msg := &typesUtil.MessageChangeParameter{
+					Signer:         multisigPublicKey.Address(),
+					Owner:          multisigPublicKey.Address(),
+					ParameterKey:   args[1],
+					ParameterValue: pbValue,
+				}
+
+				return printUnsignedMultisigTx(cmd, msg, multisigPublicKey)
+			},
+		},
+	}
+	applySubcommandOptions(txCmds, append(attachFeeFlagToSubcommands(), attachSequenceFlagToSubcommands()...))
+
+	cmds := []*cobra.Command{
+		{
+			Use:     "Address <threshold> <publicKeys...>",
+			Short:   "Address <threshold> <publicKeys...>",
+			Long:    "Prints the multisig public key and the address of the multisig account of the members <publicKeys> whose transactions must be signed by <threshold> of them",
+			Aliases: []string{"address"},
+			Args:    cobra.MinimumNArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				threshold, err := strconv.Atoi(args[0])
+				if err != nil {
+					return err
+				}
+				publicKeys := make([]crypto.PublicKey, 0, len(args)-1)
+				for _, arg := range args[1:] {
+					publicKey, err := crypto.NewPublicKey(arg)
+					if err != nil {
+						return err
+					}
+					publicKeys = append(publicKeys, publicKey)
+				}
+
+				multisigPublicKey, err := crypto.NewMultisigPublicKey(threshold, publicKeys)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Multisig public key: %s\n", multisigPublicKey.String())
+				fmt.Printf("Address: %s\n", multisigPublicKey.Address().String())
+
+				return nil
+			},
+		},
+		{
+			Use:     "Sign <tx>",
+			Short:   "Sign <tx>",
+			Long:    "Prints the multisig transaction <tx>, hex encoded, with the signature of the member whose private key is read from `--path_to_private_key_file`. The signing is done offline.",
+			Aliases: []string{"sign"},
+			Args:    cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				// TODO(#150): update when we have keybase
+				pk, err := readEd25519PrivateKeyFromFile(privateKeyFilePath)
+				if err != nil {
+					return err
+				}
+
+				tx, err := multisigTxFromHex(args[0])
+				if err != nil {
+					return err
+				}
+				if err := tx.SignMultisig(pk); err != nil {
+					return err
+				}
+
+				return printTx(tx)
+			},
+		},
+		{
+			Use:     "Combine <txs...>",
+			Short:   "Combine <txs...>",
+			Long:    "Prints the multisig transaction holding the signatures of all of the partially signed copies <txs> of the same transaction. The combination is done offline.",
+			Aliases: []string{"combine"},
+			Args:    cobra.MinimumNArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				txs := make([]*typesUtil.Transaction, len(args))
+				for i, arg := range args {
+					tx, err := multisigTxFromHex(arg)
+					if err != nil {
+						return err
+					}
+					txs[i] = tx
+				}
+
+				tx := txs[0]
+				if err := tx.CombineSignatures(txs[1:]...); err != nil {
+					return err
+				}
+				if err := tx.VerifySignatures(); err != nil {
+					fmt.Fprintf(os.Stderr, "The combined transaction cannot be broadcast yet: %s\n", err.Error())
+				}
+
+				return printTx(tx)
+			},
+		},
+		{
+			Use:     "Broadcast <tx>",
+			Short:   "Broadcast <tx>",
+			Long:    "Broadcasts the multisig transaction <tx> signed by at least the threshold of the members of its multisig account",
+			Aliases: []string{"broadcast"},
+			Args:    cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				tx, err := multisigTxFromHex(args[0])
+				if err != nil {
+					return err
+				}
+				address, err := tx.SignerAddress()
+				if err != nil {
+					return err
+				}
+				txBz, err := tx.Bytes()
+				if err != nil {
+					return err
+				}
+
+				resp, err := postRawTxFromAddress(cmd.Context(), address, txBz)
+				if err != nil {
+					return err
+				}
+				// DISCUSS(#310): define UX for return values - should we return the raw response or a parsed/human readable response? For now, I am simply printing to stdout
+				fmt.Printf("HTTP status code: %d\n", resp.StatusCode())
+				fmt.Println(string(resp.Body))
+
+				return nil
+			},
+		},
+	}
+	return append(txCmds, cmds...)
+}
+
+// printUnsignedMultisigTx prints the hex encoded transaction of the multisig account of multisigPublicKey wrapping msg,
+// which is signed offline by the members
+func printUnsignedMultisigTx(cmd *cobra.Command, msg typesUtil.Message, multisigPublicKey *crypto.MultisigPublicKey) error {
+	txSequence := sequence
+	if !cmd.Flags().Changed("sequence") {
+		nextSequence, err := getNextSequence(cmd.Context(), multisigPublicKey.Address())
+		if err != nil {
+			return err
+		}
+		txSequence = nextSequence
+	}
+
+	tx, err := prepareUnsignedTx(msg, fee, txSequence)
+	if err != nil {
+		return err
+	}
+	tx.MultisigPublicKey = multisigPublicKey.Bytes()
+
+	return printTx(tx)
+}
+
+func multisigTxFromHex(txHex string) (*typesUtil.Transaction, error) {
+	txBz, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := typesUtil.TransactionFromBytes(txBz)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.MultisigPublicKey) == 0 {
+		return nil, fmt.Errorf("the transaction is not the transaction of a multisig account")
+	}
+	return tx, nil
+}
+
+func printTx(tx *typesUtil.Transaction) error {
+	txBz, err := tx.Bytes()
+	if err != nil {
+		return err
+	}
+	fmt.Println(hex.EncodeToString(txBz))
+	return nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotConfigPath  string
+	snapshotGenesisPath string
+)
+
+func init() {
+	rootCmd.AddCommand(NewSnapshotCommand())
+}
+
+func NewSnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "Snapshot",
+		Short:   "Snapshot specific commands",
+		Long:    "Exports and imports snapshots of the state of a node. The node must be stopped since its databases are opened by the command.",
+		Aliases: []string{"snapshot"},
+		Args:    cobra.ExactArgs(0),
+	}
+
+	cmd.PersistentFlags().StringVar(&snapshotConfigPath, "config", defaultConfigPath, "Relative or absolute path to the config file of the node")
+	cmd.PersistentFlags().StringVar(&snapshotGenesisPath, "genesis", defaultGenesisPath, "Relative or absolute path to the genesis file of the node")
+
+	cmd.AddCommand(snapshotCommands()...)
+
+	return cmd
+}
+
+func snapshotCommands() []*cobra.Command {
+	cmds := []*cobra.Command{
+		{
+			Use:     "Export <height> <dir>",
+			Short:   "Export <height> <dir>",
+			Long:    "Exports a snapshot of the state of the node at <height> to the directory <dir>. Any height the node has not pruned can be exported.",
+			Aliases: []string{"export"},
+			Args:    cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				height, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+
+				persistenceMod, err := newLocalPersistenceModule(snapshotConfigPath, snapshotGenesisPath)
+				if err != nil {
+					return err
+				}
+				defer persistenceMod.Stop()
+
+				if err := persistenceMod.ExportSnapshot(height, args[1]); err != nil {
+					return err
+				}
+				fmt.Printf("Exported the snapshot at height %d to %s\n", height, args[1])
+				return nil
+			},
+		},
+		{
+			Use:     "Import <dir> <stateHash>",
+			Short:   "Import <dir> <stateHash>",
+			Long:    "Replaces the state of the node with the snapshot in the directory <dir>, once verified against <stateHash>, the state hash of the block at the height of the snapshot obtained from a trusted source",
+			Aliases: []string{"import"},
+			Args:    cobra.ExactArgs(2),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				persistenceMod, err := newLocalPersistenceModule(snapshotConfigPath, snapshotGenesisPath)
+				if err != nil {
+					return err
+				}
+				defer persistenceMod.Stop()
+
+				if err := persistenceMod.ImportSnapshot(args[0], args[1]); err != nil {
+					return err
+				}
+				fmt.Printf("Imported the snapshot from %s\n", args[0])
+				return nil
+			},
+		},
+	}
+	return cmds
+}
+
+// `newLocalPersistenceModule` creates a persistence module opening the databases of a stopped node directly, e.g. to
+// export and import snapshots. The module is started so the commit interrupted by a crash of the node, if any, is
+// recovered before the stores are read or written.
+func newLocalPersistenceModule(configPath, genesisPath string) (modules.PersistenceModule, error) {
+	runtimeMgr := runtime.NewManagerFromFiles(configPath, genesisPath)
+	persistenceMod, err := persistence.Create(runtimeMgr.GetBus())
+	if err != nil {
+		return nil, err
+	}
+	if err := persistenceMod.Start(); err != nil {
+		persistenceMod.Stop()
+		return nil, fmt.Errorf("failed to recover the interrupted commit of the node: %w", err)
+	}
+	return persistenceMod.(modules.PersistenceModule), nil
+}
@@ -9,10 +9,8 @@ import (
 	"io"
 	"log"
 	"math/big"
-	"math/rand"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/pokt-network/pocket/rpc"
 	"github.com/pokt-network/pocket/shared/codec"
@@ -21,6 +19,7 @@ import (
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 // readEd25519PrivateKeyFromFile returns an Ed25519PrivateKey from a file where the file simply encodes it in a string (for now)
@@ -91,19 +90,19 @@ func confirmation(pwd string) bool {
 	}
 }
 
-// prepareTxBytes wraps a Message into a Transaction and signs it with the provided pk
+// prepareTxBytes wraps a Message into a Transaction paying the provided fee and signs it with the provided pk,
+// using the next sequence of the account of pk fetched from the node
 //
 // returns the raw protobuf bytes of the signed transaction
-func prepareTxBytes(msg typesUtil.Message, pk crypto.Ed25519PrivateKey) ([]byte, error) {
-	var err error
-	anyMsg, err := codec.GetCodec().ToAny(msg)
+func prepareTxBytes(ctx context.Context, msg typesUtil.Message, pk crypto.Ed25519PrivateKey, fee string) ([]byte, error) {
+	sequence, err := getNextSequence(ctx, pk.Address())
 	if err != nil {
 		return nil, err
 	}
 
-	tx := &typesUtil.Transaction{
-		Msg:   anyMsg,
-		Nonce: getNonce(),
+	tx, err := prepareUnsignedTx(msg, fee, sequence)
+	if err != nil {
+		return nil, err
 	}
 
 	signBytes, err := tx.SignBytes()
@@ -128,14 +127,33 @@ func prepareTxBytes(msg typesUtil.Message, pk crypto.Ed25519PrivateKey) ([]byte,
 	return bz, nil
 }
 
+// prepareUnsignedTx wraps a Message into a Transaction paying the provided fee with the provided sequence
+func prepareUnsignedTx(msg typesUtil.Message, fee string, sequence uint64) (*typesUtil.Transaction, error) {
+	anyMsg, err := codec.GetCodec().ToAny(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typesUtil.Transaction{
+		Msgs:     []*anypb.Any{anyMsg},
+		Fee:      fee,
+		Sequence: sequence,
+	}, nil
+}
+
 // postRawTx posts a signed transaction
 func postRawTx(ctx context.Context, pk crypto.Ed25519PrivateKey, j []byte) (*rpc.PostV1ClientBroadcastTxSyncResponse, error) {
+	return postRawTxFromAddress(ctx, pk.Address(), j)
+}
+
+// postRawTxFromAddress posts a transaction signed by the account of address
+func postRawTxFromAddress(ctx context.Context, address crypto.Address, j []byte) (*rpc.PostV1ClientBroadcastTxSyncResponse, error) {
 	client, err := rpc.NewClientWithResponses(remoteCLIURL)
 	if err != nil {
 		return nil, err
 	}
 	req := rpc.RawTXRequest{
-		Address:     pk.Address().String(),
+		Address:     address.String(),
 		RawHexBytes: hex.EncodeToString(j),
 	}
 
@@ -146,9 +164,20 @@ func postRawTx(ctx context.Context, pk crypto.Ed25519PrivateKey, j []byte) (*rpc
 	return resp, nil
 }
 
-func getNonce() string {
-	rand.Seed(time.Now().UTC().UnixNano())
-	return fmt.Sprintf("%d", rand.Uint64())
+// getNextSequence returns the sequence of the account at the latest height, which the next transaction it signs must use
+func getNextSequence(ctx context.Context, address crypto.Address) (uint64, error) {
+	client, err := rpc.NewClientWithResponses(remoteCLIURL)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.PostV1QueryAccountWithResponse(ctx, rpc.QueryAccount{Address: address.String()})
+	if err != nil {
+		return 0, fmt.Errorf("unable to get the sequence of the account %s from the RPC @ %s: %w", address, remoteCLIURL, err)
+	}
+	if resp.JSON200 == nil {
+		return 0, fmt.Errorf("unable to get the sequence of the account %s: %s", address, string(resp.Body))
+	}
+	return uint64(resp.JSON200.Sequence), nil
 }
 
 func readPassphrase(currPwd string) string {
@@ -177,9 +206,9 @@ func validateStakeAmount(amount string) error {
 	return nil
 }
 
-func applySubcommandOptions(cmds []*cobra.Command, cmdDef actorCmdDef) {
+func applySubcommandOptions(cmds []*cobra.Command, cmdOptions []cmdOption) {
 	for _, cmd := range cmds {
-		for _, opt := range cmdDef.Options {
+		for _, opt := range cmdOptions {
 			opt(cmd)
 		}
 	}
@@ -191,6 +220,18 @@ func attachPwdFlagToSubcommands() []cmdOption {
 	}}
 }
 
+func attachFeeFlagToSubcommands() []cmdOption {
+	return []cmdOption{func(c *cobra.Command) {
+		c.Flags().StringVar(&fee, "fee", "", "fee paid by the transaction in uPOKT, at least the minimum fee of its message (which is paid if empty)")
+	}}
+}
+
+func attachSequenceFlagToSubcommands() []cmdOption {
+	return []cmdOption{func(c *cobra.Command) {
+		c.Flags().Uint64Var(&sequence, "sequence", 0, "sequence of the signer used by the transaction (fetched from the node if not set)")
+	}}
+}
+
 func unableToConnectToRpc(err error) error {
 	fmt.Printf("❌ Unable to connect to the RPC @ %s\n\nError: %s", boldText(remoteCLIURL), err)
 	return nil
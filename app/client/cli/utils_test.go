@@ -2,12 +2,15 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"net/http/httptest"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,3 +38,26 @@ func Test_parseEd25519PrivateKeyFromReader_ValidPrivateKey(t *testing.T) {
 		t.Errorf("parseEd25519PrivateKeyFromFile() = %v, want %v", gotPk, validPk)
 	}
 }
+
+func Test_prepareTxBytes_UnreachableRpc(t *testing.T) {
+	server := httptest.NewServer(nil)
+	server.Close()
+
+	prevRemoteCLIURL := remoteCLIURL
+	remoteCLIURL = server.URL
+	t.Cleanup(func() { remoteCLIURL = prevRemoteCLIURL })
+
+	privateKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	pk := privateKey.(crypto.Ed25519PrivateKey)
+	msg := &typesUtil.MessageSend{
+		FromAddress: pk.Address(),
+		ToAddress:   pk.Address(),
+		Amount:      "1",
+	}
+
+	// the transaction is not signed with a sequence of 0 when the sequence of its signer cannot be fetched
+	bz, err := prepareTxBytes(context.Background(), msg, pk, "10000")
+	require.Error(t, err)
+	require.Nil(t, bz)
+}
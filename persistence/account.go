@@ -35,6 +35,34 @@ func (p PostgresContext) SetAccountAmount(address []byte, amount string) error {
 	})
 }
 
+// Returns 0 if the account does not exist
+func (p PostgresContext) GetAccountSequence(address []byte, height int64) (sequence uint64, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return
+	}
+	if err = tx.QueryRow(ctx, types.GetAccountSequenceQuery(hex.EncodeToString(address), height)).Scan(&sequence); err != errNoRows {
+		return
+	}
+	return 0, nil
+}
+
+func (p PostgresContext) SetAccountSequence(address []byte, sequence uint64) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+	height, err := p.GetHeight()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, types.InsertAccountSequence(hex.EncodeToString(address), sequence, height))
+	return err
+}
+
 func (p PostgresContext) GetAccountsUpdated(height int64) (accounts []*coreTypes.Account, err error) {
 	return p.getAccountsUpdated(types.Account, height)
 }
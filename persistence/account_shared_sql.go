@@ -3,7 +3,6 @@ package persistence
 import (
 	"math/big"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/pokt-network/pocket/persistence/types"
 	"github.com/pokt-network/pocket/shared/converters"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
@@ -14,12 +13,15 @@ const (
 )
 
 func (p *PostgresContext) getAccountAmount(accountSchema types.ProtocolAccountSchema, identifier string, height int64) (amount string, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return
 	}
 	amount = defaultAccountAmountStr
-	if err = tx.QueryRow(ctx, accountSchema.GetAccountAmountQuery(identifier, height)).Scan(&amount); err != pgx.ErrNoRows {
+	if err = tx.QueryRow(ctx, accountSchema.GetAccountAmountQuery(identifier, height)).Scan(&amount); err != errNoRows {
 		return
 	}
 
@@ -61,6 +63,9 @@ func (p *PostgresContext) operationAccountAmount(
 }
 
 func (p *PostgresContext) getAccountsUpdated(accountType types.ProtocolAccountSchema, height int64) (accounts []*coreTypes.Account, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	query := accountType.GetAccountsUpdatedAtHeightQuery(height)
 
 	ctx, tx, err := p.getCtxAndTx()
@@ -76,7 +81,7 @@ func (p *PostgresContext) getAccountsUpdated(accountType types.ProtocolAccountSc
 
 	for rows.Next() {
 		acc := new(coreTypes.Account)
-		if err = rows.Scan(&acc.Address, &acc.Amount); err != nil {
+		if err = rows.Scan(&acc.Address, &acc.Amount, &acc.Sequence); err != nil {
 			return nil, err
 		}
 		accounts = append(accounts, acc)
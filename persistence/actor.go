@@ -9,6 +9,9 @@ import (
 //	can easily be refactored and condensed into a single function using a generic type or a common
 //  interface.
 func (p PostgresContext) GetAllApps(height int64) (apps []*coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -38,6 +41,9 @@ func (p PostgresContext) GetAllApps(height int64) (apps []*coreTypes.Actor, err
 }
 
 func (p PostgresContext) GetAllValidators(height int64) (vals []*coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -68,6 +74,9 @@ func (p PostgresContext) GetAllValidators(height int64) (vals []*coreTypes.Actor
 }
 
 func (p PostgresContext) GetAllServiceNodes(height int64) (sn []*coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -97,6 +106,9 @@ func (p PostgresContext) GetAllServiceNodes(height int64) (sn []*coreTypes.Actor
 }
 
 func (p PostgresContext) GetAllFishermen(height int64) (f []*coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
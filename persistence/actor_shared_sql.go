@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"fmt"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/pokt-network/pocket/persistence/types"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/modules"
@@ -33,6 +32,9 @@ func UnstakingHeightToStatus(unstakingHeight int64) int32 {
 }
 
 func (p *PostgresContext) GetExists(actorSchema types.ProtocolActorSchema, address []byte, height int64) (exists bool, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return
@@ -46,6 +48,9 @@ func (p *PostgresContext) GetExists(actorSchema types.ProtocolActorSchema, addre
 }
 
 func (p *PostgresContext) GetActorsUpdated(actorSchema types.ProtocolActorSchema, height int64) (actors []*coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return
@@ -84,6 +89,9 @@ func (p *PostgresContext) GetActorsUpdated(actorSchema types.ProtocolActorSchema
 }
 
 func (p *PostgresContext) getActor(actorSchema types.ProtocolActorSchema, address []byte, height int64) (actor *coreTypes.Actor, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return
@@ -95,7 +103,7 @@ func (p *PostgresContext) getActor(actorSchema types.ProtocolActorSchema, addres
 	return p.getChainsForActor(ctx, tx, actorSchema, actor, height)
 }
 
-func (p *PostgresContext) getActorFromRow(actorType coreTypes.ActorType, row pgx.Row) (actor *coreTypes.Actor, height int64, err error) {
+func (p *PostgresContext) getActorFromRow(actorType coreTypes.ActorType, row SQLRow) (actor *coreTypes.Actor, height int64, err error) {
 	actor = &coreTypes.Actor{
 		ActorType: actorType,
 	}
@@ -113,7 +121,7 @@ func (p *PostgresContext) getActorFromRow(actorType coreTypes.ActorType, row pgx
 
 func (p *PostgresContext) getChainsForActor(
 	ctx context.Context,
-	tx pgx.Tx,
+	tx SQLTx,
 	actorSchema types.ProtocolActorSchema,
 	actor *coreTypes.Actor,
 	height int64,
@@ -154,10 +162,17 @@ func (p *PostgresContext) InsertActor(actorSchema types.ProtocolActorSchema, act
 		return err
 	}
 
-	_, err = tx.Exec(ctx, actorSchema.InsertQuery(
+	if _, err = tx.Exec(ctx, actorSchema.InsertQuery(
 		actor.Address, actor.PublicKey, actor.StakedAmount, actor.GenericParam,
-		actor.Output, actor.PausedHeight, actor.UnstakingHeight, actor.Chains,
-		height))
+		actor.Output, actor.PausedHeight, actor.UnstakingHeight,
+		height)); err != nil {
+		return err
+	}
+
+	if actorSchema.GetChainsTableName() == "" || actor.Chains == nil {
+		return nil
+	}
+	_, err = tx.Exec(ctx, actorSchema.InsertChainsQuery(actor.Address, actor.Chains, height))
 	return err
 }
 
@@ -181,7 +196,7 @@ func (p *PostgresContext) UpdateActor(actorSchema types.ProtocolActorSchema, act
 		if _, err = tx.Exec(ctx, types.NullifyChains(actor.Address, height, chainsTableName)); err != nil {
 			return err
 		}
-		if _, err = tx.Exec(ctx, actorSchema.UpdateChainsQuery(actor.Address, actor.Chains, height)); err != nil {
+		if _, err = tx.Exec(ctx, actorSchema.InsertChainsQuery(actor.Address, actor.Chains, height)); err != nil {
 			return err
 		}
 	}
@@ -216,6 +231,9 @@ func (p *PostgresContext) GetActorsReadyToUnstake(actorSchema types.ProtocolActo
 }
 
 func (p *PostgresContext) GetActorStatus(actorSchema types.ProtocolActorSchema, address []byte, height int64) (int32, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return UndefinedStakingStatus, err
+	}
 	var unstakingHeight int64
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
@@ -252,6 +270,9 @@ func (p *PostgresContext) SetActorUnstakingHeightAndStatus(actorSchema types.Pro
 }
 
 func (p *PostgresContext) GetActorPauseHeightIfExists(actorSchema types.ProtocolActorSchema, address []byte, height int64) (pausedHeight int64, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return types.DefaultBigInt, err
@@ -309,6 +330,9 @@ func (p PostgresContext) setActorStakeAmount(actorSchema types.ProtocolActorSche
 }
 
 func (p PostgresContext) GetActorOutputAddress(actorSchema types.ProtocolActorSchema, operatorAddr []byte, height int64) ([]byte, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -323,6 +347,9 @@ func (p PostgresContext) GetActorOutputAddress(actorSchema types.ProtocolActorSc
 }
 
 func (p PostgresContext) getActorStakeAmount(actorSchema types.ProtocolActorSchema, address []byte, height int64) (string, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return "", err
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return "", err
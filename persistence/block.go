@@ -27,6 +27,18 @@ func (p *persistenceModule) TransactionExists(transactionHash string) (bool, err
 	return true, err
 }
 
+func (p *persistenceModule) GetBlock(height int64) (*coreTypes.Block, error) {
+	blockBz, err := p.blockStore.Get(heightToBytes(height))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the block at height %d: %v", height, err)
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
 func (p PostgresContext) GetLatestBlockHeight() (latestHeight uint64, err error) {
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
@@ -38,6 +50,9 @@ func (p PostgresContext) GetLatestBlockHeight() (latestHeight uint64, err error)
 }
 
 func (p PostgresContext) GetBlockHash(height int64) (string, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return "", err
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return "", err
@@ -51,6 +66,16 @@ func (p PostgresContext) GetBlockHash(height int64) (string, error) {
 	return blockHash, nil
 }
 
+func (p PostgresContext) blockExists(height int64) (exists bool, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.QueryRow(ctx, types.BlockExistsQuery(height)).Scan(&exists)
+	return
+}
+
 func (p PostgresContext) GetHeight() (int64, error) {
 	return p.Height, nil
 }
@@ -79,8 +104,14 @@ func (p *PostgresContext) prepareBlock(proposerAddr, quorumCert []byte) (*coreTy
 		QuorumCertificate: quorumCert,
 		TransactionsHash:  txsHash,
 	}
+	txResults := p.pending.getTxResults(txsOrderInBlockHashDescending)
+	txs := make([][]byte, len(txResults))
+	for i, txResult := range txResults {
+		txs[i] = txResult.GetTx()
+	}
 	block := &coreTypes.Block{
-		BlockHeader: blockHeader,
+		BlockHeader:  blockHeader,
+		Transactions: txs,
 	}
 
 	return block, nil
@@ -102,13 +133,12 @@ func (p *PostgresContext) insertBlock(block *coreTypes.Block) error {
 	return err
 }
 
-// Stores the block in the key-value store
-func (p PostgresContext) storeBlock(block *coreTypes.Block) error {
-	blockBz, err := codec.GetCodec().Marshal(block)
-	if err != nil {
-		return err
-	}
-	return p.blockStore.Set(heightToBytes(p.Height), blockBz)
+// The results of the transactions of a block are stored in the block store under a key distinct from the key of the
+// block, which is its height
+var blockTxResultsKeyPrefix = []byte("tx_results/")
+
+func blockTxResultsKey(height int64) []byte {
+	return append(append([]byte{}, blockTxResultsKeyPrefix...), heightToBytes(height)...)
 }
 
 func heightToBytes(height int64) []byte {
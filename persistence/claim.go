@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"encoding/hex"
+
+	"github.com/pokt-network/pocket/persistence/types"
+)
+
+func (p PostgresContext) InsertClaim(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain, geoZone string, merkleRoot []byte) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, types.InsertClaimQuery(hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, geoZone, hex.EncodeToString(merkleRoot), p.Height))
+	return err
+}
+
+func (p PostgresContext) SetClaimProvenHeight(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, types.SetClaimProvenHeightQuery(hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, provenHeight))
+	return err
+}
+
+func (p PostgresContext) GetClaim(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (geoZone string, merkleRoot []byte, claimHeight, provenHeight int64, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return
+	}
+
+	var merkleRootHex string
+	if err = tx.QueryRow(ctx, types.GetClaimQuery(hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, height)).Scan(&geoZone, &merkleRootHex, &claimHeight, &provenHeight); err != nil {
+		return
+	}
+
+	merkleRoot, err = hex.DecodeString(merkleRootHex)
+	return
+}
+
+func (p PostgresContext) GetClaimExists(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (exists bool, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return
+	}
+
+	err = tx.QueryRow(ctx, types.ClaimExistsQuery(hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, height)).Scan(&exists)
+	return
+}
@@ -0,0 +1,240 @@
+package persistence
+
+import (
+	"log"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
+/*
+	The commit of a block spans multiple stores that cannot be updated atomically: the SQL database, the block
+	store, the transaction indexer and the state trees. To make it crash-consistent, every commit is first written
+	to a write-ahead journal with everything needed to redo the writes it makes outside of the SQL database.
+
+	The commit of the SQL transaction is the point of no return of a commit:
+	  - If the node crashes before it, none of the writes of the commit are durable and the journaled commit is
+	    rolled back (i.e. discarded) when the persistence module starts
+	  - If the node crashes after it, the journaled writes are replayed when the persistence module starts
+
+	The journal is cleared once all the stores are up to date.
+*/
+
+// `CommitStep` identifies the steps of a commit after which it can be interrupted
+type CommitStep int
+
+const (
+	CommitStepWriteJournal CommitStep = iota
+	CommitStepSQL
+	CommitStepBlockStore
+	CommitStepTxIndexer
+	CommitStepStateTrees
+	CommitStepClearJournal
+)
+
+// `CommitFaultInjector` is called before every step of a commit, and a non-nil error interrupts the commit at that
+// step the same way a crash would. It is only injected for fault-injection testing purposes; see
+// `CreateWithCommitFaultInjector`.
+type CommitFaultInjector func(step CommitStep) error
+
+// The journal holds at most one commit at a time: the one in progress
+var commitJournalKey = []byte("commit")
+
+type commitJournal struct {
+	store         kvstore.KVStore
+	faultInjector CommitFaultInjector // nil unless injected by a test
+}
+
+func newCommitJournal(commitJournalPath string, faultInjector CommitFaultInjector) (*commitJournal, error) {
+	if commitJournalPath == "" {
+		return &commitJournal{store: kvstore.NewMemKVStore(), faultInjector: faultInjector}, nil
+	}
+	store, err := kvstore.NewSyncKVStore(commitJournalPath)
+	if err != nil {
+		return nil, err
+	}
+	return &commitJournal{store: store, faultInjector: faultInjector}, nil
+}
+
+func (j *commitJournal) write(entry *types.CommitJournalEntry) error {
+	entryBz, err := codec.GetCodec().Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return j.store.Set(commitJournalKey, entryBz)
+}
+
+// `get` returns the journaled commit or nil if there is none
+func (j *commitJournal) get() (*types.CommitJournalEntry, error) {
+	entryBz, err := j.store.Get(commitJournalKey)
+	if err != nil {
+		if err.Error() == kvstore.BadgerKeyNotFoundError {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := new(types.CommitJournalEntry)
+	if err := codec.GetCodec().Unmarshal(entryBz, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (j *commitJournal) clear() error {
+	return j.store.Delete(commitJournalKey)
+}
+
+func (j *commitJournal) stop() error {
+	return j.store.Stop()
+}
+
+// `newCommitJournalEntry` journals the block and all the writes of the context staged outside of the SQL transaction
+func (p PostgresContext) newCommitJournalEntry(block *coreTypes.Block) (*types.CommitJournalEntry, error) {
+	blockBz, err := codec.GetCodec().Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	txResults := p.pending.getTxResults(txsOrderInBlockHashDescending)
+	txResultsBz := make([][]byte, len(txResults))
+	for i, txResult := range txResults {
+		if txResultsBz[i], err = txResult.Bytes(); err != nil {
+			return nil, err
+		}
+	}
+	eventsBz, err := marshalEvents(p.pending.events)
+	if err != nil {
+		return nil, err
+	}
+	return &types.CommitJournalEntry{
+		Height:    p.Height,
+		Block:     blockBz,
+		TxResults: txResultsBz,
+		Trees:     p.stateTrees.getStagedWrites(),
+		Events:    eventsBz,
+	}, nil
+}
+
+// `apply` performs the writes of a journaled commit that live outside of the SQL database. All of them are
+// idempotent so an interrupted commit can be replayed from its journal entry.
+func (j *commitJournal) apply(entry *types.CommitJournalEntry, blockStore kvstore.KVStore, txIndexer indexer.TxIndexer, trees *stateTrees) error {
+	if err := j.injectFault(CommitStepBlockStore); err != nil {
+		return err
+	}
+	if err := blockStore.Set(heightToBytes(entry.Height), entry.Block); err != nil {
+		return err
+	}
+	blockTxResultsBz, err := codec.GetCodec().Marshal(&types.BlockTxResults{TxResults: entry.TxResults})
+	if err != nil {
+		return err
+	}
+	if err := blockStore.Set(blockTxResultsKey(entry.Height), blockTxResultsBz); err != nil {
+		return err
+	}
+	if err := storeBlockEvents(blockStore, entry.Height, entry.Events); err != nil {
+		return err
+	}
+
+	if err := j.injectFault(CommitStepTxIndexer); err != nil {
+		return err
+	}
+	for _, txResultBz := range entry.TxResults {
+		txResult, err := new(indexer.TxRes).FromBytes(txResultBz)
+		if err != nil {
+			return err
+		}
+		if err := txIndexer.Index(txResult); err != nil {
+			return err
+		}
+	}
+
+	if err := j.injectFault(CommitStepStateTrees); err != nil {
+		return err
+	}
+	return trees.applyJournaledWrites(entry.Height, entry.Trees)
+}
+
+// `recoverCommit` replays or rolls back the commit left in the journal by a crash, if any
+func (m *persistenceModule) recoverCommit() error {
+	entry, err := m.commitJournal.get()
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return nil
+	}
+
+	isCommitted, err := m.isBlockCommitted(entry.Height)
+	if err != nil {
+		return err
+	}
+	if isCommitted {
+		log.Printf("Replaying the interrupted commit of height %d...\n", entry.Height)
+		if err := m.commitJournal.apply(entry, m.blockStore, m.txIndexer, m.stateTrees); err != nil {
+			return err
+		}
+	} else {
+		// Nothing is written outside of the SQL database before its transaction is committed,
+		// so discarding the journal is enough to roll the commit back
+		log.Printf("Rolling back the interrupted commit of height %d...\n", entry.Height)
+	}
+	return m.commitJournal.clear()
+}
+
+func (m *persistenceModule) isBlockCommitted(height int64) (bool, error) {
+	readContext, err := m.NewReadContext(height)
+	if err != nil {
+		return false, err
+	}
+	defer readContext.Close()
+	return readContext.(PostgresContext).blockExists(height)
+}
+
+func (j *commitJournal) injectFault(step CommitStep) error {
+	if j.faultInjector == nil {
+		return nil
+	}
+	return j.faultInjector(step)
+}
+
+func newCommitJournalWrites(keys, values [][]byte) []*types.CommitJournalWrite {
+	writes := make([]*types.CommitJournalWrite, len(keys))
+	for i, key := range keys {
+		writes[i] = &types.CommitJournalWrite{
+			Key:     key,
+			Value:   values[i],
+			Deleted: values[i] == nil,
+		}
+	}
+	return writes
+}
+
+// `commitJournalWritesToKeyValues` is the inverse of `newCommitJournalWrites`
+func commitJournalWritesToKeyValues(writes []*types.CommitJournalWrite) (keys, values [][]byte) {
+	keys = make([][]byte, len(writes))
+	values = make([][]byte, len(writes))
+	for i, write := range writes {
+		keys[i] = write.Key
+		if !write.Deleted {
+			values[i] = write.Value
+		}
+	}
+	return keys, values
+}
+
+func applyCommitJournalWrites(store kvstore.KVStore, writes []*types.CommitJournalWrite) error {
+	for _, write := range writes {
+		if write.Deleted {
+			if err := store.Delete(write.Key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := store.Set(write.Key, write.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
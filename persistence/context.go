@@ -4,11 +4,14 @@ package persistence
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
 	"log"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/pokt-network/pocket/persistence/indexer"
 	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/modules"
 )
 
@@ -17,27 +20,77 @@ var _ modules.PersistenceRWContext = &PostgresContext{}
 // TECHDEBT: All the functions of `PostgresContext` should be organized in appropriate packages and use pointer receivers
 type PostgresContext struct {
 	Height int64 // TECHDEBT: `Height` is only externalized for testing purposes. Replace with a `Debug` interface containing helpers
-	conn   *pgx.Conn
-	tx     pgx.Tx
+	conn   SQLConn
+	tx     SQLTx
 
 	stateHash string
 
-	// TECHDEBT(#361): These three values are pointers to objects maintained by the PersistenceModule.
+	// The transactions indexed, the events emitted and the save points created in this context, which are only persisted on commit
+	pending *pendingWrites
+
+	// TECHDEBT(#361): These values are pointers to objects maintained by the PersistenceModule.
 	//                 Need to simply access them via the bus.
-	blockStore kvstore.KVStore
-	txIndexer  indexer.TxIndexer
-	stateTrees *stateTrees
+	blockStore    kvstore.KVStore
+	txIndexer     indexer.TxIndexer
+	stateTrees    *stateTrees
+	commitJournal *commitJournal
+	pruner        *pruner
 }
 
+// `NewSavePoint` creates a SQL save point keyed by the hash of the transaction about to be applied, alongside
+// a save point of the pending transactions and of the merkle trees so all of them can be rolled back together.
 func (p PostgresContext) NewSavePoint(bytes []byte) error {
-	log.Println("TODO: NewSavePoint not implemented")
+	txHash := hex.EncodeToString(bytes)
+	if p.pending.getSavePointIndex(txHash) != -1 {
+		return fmt.Errorf("save point already exists for transaction %s", txHash)
+	}
+
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+	sqlName := types.SavePointName(len(p.pending.savePoints))
+	if _, err := tx.Exec(ctx, types.SavePointQuery(sqlName)); err != nil {
+		return err
+	}
+
+	p.pending.savePoints = append(p.pending.savePoints, savePoint{
+		txHash:       txHash,
+		sqlName:      sqlName,
+		numTxResults: len(p.pending.txResults),
+		numEvents:    len(p.pending.events),
+		trees:        p.stateTrees.savePoint(),
+	})
 	return nil
 }
 
-// TECHDEBT(#327): Guarantee atomicity betweens `prepareBlock`, `insertBlock` and `storeBlock` for save points & rollbacks.
+// `RollbackToSavePoint` reverts every write made since the save point keyed by the transaction hash was
+// created, leaving the rest of the context (i.e. the previous transactions of the block) untouched.
+// The save point, and any save point created after it, are released.
 func (p PostgresContext) RollbackToSavePoint(bytes []byte) error {
-	log.Println("TODO: RollbackToSavePoint not fully implemented")
-	return p.getTx().Rollback(context.TODO())
+	txHash := hex.EncodeToString(bytes)
+	index := p.pending.getSavePointIndex(txHash)
+	if index == -1 {
+		return fmt.Errorf("save point not found for transaction %s", txHash)
+	}
+	savePoint := p.pending.savePoints[index]
+
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, types.RollbackToSavePointQuery(savePoint.sqlName)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, types.ReleaseSavePointQuery(savePoint.sqlName)); err != nil {
+		return err
+	}
+
+	p.pending.txResults = p.pending.txResults[:savePoint.numTxResults]
+	p.pending.events = p.pending.events[:savePoint.numEvents]
+	p.pending.savePoints = p.pending.savePoints[:index]
+	p.stateTrees.rollbackToSavePoint(savePoint.trees)
+	return nil
 }
 
 // IMPROVE(#361): Guarantee the integrity of the state
@@ -51,7 +104,8 @@ func (p *PostgresContext) ComputeStateHash() (string, error) {
 	return p.stateHash, nil
 }
 
-// TECHDEBT(#327): Make sure these operations are atomic
+// The commit is made crash-consistent across the SQL DB, the block store, the tx indexer and the state trees
+// with a write-ahead journal; see `commit_journal.go` for details.
 func (p PostgresContext) Commit(proposerAddr, quorumCert []byte) error {
 	log.Printf("About to commit block & context at height %d.\n", p.Height)
 
@@ -61,25 +115,47 @@ func (p PostgresContext) Commit(proposerAddr, quorumCert []byte) error {
 		return err
 	}
 
-	// Store block in the KV store
-	if err := p.storeBlock(block); err != nil {
+	// Journal the commit before any of its writes is made durable
+	entry, err := p.newCommitJournalEntry(block)
+	if err != nil {
+		return err
+	}
+	if err := p.commitJournal.injectFault(CommitStepWriteJournal); err != nil {
+		return err
+	}
+	if err := p.commitJournal.write(entry); err != nil {
 		return err
 	}
 
-	// Insert the block into the SQL DB
+	// Insert the block into the SQL DB and commit the SQL transaction; from here on the commit is replayed if interrupted
+	if err := p.commitJournal.injectFault(CommitStepSQL); err != nil {
+		return err
+	}
 	if err := p.insertBlock(block); err != nil {
 		return err
 	}
-
-	// Commit the SQL transaction
 	ctx := context.TODO()
 	if err := p.getTx().Commit(ctx); err != nil {
 		return err
 	}
 	if err := p.conn.Close(ctx); err != nil {
-		log.Println("[TODO][ERROR] Implement connection pooling. Error when closing DB connecting...", err)
+		log.Println("[ERROR] Error releasing the DB connection of the write context...", err)
 	}
 
+	// Store the block and its events in the KV store, index the transactions and persist the state trees
+	if err := p.commitJournal.apply(entry, p.blockStore, p.txIndexer, p.stateTrees); err != nil {
+		return err
+	}
+	p.pending.reset()
+
+	if err := p.commitJournal.injectFault(CommitStepClearJournal); err != nil {
+		return err
+	}
+	if err := p.commitJournal.clear(); err != nil {
+		return err
+	}
+
+	p.pruner.onCommit(p.Height)
 	return nil
 }
 
@@ -89,6 +165,7 @@ func (p PostgresContext) Release() error {
 	if err := p.getTx().Rollback(ctx); err != nil {
 		return err
 	}
+	p.discardPendingWrites()
 	if err := p.resetContext(); err != nil {
 		return err
 	}
@@ -101,8 +178,28 @@ func (p PostgresContext) Close() error {
 }
 
 // INVESTIGATE(#361): Revisit if is used correctly in the context of the lifecycle of a persistenceContext and a utilityContext
+// The transaction is only indexed once the context is committed, so it can be rolled back with a save point until then.
 func (p PostgresContext) IndexTransaction(txResult modules.TxResult) error {
-	return p.txIndexer.Index(txResult)
+	p.pending.txResults = append(p.pending.txResults, txResult)
+	return nil
+}
+
+// The event is only stored once the context is committed, so it can be rolled back with a save point until then.
+// Its height and index are set by the context.
+func (p PostgresContext) EmitEvent(event *coreTypes.StateChangeEvent) error {
+	event.Height = p.Height
+	event.Index = int32(len(p.pending.events))
+	p.pending.events = append(p.pending.events, event)
+	return nil
+}
+
+// `discardPendingWrites` drops the writes staged outside of the SQL transaction of the context
+func (p *PostgresContext) discardPendingWrites() {
+	if p == nil || p.pending == nil {
+		return
+	}
+	p.pending.reset()
+	p.stateTrees.discard()
 }
 
 func (p *PostgresContext) resetContext() (err error) {
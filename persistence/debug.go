@@ -1,11 +1,9 @@
 package persistence
 
 import (
-	"crypto/sha256"
 	"log"
 	"runtime/debug"
 
-	"github.com/celestiaorg/smt"
 	"github.com/pokt-network/pocket/persistence/types"
 	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
@@ -19,6 +17,8 @@ var nonActorClearFunctions = []func() string{
 	types.ClearAllGovParamsQuery,
 	types.ClearAllGovFlagsQuery,
 	types.ClearAllBlocksQuery,
+	types.ClearAllClaimsQuery,
+	types.ClearAllTestScoresQuery,
 }
 
 func (m *persistenceModule) HandleDebugMessage(debugMessage *messaging.DebugMessage) error {
@@ -89,6 +89,11 @@ func (m *persistenceModule) clearAllState(_ *messaging.DebugMessage) error {
 		return err
 	}
 
+	// Nothing is pruned anymore
+	if err := m.pruner.reset(); err != nil {
+		return err
+	}
+
 	log.Println("Cleared all the state")
 	// reclaming memory manually because the above calls deallocate and reallocate a lot of memory
 	debug.FreeOSMemory()
@@ -137,8 +142,8 @@ func (p *PostgresContext) clearAllTreeState() error {
 			return err
 		}
 
-		// Needed in order to make sure the root is re-set correctly after clearing
-		p.stateTrees.merkleTrees[treeType] = smt.NewSparseMerkleTree(valueStore, nodeStore, sha256.New())
+		// Needed in order to make sure the root (and any staged update) is re-set correctly after clearing
+		p.stateTrees.resetTree(treeType)
 	}
 
 	return nil
@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
+/*
+	The state change events emitted by the utility module while applying a block are stored in the block store
+	alongside the block, under `events/<height>`. Every address an event is about, as its `address` or its
+	`counterparty`, is indexed under `events_by_address/<address>/<height>` so the events of an address can be
+	found without going through every block. The events are pruned along with their block.
+*/
+
+var (
+	blockEventsKeyPrefix     = []byte("events/")
+	eventsByAddressKeyPrefix = []byte("events_by_address/")
+)
+
+// `GetEventsByHeight` returns the state change events of the block committed at `height`, in the order they were emitted
+func (m *persistenceModule) GetEventsByHeight(height int64) ([]*coreTypes.StateChangeEvent, error) {
+	if err := m.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
+	if _, err := m.blockStore.Get(heightToBytes(height)); err != nil {
+		return nil, fmt.Errorf("unable to get the block at height %d: %v", height, err)
+	}
+	return getBlockEvents(m.blockStore, height)
+}
+
+// `GetEventsByAddress` returns the state change events about `address` across all the retained heights, ordered by height
+func (m *persistenceModule) GetEventsByAddress(address []byte) ([]*coreTypes.StateChangeEvent, error) {
+	addressHex := hex.EncodeToString(address)
+	it, err := m.blockStore.Iterator(eventsByAddressPrefix(addressHex), nil, false)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	events := make([]*coreTypes.StateChangeEvent, 0)
+	for it.Next() {
+		height := int64(binary.BigEndian.Uint64(it.Key()[len(it.Key())-8:]))
+		if m.pruner.checkHeight(height) != nil {
+			continue
+		}
+		blockEvents, err := getBlockEvents(m.blockStore, height)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range blockEvents {
+			if event.Address == addressHex || event.Counterparty == addressHex {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, it.Err()
+}
+
+// `storeBlockEvents` stores the serialized events of the block at `height` and indexes them by address
+func storeBlockEvents(blockStore kvstore.KVStore, height int64, eventsBz [][]byte) error {
+	events, err := unmarshalEvents(eventsBz)
+	if err != nil {
+		return err
+	}
+	blockEventsBz, err := codec.GetCodec().Marshal(&types.BlockEvents{Events: eventsBz})
+	if err != nil {
+		return err
+	}
+	if err := blockStore.Set(blockEventsKey(height), blockEventsBz); err != nil {
+		return err
+	}
+	for _, key := range eventsByAddressKeys(height, events) {
+		if err := blockStore.Set(key, []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `deleteBlockEvents` deletes the events of the block at `height` along with their index by address
+func deleteBlockEvents(blockStore kvstore.KVStore, height int64) error {
+	events, err := getBlockEvents(blockStore, height)
+	if err != nil {
+		return err
+	}
+	for _, key := range eventsByAddressKeys(height, events) {
+		if err := blockStore.Delete(key); err != nil {
+			return err
+		}
+	}
+	return blockStore.Delete(blockEventsKey(height))
+}
+
+// `getBlockEvents` returns the events of the block at `height`, or none if it has none or was committed before the
+// events were stored
+func getBlockEvents(blockStore kvstore.KVStore, height int64) ([]*coreTypes.StateChangeEvent, error) {
+	blockEventsBz, err := blockStore.Get(blockEventsKey(height))
+	if err != nil {
+		if err.Error() == kvstore.BadgerKeyNotFoundError {
+			return []*coreTypes.StateChangeEvent{}, nil
+		}
+		return nil, err
+	}
+	blockEvents := new(types.BlockEvents)
+	if err := codec.GetCodec().Unmarshal(blockEventsBz, blockEvents); err != nil {
+		return nil, err
+	}
+	return unmarshalEvents(blockEvents.Events)
+}
+
+func marshalEvents(events []*coreTypes.StateChangeEvent) ([][]byte, error) {
+	eventsBz := make([][]byte, len(events))
+	for i, event := range events {
+		eventBz, err := codec.GetCodec().Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		eventsBz[i] = eventBz
+	}
+	return eventsBz, nil
+}
+
+func unmarshalEvents(eventsBz [][]byte) ([]*coreTypes.StateChangeEvent, error) {
+	events := make([]*coreTypes.StateChangeEvent, len(eventsBz))
+	for i, eventBz := range eventsBz {
+		events[i] = new(coreTypes.StateChangeEvent)
+		if err := codec.GetCodec().Unmarshal(eventBz, events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+func blockEventsKey(height int64) []byte {
+	return append(append([]byte{}, blockEventsKeyPrefix...), heightToBytes(height)...)
+}
+
+func eventsByAddressPrefix(addressHex string) []byte {
+	return append(append([]byte{}, eventsByAddressKeyPrefix...), addressHex+"/"...)
+}
+
+// `eventsByAddressKeys` returns the keys indexing the block at `height` by every address its events are about
+func eventsByAddressKeys(height int64, events []*coreTypes.StateChangeEvent) [][]byte {
+	keys := make([][]byte, 0)
+	for _, event := range events {
+		for _, addressHex := range []string{event.Address, event.Counterparty} {
+			if addressHex == "" {
+				continue
+			}
+			key := append(eventsByAddressPrefix(addressHex), uint64ToBytes(uint64(height))...)
+			if !containsKey(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
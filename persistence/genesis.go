@@ -2,8 +2,10 @@ package persistence
 
 import (
 	"encoding/hex"
+	"fmt"
 	"log"
 	"math/big"
+	"strconv"
 
 	"github.com/pokt-network/pocket/persistence/types"
 	"github.com/pokt-network/pocket/runtime/genesis"
@@ -44,6 +46,12 @@ func (m *persistenceModule) populateGenesisState(state *genesis.GenesisState) {
 		if err != nil {
 			log.Fatalf("an error occurred inserting an acc in the genesis state: %s", err.Error())
 		}
+		// The accounts of a genesis exported from a live network keep their sequence
+		if acc.GetSequence() != 0 {
+			if err = rwContext.SetAccountSequence(addrBz, acc.GetSequence()); err != nil {
+				log.Fatalf("an error occurred inserting the sequence of an acc in the genesis state: %s", err.Error())
+			}
+		}
 	}
 	for _, pool := range state.GetPools() {
 		err = rwContext.InsertPool(pool.GetAddress(), pool.GetAmount()) // pool.GetAddress() returns the pool's semantic name
@@ -117,12 +125,28 @@ func (m *persistenceModule) populateGenesisState(state *genesis.GenesisState) {
 	if err = rwContext.InitFlags(); err != nil { // TODO (Team) use flags from genesis file not hardcoded
 		log.Fatalf("an error occurred initializing flags: %s", err.Error())
 	}
+	// The flags of a genesis state exported from the state of a node
+	for _, flag := range state.GetFlags() {
+		enabled, err := strconv.ParseBool(flag.GetEnabled())
+		if err != nil {
+			log.Fatalf("an error occurred parsing the flag %s in the genesis state: %s", flag.GetName(), err.Error())
+		}
+		if err = rwContext.SetFlag(flag.GetName(), flag.GetValue(), enabled); err != nil {
+			log.Fatalf("an error occurred inserting the flag %s in the genesis state: %s", flag.GetName(), err.Error())
+		}
+	}
 
 	// Updates all the merkle trees
 	stateHash, err := rwContext.ComputeStateHash()
 	if err != nil {
 		log.Fatalf("an error occurred updating the app hash during genesis: %s", err.Error())
 	}
+	// The trees of a genesis state exported from the state of a node
+	if len(state.GetStateTrees()) > 0 {
+		if stateHash, err = rwContext.(*PostgresContext).importGenesisTrees(state.GetStateTrees()); err != nil {
+			log.Fatalf("an error occurred importing the state trees of the genesis state: %s", err.Error())
+		}
+	}
 	log.Println("PopulateGenesisState - computed state hash:", stateHash)
 
 	// This updates the DB, blockstore, and commits the genesis state.
@@ -132,11 +156,174 @@ func (m *persistenceModule) populateGenesisState(state *genesis.GenesisState) {
 	}
 }
 
+// `ExportGenesis` exports the state committed at `height` (i.e. its accounts, pools, actors, params and flags) as a
+// genesis state. The genesis time, chain ID and max block bytes are the ones of the genesis of the node.
+//
+// The transactions, params and flags trees also commit to the transactions and to the previous values of the params
+// and flags, so they are exported along with the state and imported as they are: populating a node with the exported
+// genesis state recreates the state hash of `height`.
+func (m *persistenceModule) ExportGenesis(height int64) (*genesis.GenesisState, error) {
+	if _, err := m.blockStore.Get(heightToBytes(height)); err != nil {
+		return nil, fmt.Errorf("unable to get the block at height %d: %v", height, err)
+	}
+	readCtx, err := m.NewReadContext(height)
+	if err != nil {
+		return nil, err
+	}
+	defer readCtx.Close()
+	p := readCtx.(PostgresContext)
+
+	state := &genesis.GenesisState{
+		GenesisTime:   m.genesisState.GetGenesisTime(),
+		ChainId:       m.genesisState.GetChainId(),
+		MaxBlockBytes: m.genesisState.GetMaxBlockBytes(),
+	}
+	if state.Accounts, err = p.GetAllAccounts(height); err != nil {
+		return nil, err
+	}
+	if state.Pools, err = p.GetAllPools(height); err != nil {
+		return nil, err
+	}
+	if state.Applications, err = p.GetAllApps(height); err != nil {
+		return nil, err
+	}
+	if state.Validators, err = p.GetAllValidators(height); err != nil {
+		return nil, err
+	}
+	if state.ServiceNodes, err = p.GetAllServiceNodes(height); err != nil {
+		return nil, err
+	}
+	if state.Fishermen, err = p.GetAllFishermen(height); err != nil {
+		return nil, err
+	}
+	if state.Params, state.Flags, err = p.getGenesisParamsAndFlags(height); err != nil {
+		return nil, err
+	}
+	if state.StateTrees, err = m.exportGenesisTrees(height); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// The trees of a genesis state that cannot be rebuilt from its accounts, pools, actors, params and flags
+var genesisStateTrees = []merkleTree{transactionsMerkleTree, paramsMerkleTree, flagsMerkleTree}
+
+// `exportGenesisTrees` exports the nodes and the values of the trees of `genesisStateTrees` at `height`
+func (m *persistenceModule) exportGenesisTrees(height int64) ([]*genesis.StateTree, error) {
+	treesAtHeight, err := m.stateTrees.AtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	stateTrees := make([]*genesis.StateTree, 0, len(genesisStateTrees))
+	for _, tree := range genesisStateTrees {
+		stateTree := &genesis.StateTree{
+			Name: merkleTreeToString[tree],
+			Root: treesAtHeight.roots[int(tree)],
+		}
+		onNode := func(nodeHash, node []byte) error {
+			stateTree.Nodes = append(stateTree.Nodes, &genesis.StateTreeEntry{Key: nodeHash, Value: node})
+			return nil
+		}
+		onLeaf := func(path, value []byte) error {
+			stateTree.Values = append(stateTree.Values, &genesis.StateTreeEntry{Key: path, Value: value})
+			return nil
+		}
+		if err := walkTree(tree, treesAtHeight.nodeStores[tree], treesAtHeight.valueStores[tree], stateTree.Root, onNode, onLeaf); err != nil {
+			return nil, err
+		}
+		stateTrees = append(stateTrees, stateTree)
+	}
+	return stateTrees, nil
+}
+
+// `importGenesisTrees` replaces the updates of the trees computed from a genesis state with the trees it was exported
+// with, so they are committed instead, and returns the resulting state hash
+func (p *PostgresContext) importGenesisTrees(stateTrees []*genesis.StateTree) (string, error) {
+	if len(stateTrees) != len(genesisStateTrees) {
+		return "", fmt.Errorf("expected %d state trees in the genesis state, got %d", len(genesisStateTrees), len(stateTrees))
+	}
+	for i, tree := range genesisStateTrees {
+		stateTree := stateTrees[i]
+		if stateTree.GetName() != merkleTreeToString[tree] {
+			return "", fmt.Errorf("expected the %s tree in the genesis state, got %s", merkleTreeToString[tree], stateTree.GetName())
+		}
+		nodes, values := p.stateTrees.stagedNodeStores[tree], p.stateTrees.stagedValueStores[tree]
+		nodes.Discard()
+		values.Discard()
+		for _, entry := range stateTree.GetNodes() {
+			if err := nodes.Set(entry.GetKey(), entry.GetValue()); err != nil {
+				return "", err
+			}
+		}
+		for _, entry := range stateTree.GetValues() {
+			if err := values.Set(entry.GetKey(), entry.GetValue()); err != nil {
+				return "", err
+			}
+		}
+		// The imported nodes and values are authenticated by the root of the tree
+		if err := walkTree(tree, nodes, values, stateTree.GetRoot(), nil, func(path, value []byte) error { return nil }); err != nil {
+			return "", err
+		}
+		p.stateTrees.merkleTrees[tree].SetRoot(stateTree.GetRoot())
+	}
+	p.stateHash = p.getStateHash()
+	return p.stateHash, nil
+}
+
+// `getGenesisParamsAndFlags` returns the latest value of every param and flag at `height`
+func (p PostgresContext) getGenesisParamsAndFlags(height int64) (params *genesis.Params, flags []*coreTypes.Flag, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rowHeight int64
+	var valType string
+
+	params = new(genesis.Params)
+	rows, err := tx.Query(ctx, types.SelectGovRows(types.ParamsTableName, height))
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &rowHeight, &valType, &value); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		if err = types.SetGenesisParam(params, name, value); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+	}
+	rows.Close()
+
+	flags = make([]*coreTypes.Flag, 0)
+	rows, err = tx.Query(ctx, types.SelectGovRows(types.FlagsTableName, height))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var enabled bool
+		flag := new(coreTypes.Flag)
+		if err = rows.Scan(&flag.Name, &rowHeight, &valType, &flag.Value, &enabled); err != nil {
+			return nil, nil, err
+		}
+		flag.Enabled = strconv.FormatBool(enabled)
+		flags = append(flags, flag)
+	}
+	return params, flags, nil
+}
+
 // TODO (#399): All of the functions below following a structure similar to `GetAll<Actor>`
 //
 //		can easily be refactored and condensed into a single function using a generic type or a common
 //	 interface.
 func (p PostgresContext) GetAllAccounts(height int64) (accs []*coreTypes.Account, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -147,7 +334,7 @@ func (p PostgresContext) GetAllAccounts(height int64) (accs []*coreTypes.Account
 	}
 	for rows.Next() {
 		acc := new(coreTypes.Account)
-		if err = rows.Scan(&acc.Address, &acc.Amount, &height); err != nil {
+		if err = rows.Scan(&acc.Address, &acc.Amount, &acc.Sequence, &height); err != nil {
 			return nil, err
 		}
 		// acc.Address, err = address
@@ -161,6 +348,9 @@ func (p PostgresContext) GetAllAccounts(height int64) (accs []*coreTypes.Account
 
 // CLEANUP: Consolidate with GetAllAccounts.
 func (p PostgresContext) GetAllPools(height int64) (accs []*coreTypes.Account, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -171,7 +361,7 @@ func (p PostgresContext) GetAllPools(height int64) (accs []*coreTypes.Account, e
 	}
 	for rows.Next() {
 		pool := new(coreTypes.Account)
-		if err = rows.Scan(&pool.Address, &pool.Amount, &height); err != nil {
+		if err = rows.Scan(&pool.Address, &pool.Amount, &pool.Sequence, &height); err != nil {
 			return nil, err
 		}
 		accs = append(accs, pool)
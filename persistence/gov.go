@@ -150,6 +150,9 @@ func setParamOrFlag[T types.SupportedParamTypes](p PostgresContext, paramName st
 }
 
 func getParamOrFlag[T int | string | []byte](p PostgresContext, tableName, paramName string, height int64) (i T, enabled bool, err error) {
+	if err = p.pruner.checkHeight(height); err != nil {
+		return
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return i, enabled, err
@@ -182,6 +185,9 @@ func getParamOrFlag[T int | string | []byte](p PostgresContext, tableName, param
 }
 
 func (p PostgresContext) getParamsUpdated(height int64) ([]*coreTypes.Param, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
@@ -207,6 +213,9 @@ func (p PostgresContext) getParamsUpdated(height int64) ([]*coreTypes.Param, err
 }
 
 func (p PostgresContext) getFlagsUpdated(height int64) ([]*coreTypes.Flag, error) {
+	if err := p.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
 	ctx, tx, err := p.getCtxAndTx()
 	if err != nil {
 		return nil, err
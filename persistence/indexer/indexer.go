@@ -34,6 +34,10 @@ type TxIndexer interface {
 	// GetByRecipient returns all transactions *sent to address*; may be ordered descending/ascending
 	GetByRecipient(recipient string, descending bool) ([]shared.TxResult, error)
 
+	// `Query` returns a page of the transactions matching all the filters of the query, ordered by height and index,
+	// along with the total number of matching transactions
+	Query(query *TxQuery) (*TxQueryResult, error)
+
 	// Close stops the underlying db connection
 	Close() error
 }
@@ -43,10 +47,12 @@ var _ TxIndexer = &txIndexer{}
 var _ shared.TxResult = &TxRes{}
 
 const (
-	hashPrefix      = 'h'
-	heightPrefix    = 'b' // b for block
-	senderPrefix    = 's'
-	recipientPrefix = 'r'
+	hashPrefix        = 'h'
+	heightPrefix      = 'b' // b for block
+	senderPrefix      = 's'
+	recipientPrefix   = 'r'
+	messageTypePrefix = 'm'
+	resultCodePrefix  = 'c'
 )
 
 // =,- are the default parameters in the [example repository](https://github.com/jordanorelli/lexnum#example)
@@ -65,6 +71,28 @@ func (*TxRes) FromBytes(bz []byte) (shared.TxResult, error) {
 	return result, nil
 }
 
+func (x *TxRes) GetRecipientAddrs() []string {
+	if len(x.MessageResults) == 0 {
+		return []string{x.RecipientAddr}
+	}
+	recipients := make([]string, len(x.MessageResults))
+	for i, messageResult := range x.MessageResults {
+		recipients[i] = messageResult.RecipientAddr
+	}
+	return recipients
+}
+
+func (x *TxRes) GetMessageTypes() []string {
+	if len(x.MessageResults) == 0 {
+		return []string{x.MessageType}
+	}
+	messageTypes := make([]string, len(x.MessageResults))
+	for i, messageResult := range x.MessageResults {
+		messageTypes[i] = messageResult.MessageType
+	}
+	return messageTypes
+}
+
 func (x *TxRes) Hash() ([]byte, error) {
 	bz, err := x.Bytes()
 	if err != nil {
@@ -114,10 +142,22 @@ func (indexer *txIndexer) Index(result shared.TxResult) error {
 	if err := indexer.indexByHeightAndIndex(result.GetHeight(), result.GetIndex(), hashKey); err != nil {
 		return err
 	}
-	if err := indexer.indexBySender(result.GetSignerAddr(), hashKey); err != nil {
+	if err := indexer.indexBySender(result.GetSignerAddr(), result.GetHeight(), result.GetIndex(), hashKey); err != nil {
 		return err
 	}
-	if err := indexer.indexByRecipient(result.GetRecipientAddr(), hashKey); err != nil {
+	// A transaction is indexed once for every recipient and type of its messages, the same key being set again for a
+	// recipient or type shared by several messages
+	for _, recipient := range result.GetRecipientAddrs() {
+		if err := indexer.indexByRecipient(recipient, result.GetHeight(), result.GetIndex(), hashKey); err != nil {
+			return err
+		}
+	}
+	for _, messageType := range result.GetMessageTypes() {
+		if err := indexer.indexByMessageType(messageType, result.GetHeight(), result.GetIndex(), hashKey); err != nil {
+			return err
+		}
+	}
+	if err := indexer.indexByResultCode(result.GetResultCode(), result.GetHeight(), result.GetIndex(), hashKey); err != nil {
 		return err
 	}
 	return nil
@@ -128,15 +168,15 @@ func (indexer *txIndexer) GetByHash(hash []byte) (shared.TxResult, error) {
 }
 
 func (indexer *txIndexer) GetByHeight(height int64, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.heightKey(height), descending)
+	return indexer.queryAll(&TxQuery{MinHeight: &height, MaxHeight: &height, Descending: descending})
 }
 
 func (indexer *txIndexer) GetBySender(sender string, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.senderKey(sender), descending)
+	return indexer.queryAll(&TxQuery{Sender: sender, Descending: descending})
 }
 
 func (indexer *txIndexer) GetByRecipient(recipient string, descending bool) ([]shared.TxResult, error) {
-	return indexer.getAll(indexer.recipientKey(recipient), descending)
+	return indexer.queryAll(&TxQuery{Recipient: recipient, Descending: descending})
 }
 
 func (indexer *txIndexer) Close() error {
@@ -145,22 +185,6 @@ func (indexer *txIndexer) Close() error {
 
 // kv helper functions
 
-func (indexer *txIndexer) getAll(prefix []byte, descending bool) (result []shared.TxResult, err error) {
-	_, hashKeys, err := indexer.db.GetAll(prefix, descending)
-	if err != nil {
-		return nil, err
-	}
-	for _, hashKey := range hashKeys {
-		var txResult shared.TxResult
-		txResult, err = indexer.get(hashKey)
-		if err != nil {
-			return
-		}
-		result = append(result, txResult)
-	}
-	return
-}
-
 func (indexer *txIndexer) get(key []byte) (shared.TxResult, error) {
 	bz, err := indexer.db.Get(key)
 	if err != nil {
@@ -180,15 +204,26 @@ func (indexer *txIndexer) indexByHeightAndIndex(height int64, index int32, bz []
 	return indexer.db.Set(indexer.heightAndIndexKey(height, index), bz)
 }
 
-func (indexer *txIndexer) indexBySender(sender string, bz []byte) error {
-	return indexer.db.Set(indexer.senderKey(sender), bz)
+func (indexer *txIndexer) indexBySender(sender string, height int64, index int32, bz []byte) error {
+	return indexer.db.Set(indexer.senderKey(sender, height, index), bz)
 }
 
-func (indexer *txIndexer) indexByRecipient(recipient string, bz []byte) error {
+func (indexer *txIndexer) indexByRecipient(recipient string, height int64, index int32, bz []byte) error {
 	if recipient == "" {
 		return nil
 	}
-	return indexer.db.Set(indexer.recipientKey(recipient), bz)
+	return indexer.db.Set(indexer.recipientKey(recipient, height, index), bz)
+}
+
+func (indexer *txIndexer) indexByMessageType(messageType string, height int64, index int32, bz []byte) error {
+	if messageType == "" {
+		return nil
+	}
+	return indexer.db.Set(indexer.messageTypeKey(messageType, height, index), bz)
+}
+
+func (indexer *txIndexer) indexByResultCode(resultCode int32, height int64, index int32, bz []byte) error {
+	return indexer.db.Set(indexer.resultCodeKey(resultCode, height, index), bz)
 }
 
 // key helper functions
@@ -205,12 +240,42 @@ func (indexer *txIndexer) heightKey(height int64) []byte {
 	return indexer.key(heightPrefix, elenEncoder.EncodeInt(int(height))+"/")
 }
 
-func (indexer *txIndexer) senderKey(address string) []byte {
-	return indexer.key(senderPrefix, address)
+// The secondary indexes key the transactions by their height and index so they are all indexed, in order
+
+func (indexer *txIndexer) senderKey(address string, height int64, index int32) []byte {
+	return append(indexer.senderPrefixKey(address), indexer.heightAndIndexPostfix(height, index)...)
+}
+
+func (indexer *txIndexer) senderPrefixKey(address string) []byte {
+	return indexer.key(senderPrefix, address+"/")
+}
+
+func (indexer *txIndexer) recipientKey(address string, height int64, index int32) []byte {
+	return append(indexer.recipientPrefixKey(address), indexer.heightAndIndexPostfix(height, index)...)
+}
+
+func (indexer *txIndexer) recipientPrefixKey(address string) []byte {
+	return indexer.key(recipientPrefix, address+"/")
+}
+
+func (indexer *txIndexer) messageTypeKey(messageType string, height int64, index int32) []byte {
+	return append(indexer.messageTypePrefixKey(messageType), indexer.heightAndIndexPostfix(height, index)...)
+}
+
+func (indexer *txIndexer) messageTypePrefixKey(messageType string) []byte {
+	return indexer.key(messageTypePrefix, messageType+"/")
+}
+
+func (indexer *txIndexer) resultCodeKey(resultCode int32, height int64, index int32) []byte {
+	return append(indexer.resultCodePrefixKey(resultCode), indexer.heightAndIndexPostfix(height, index)...)
+}
+
+func (indexer *txIndexer) resultCodePrefixKey(resultCode int32) []byte {
+	return indexer.key(resultCodePrefix, fmt.Sprintf("%d/", resultCode))
 }
 
-func (indexer *txIndexer) recipientKey(address string) []byte {
-	return indexer.key(recipientPrefix, address)
+func (indexer *txIndexer) heightAndIndexPostfix(height int64, index int32) []byte {
+	return []byte(elenEncoder.EncodeInt(int(height)) + "/" + elenEncoder.EncodeInt(int(index)))
 }
 
 func (indexer *txIndexer) key(prefix rune, postfix string) []byte {
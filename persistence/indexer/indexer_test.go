@@ -183,6 +183,61 @@ func TestGetByRecipient(t *testing.T) {
 	require.Equal(t, 0, len(txResultsFromSenderBad))
 }
 
+func TestGetByRecipient_MultipleMessages(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer txIndexer.Close()
+	// setup a transaction whose messages have different recipients and types
+	txResult := NewTestingTransactionResult(t, 1, 0).(*TxRes)
+	txResult.MessageResults = []*MessageRes{
+		{MessageType: SendMessage.String(), RecipientAddr: randomAddress(t)},
+		{MessageType: StakeMessage.String(), RecipientAddr: randomAddress(t)},
+	}
+	txResult.RecipientAddr = txResult.MessageResults[0].RecipientAddr
+	txResult.MessageType = txResult.MessageResults[0].MessageType
+	require.NoError(t, txIndexer.Index(txResult))
+	// the transaction is indexed for the recipient of every message
+	for _, messageResult := range txResult.MessageResults {
+		txResultsFromRecipient, err := txIndexer.GetByRecipient(messageResult.RecipientAddr, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(txResultsFromRecipient))
+		requireTxResultsEqual(t, txResult, txResultsFromRecipient[0])
+
+		queryResult, err := txIndexer.Query(&TxQuery{Recipient: messageResult.RecipientAddr, MessageType: messageResult.MessageType})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(queryResult.TxResults))
+	}
+}
+
+func TestGetBySenderAndRecipient_MultipleTransactions(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer txIndexer.Close()
+	// setup transactions between the same sender and recipient across heights, indexed out of order
+	sender, recipient := randomAddress(t), randomAddress(t)
+	heightsAndIndices := [][2]int{{2, 0}, {1, 1}, {1, 0}, {10, 0}}
+	txResults := make(map[[2]int]shared.TxResult, len(heightsAndIndices))
+	for _, heightAndIndex := range heightsAndIndices {
+		txResult := NewTestingTransactionResult(t, heightAndIndex[0], heightAndIndex[1])
+		txResult.(*TxRes).SignerAddr = sender
+		txResult.(*TxRes).RecipientAddr = recipient
+		require.NoError(t, txIndexer.Index(txResult))
+		txResults[heightAndIndex] = txResult
+	}
+	// every transaction is returned, ordered by height and index
+	expectedOrder := [][2]int{{1, 0}, {1, 1}, {2, 0}, {10, 0}}
+	txResultsFromSender, err := txIndexer.GetBySender(sender, false)
+	require.NoError(t, err)
+	txResultsToRecipient, err := txIndexer.GetByRecipient(recipient, true)
+	require.NoError(t, err)
+	require.Equal(t, len(expectedOrder), len(txResultsFromSender))
+	require.Equal(t, len(expectedOrder), len(txResultsToRecipient))
+	for i, heightAndIndex := range expectedOrder {
+		requireTxResultsEqual(t, txResults[heightAndIndex], txResultsFromSender[i])
+		requireTxResultsEqual(t, txResults[heightAndIndex], txResultsToRecipient[len(expectedOrder)-1-i])
+	}
+}
+
 func requireTxResultsEqual(t *testing.T, txR1, txR2 shared.TxResult) {
 	bz, err := txR1.Bytes()
 	require.NoError(t, err)
@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"fmt"
+
+	shared "github.com/pokt-network/pocket/shared/modules"
+)
+
+// `Prune` deletes the transactions below `height` from every index of `txIdx`. The index by height is scanned from
+// its first height, so the transactions a previous pruning deleted are not scanned again.
+func Prune(txIdx TxIndexer, height int64) error {
+	indexer, ok := txIdx.(*txIndexer)
+	if !ok {
+		return fmt.Errorf("unsupported transaction indexer type: %T", txIdx)
+	}
+
+	it, err := indexer.db.Iterator(indexer.key(heightPrefix, ""), nil, false)
+	if err != nil {
+		return err
+	}
+	prunedKeys := make([][]byte, 0)
+	for it.Next() {
+		hashKey := it.Value()
+		txResult, err := indexer.get(hashKey)
+		if err != nil {
+			it.Close()
+			return err
+		}
+		if txResult.GetHeight() >= height {
+			break
+		}
+		prunedKeys = append(prunedKeys, indexer.indexKeys(txResult, hashKey)...)
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range prunedKeys {
+		if err := indexer.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `indexKeys` returns the keys `Index` sets for `txResult`, whose key in the index by hash is `hashKey`
+func (indexer *txIndexer) indexKeys(txResult shared.TxResult, hashKey []byte) [][]byte {
+	height, index := txResult.GetHeight(), txResult.GetIndex()
+	keys := [][]byte{
+		hashKey,
+		indexer.heightAndIndexKey(height, index),
+		indexer.senderKey(txResult.GetSignerAddr(), height, index),
+		indexer.resultCodeKey(txResult.GetResultCode(), height, index),
+	}
+	for _, recipient := range txResult.GetRecipientAddrs() {
+		if recipient != "" {
+			keys = append(keys, indexer.recipientKey(recipient, height, index))
+		}
+	}
+	for _, messageType := range txResult.GetMessageTypes() {
+		if messageType != "" {
+			keys = append(keys, indexer.messageTypeKey(messageType, height, index))
+		}
+	}
+	return keys
+}
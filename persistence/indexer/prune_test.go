@@ -0,0 +1,57 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer txIndexer.Close()
+	retainedTxIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer retainedTxIndexer.Close()
+
+	// setup 2 transactions per height from height 1 to 4, the last one having several messages
+	pruneHeight := int64(3)
+	for height := 1; height <= 4; height++ {
+		for index := 0; index < 2; index++ {
+			txResult := NewTestingTransactionResult(t, height, index)
+			if index == 1 {
+				txRes := txResult.(*TxRes)
+				txRes.MessageResults = []*MessageRes{
+					{RecipientAddr: txRes.RecipientAddr, MessageType: txRes.MessageType},
+					{RecipientAddr: "", MessageType: randomMessageType()},
+				}
+			}
+			require.NoError(t, txIndexer.Index(txResult))
+			if int64(height) >= pruneHeight {
+				require.NoError(t, retainedTxIndexer.Index(txResult))
+			}
+		}
+	}
+
+	require.NoError(t, Prune(txIndexer, pruneHeight))
+
+	// only the keys of the retained transactions are left in every index
+	keys, _, err := txIndexer.(*txIndexer).db.GetAll([]byte{}, false)
+	require.NoError(t, err)
+	retainedKeys, _, err := retainedTxIndexer.(*txIndexer).db.GetAll([]byte{}, false)
+	require.NoError(t, err)
+	require.Equal(t, retainedKeys, keys)
+
+	txResults, err := txIndexer.GetByHeight(pruneHeight-1, false)
+	require.NoError(t, err)
+	require.Empty(t, txResults)
+	txResults, err = txIndexer.GetByHeight(pruneHeight, false)
+	require.NoError(t, err)
+	require.Len(t, txResults, 2)
+
+	// pruning again below the same height is a no-op
+	require.NoError(t, Prune(txIndexer, pruneHeight))
+	result, err := txIndexer.Query(&TxQuery{})
+	require.NoError(t, err)
+	require.Len(t, result.TxResults, 4)
+}
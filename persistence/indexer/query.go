@@ -0,0 +1,175 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	shared "github.com/pokt-network/pocket/shared/modules"
+)
+
+// `TxQuery` filters the indexed transactions; the filters left to their zero value match every transaction
+type TxQuery struct {
+	Sender      string
+	Recipient   string
+	MessageType string
+	ResultCode  *int32
+	MinHeight   *int64 // Inclusive
+	MaxHeight   *int64 // Inclusive
+
+	Descending bool
+	// `Cursor` is the `NextCursor` of the previous page of the same query, or empty for the first page
+	Cursor string
+	// `Limit` is the maximum number of transactions in a page, or 0 to return all of them in a single page
+	Limit int
+	// `CountTotal` also counts the transactions matching the query across all pages, which scans the whole height
+	// range of the index of the query rather than only the page
+	CountTotal bool
+}
+
+type TxQueryResult struct {
+	TxResults  []shared.TxResult
+	NextCursor string // Empty on the last page
+	TotalCount int    // The number of transactions matching the query across all pages; only set if `CountTotal`
+}
+
+// `Query` scans the most selective index of the query, which is ordered by height and index like every index but the
+// one by hash, and filters the transactions it references. The cursor of a page is the index key of its first
+// transaction, so a page is scanned from its cursor, or from the first key in the height range of the query, onwards.
+func (indexer *txIndexer) Query(query *TxQuery) (*TxQueryResult, error) {
+	if query == nil {
+		query = &TxQuery{}
+	}
+	if query.Limit < 0 {
+		return nil, fmt.Errorf("the limit of a query cannot be negative, got %d", query.Limit)
+	}
+	cursor, err := hex.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query cursor: %v", err)
+	}
+	prefix, isHeightOrdered := indexer.queryIndexPrefix(query)
+	if len(cursor) > 0 && !bytes.HasPrefix(cursor, prefix) {
+		return nil, fmt.Errorf("invalid query cursor: %x is not a key of the index of the query", cursor)
+	}
+	var start []byte
+	if isHeightOrdered {
+		start = query.heightRangeStartKey(prefix)
+	}
+
+	result := new(TxQueryResult)
+	pageStart := start
+	if len(cursor) > 0 {
+		pageStart = cursor
+	}
+	err = indexer.scan(query, prefix, pageStart, func(key []byte, txResult shared.TxResult) bool {
+		if query.Limit == 0 || len(result.TxResults) < query.Limit {
+			result.TxResults = append(result.TxResults, txResult)
+			return true
+		}
+		result.NextCursor = hex.EncodeToString(key)
+		return false
+	})
+	if err != nil || !query.CountTotal {
+		return result, err
+	}
+
+	err = indexer.scan(query, prefix, start, func(_ []byte, _ shared.TxResult) bool {
+		result.TotalCount++
+		return true
+	})
+	return result, err
+}
+
+// `scan` iterates over the index from `start`, or its first key if nil, and calls `fn` with the transactions matching
+// the query until it returns false or the iteration goes past the height range of the query
+func (indexer *txIndexer) scan(query *TxQuery, prefix, start []byte, fn func(key []byte, txResult shared.TxResult) bool) error {
+	it, err := indexer.db.Iterator(prefix, start, query.Descending)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		txResult, err := indexer.get(it.Value())
+		if err != nil {
+			return err
+		}
+		if query.isPastHeightRange(txResult.GetHeight()) {
+			break
+		}
+		if query.matches(txResult) && !fn(it.Key(), txResult) {
+			break
+		}
+	}
+	return it.Err()
+}
+
+// `queryAll` returns all the transactions matching the query
+func (indexer *txIndexer) queryAll(query *TxQuery) ([]shared.TxResult, error) {
+	result, err := indexer.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	return result.TxResults, nil
+}
+
+// `queryIndexPrefix` returns the prefix of the index to scan to serve the query, and whether the keys with the prefix
+// are followed by the height of their transaction rather than only by its index (i.e. for a query of a single height)
+func (indexer *txIndexer) queryIndexPrefix(query *TxQuery) (prefix []byte, isHeightOrdered bool) {
+	switch {
+	case query.Sender != "":
+		return indexer.senderPrefixKey(query.Sender), true
+	case query.Recipient != "":
+		return indexer.recipientPrefixKey(query.Recipient), true
+	case query.MessageType != "":
+		return indexer.messageTypePrefixKey(query.MessageType), true
+	case query.ResultCode != nil:
+		return indexer.resultCodePrefixKey(*query.ResultCode), true
+	case query.MinHeight != nil && query.MaxHeight != nil && *query.MinHeight == *query.MaxHeight:
+		return indexer.heightKey(*query.MinHeight), false
+	default:
+		return indexer.key(heightPrefix, ""), true
+	}
+}
+
+// `heightRangeStartKey` returns the key of the index with `prefix` to seek to for the scan to start at the first
+// height of the query in the order of the scan, or nil to start from the first key of the index
+func (query *TxQuery) heightRangeStartKey(prefix []byte) []byte {
+	if query.Descending {
+		if query.MaxHeight == nil {
+			return nil
+		}
+		// The keys of the transactions at `MaxHeight` all sort before its height followed by the byte after '/'
+		return append(append([]byte{}, prefix...), elenEncoder.EncodeInt(int(*query.MaxHeight))+"0"...)
+	}
+	if query.MinHeight == nil {
+		return nil
+	}
+	return append(append([]byte{}, prefix...), elenEncoder.EncodeInt(int(*query.MinHeight))+"/"...)
+}
+
+func (query *TxQuery) matches(txResult shared.TxResult) bool {
+	return (query.Sender == "" || txResult.GetSignerAddr() == query.Sender) &&
+		(query.Recipient == "" || contains(txResult.GetRecipientAddrs(), query.Recipient)) &&
+		(query.MessageType == "" || contains(txResult.GetMessageTypes(), query.MessageType)) &&
+		(query.ResultCode == nil || txResult.GetResultCode() == *query.ResultCode) &&
+		(query.MinHeight == nil || txResult.GetHeight() >= *query.MinHeight) &&
+		(query.MaxHeight == nil || txResult.GetHeight() <= *query.MaxHeight)
+}
+
+// `isPastHeightRange` returns whether the scan of an index has gone past the height range of the query
+func (query *TxQuery) isPastHeightRange(height int64) bool {
+	if query.Descending {
+		return query.MinHeight != nil && height < *query.MinHeight
+	}
+	return query.MaxHeight != nil && height > *query.MaxHeight
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package indexer
+
+import (
+	"testing"
+
+	shared "github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer txIndexer.Close()
+
+	// setup 10 transactions across 5 heights between 2 senders and 2 recipients
+	senders := []string{randomAddress(t), randomAddress(t)}
+	recipients := []string{randomAddress(t), randomAddress(t)}
+	txResults := make([]shared.TxResult, 0)
+	for height := 1; height <= 5; height++ {
+		for index := 0; index < 2; index++ {
+			txResult := NewTestingTransactionResult(t, height, index).(*TxRes)
+			txResult.SignerAddr = senders[index]
+			txResult.RecipientAddr = recipients[height%2]
+			txResult.MessageType = SendMessage.String()
+			txResult.ResultCode = 0
+			if height == 3 {
+				txResult.MessageType = StakeMessage.String()
+				txResult.ResultCode = 1
+			}
+			require.NoError(t, txIndexer.Index(txResult))
+			txResults = append(txResults, txResult)
+		}
+	}
+
+	resultCode := int32(1)
+	minHeight, maxHeight := int64(2), int64(4)
+	tests := []struct {
+		name            string
+		query           *TxQuery
+		expectedIndices []int // the indices of the expected transactions in `txResults`
+	}{
+		{"all", &TxQuery{}, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"all descending", &TxQuery{Descending: true}, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{"by sender", &TxQuery{Sender: senders[1]}, []int{1, 3, 5, 7, 9}},
+		{"by sender and recipient", &TxQuery{Sender: senders[1], Recipient: recipients[0]}, []int{3, 7}},
+		{"by message type", &TxQuery{MessageType: StakeMessage.String()}, []int{4, 5}},
+		{"by result code", &TxQuery{ResultCode: &resultCode}, []int{4, 5}},
+		{"by height range", &TxQuery{MinHeight: &minHeight, MaxHeight: &maxHeight}, []int{2, 3, 4, 5, 6, 7}},
+		{"by height range descending", &TxQuery{MinHeight: &minHeight, MaxHeight: &maxHeight, Descending: true}, []int{7, 6, 5, 4, 3, 2}},
+		{"by single height", &TxQuery{MinHeight: &maxHeight, MaxHeight: &maxHeight}, []int{6, 7}},
+		{"by sender and height range", &TxQuery{Sender: senders[0], MinHeight: &minHeight}, []int{2, 4, 6, 8}},
+		{"no match", &TxQuery{Sender: recipients[0]}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// go through every page of 3 transactions
+			actual := make([]shared.TxResult, 0)
+			query := *tt.query
+			query.Limit = 3
+			query.CountTotal = true
+			for {
+				result, err := txIndexer.Query(&query)
+				require.NoError(t, err)
+				require.Equal(t, len(tt.expectedIndices), result.TotalCount)
+				require.LessOrEqual(t, len(result.TxResults), query.Limit)
+				actual = append(actual, result.TxResults...)
+				if result.NextCursor == "" {
+					break
+				}
+				require.Len(t, result.TxResults, query.Limit)
+				query.Cursor = result.NextCursor
+			}
+
+			require.Len(t, actual, len(tt.expectedIndices))
+			for i, expectedIndex := range tt.expectedIndices {
+				requireTxResultsEqual(t, txResults[expectedIndex], actual[i])
+			}
+		})
+	}
+
+	_, err = txIndexer.Query(&TxQuery{Limit: -1})
+	require.Error(t, err)
+	_, err = txIndexer.Query(&TxQuery{Cursor: "not a cursor"})
+	require.Error(t, err)
+
+	// the transactions are only counted across pages on demand
+	result, err := txIndexer.Query(&TxQuery{Sender: senders[0], Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, result.TxResults, 1)
+	require.Zero(t, result.TotalCount)
+
+	// the cursor of a query must be a key of the index it scans
+	_, err = txIndexer.Query(&TxQuery{Sender: senders[1], Limit: 1, Cursor: result.NextCursor})
+	require.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package indexer
+
+import (
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	shared "github.com/pokt-network/pocket/shared/modules"
+)
+
+// The height of the last block reindexed by a rebuild in progress, so an interrupted rebuild can be resumed
+var reindexCheckpointKey = []byte("k/reindex")
+
+// `ReindexConfig` configures the rebuild of the indexes from the blocks committed from `FromHeight` to `ToHeight`
+type ReindexConfig struct {
+	FromHeight int64
+	ToHeight   int64
+	// `GetTxResults` returns the results of the transactions of the block committed at a height, in order
+	GetTxResults func(height int64) ([]shared.TxResult, error)
+	// `Restart` discards the checkpoint of an interrupted rebuild instead of resuming from it
+	Restart bool
+	// `Progress` is called, if set, once the block at `height` is reindexed
+	Progress func(height, toHeight int64, numTxs int)
+}
+
+// `Reindex` rebuilds every index of `txIdx` from the results of the transactions of the committed blocks. The
+// indexes are cleared unless an interrupted rebuild is resumed from its checkpoint, which is removed once done.
+func Reindex(txIdx TxIndexer, cfg *ReindexConfig) error {
+	indexer, ok := txIdx.(*txIndexer)
+	if !ok {
+		return fmt.Errorf("unsupported transaction indexer type: %T", txIdx)
+	}
+	if cfg.GetTxResults == nil {
+		return fmt.Errorf("a reader of the transaction results is required to reindex")
+	}
+
+	fromHeight, err := indexer.reindexStartHeight(cfg)
+	if err != nil {
+		return err
+	}
+	for height := fromHeight; height <= cfg.ToHeight; height++ {
+		txResults, err := cfg.GetTxResults(height)
+		if err != nil {
+			return fmt.Errorf("unable to get the transaction results of height %d: %v", height, err)
+		}
+		for _, txResult := range txResults {
+			if err := indexer.Index(txResult); err != nil {
+				return err
+			}
+		}
+		if err := indexer.db.Set(reindexCheckpointKey, heightToCheckpoint(height)); err != nil {
+			return err
+		}
+		if cfg.Progress != nil {
+			cfg.Progress(height, cfg.ToHeight, len(txResults))
+		}
+	}
+	return indexer.db.Delete(reindexCheckpointKey)
+}
+
+// `reindexStartHeight` returns the height following the checkpoint of the rebuild in progress, if any and unless it is
+// restarted, or clears the indexes to start over from the first height
+func (indexer *txIndexer) reindexStartHeight(cfg *ReindexConfig) (int64, error) {
+	checkpoint, err := indexer.db.Get(reindexCheckpointKey)
+	if err != nil && err.Error() != kvstore.BadgerKeyNotFoundError {
+		return 0, err
+	}
+	if err == nil && !cfg.Restart {
+		var height int64
+		if _, err := fmt.Sscanf(string(checkpoint), "%d", &height); err != nil {
+			return 0, fmt.Errorf("invalid reindex checkpoint %q: %v", checkpoint, err)
+		}
+		if height+1 > cfg.FromHeight {
+			return height + 1, nil
+		}
+	}
+	if err := indexer.db.ClearAll(); err != nil {
+		return 0, err
+	}
+	return cfg.FromHeight, nil
+}
+
+func heightToCheckpoint(height int64) []byte {
+	return []byte(fmt.Sprintf("%d", height))
+}
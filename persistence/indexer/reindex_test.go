@@ -0,0 +1,71 @@
+package indexer
+
+import (
+	"errors"
+	"testing"
+
+	shared "github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReindex(t *testing.T) {
+	txIndexer, err := NewMemTxIndexer()
+	require.NoError(t, err)
+	defer txIndexer.Close()
+
+	// setup 2 transactions per height from height 1 to 4
+	txResultsByHeight := make(map[int64][]shared.TxResult)
+	for height := 1; height <= 4; height++ {
+		for index := 0; index < 2; index++ {
+			txResult := NewTestingTransactionResult(t, height, index)
+			txResultsByHeight[int64(height)] = append(txResultsByHeight[int64(height)], txResult)
+		}
+	}
+	// a stale transaction which must not survive the rebuild
+	staleTxResult := NewTestingTransactionResult(t, 5, 0)
+	require.NoError(t, txIndexer.Index(staleTxResult))
+
+	// the first rebuild is interrupted at height 3
+	reindexedHeights := make([]int64, 0)
+	failAtHeight := int64(3)
+	cfg := &ReindexConfig{
+		FromHeight: 1,
+		ToHeight:   4,
+		GetTxResults: func(height int64) ([]shared.TxResult, error) {
+			if height == failAtHeight {
+				return nil, errors.New("interrupted")
+			}
+			return txResultsByHeight[height], nil
+		},
+		Progress: func(height, toHeight int64, numTxs int) {
+			require.Equal(t, int64(4), toHeight)
+			require.Equal(t, 2, numTxs)
+			reindexedHeights = append(reindexedHeights, height)
+		},
+	}
+	require.Error(t, Reindex(txIndexer, cfg))
+	require.Equal(t, []int64{1, 2}, reindexedHeights)
+
+	// the next one resumes from the checkpoint
+	failAtHeight = -1
+	reindexedHeights = reindexedHeights[:0]
+	require.NoError(t, Reindex(txIndexer, cfg))
+	require.Equal(t, []int64{3, 4}, reindexedHeights)
+
+	result, err := txIndexer.Query(&TxQuery{})
+	require.NoError(t, err)
+	require.Len(t, result.TxResults, 8)
+	for i, txResult := range result.TxResults {
+		requireTxResultsEqual(t, txResultsByHeight[int64(i/2+1)][i%2], txResult)
+	}
+	staleHash, err := staleTxResult.Hash()
+	require.NoError(t, err)
+	_, err = txIndexer.GetByHash(staleHash)
+	require.Error(t, err)
+
+	// the checkpoint is removed once done, so a restarted or new rebuild starts over
+	reindexedHeights = reindexedHeights[:0]
+	cfg.Restart = true
+	require.NoError(t, Reindex(txIndexer, cfg))
+	require.Equal(t, []int64{1, 2, 3, 4}, reindexedHeights)
+}
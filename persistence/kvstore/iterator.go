@@ -0,0 +1,131 @@
+package kvstore
+
+import (
+	"bytes"
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// `Iterator` streams the keys and values of a store in order. `Next` must be called before reading the first key.
+type Iterator interface {
+	// `Next` moves the iterator to the next key and returns false once there are no more keys or an error occurred
+	Next() bool
+	Key() []byte
+	Value() []byte
+	// `Err` returns the error that stopped the iteration, if any
+	Err() error
+	Close()
+}
+
+var _ Iterator = &badgerIterator{}
+
+// `badgerIterator` iterates over a read-only badger transaction, so it sees a consistent view of the store
+type badgerIterator struct {
+	txn     *badger.Txn
+	it      *badger.Iterator
+	prefix  []byte
+	started bool
+	key     []byte
+	value   []byte
+	err     error
+}
+
+func (store *badgerKVStore) Iterator(prefix, start []byte, reverse bool) (Iterator, error) {
+	// INVESTIGATE: research `badger.views` for further improvements and optimizations
+	// Reference https://pkg.go.dev/github.com/dgraph-io/badger#readme-prefix-scans
+	txn := store.db.NewTransaction(false)
+
+	opt := badger.DefaultIteratorOptions
+	// A reverse prefixed iteration cannot seek past the keys with the prefix, so it is bounded by the iterator instead
+	if !reverse {
+		opt.Prefix = prefix
+	}
+	opt.Reverse = reverse
+	it := txn.NewIterator(opt)
+
+	seekKey := iteratorSeekKey(prefix, start, reverse)
+	it.Seek(seekKey)
+	// When iterating in reverse without a start key within the prefix, the iteration is seeked to the first key after
+	// the prefix, which is excluded from it
+	if reverse && it.Valid() && !bytes.HasPrefix(it.Item().Key(), prefix) && bytes.Equal(it.Item().Key(), seekKey) {
+		it.Next()
+	}
+
+	return &badgerIterator{txn: txn, it: it, prefix: prefix}, nil
+}
+
+func (store *badgerKVStore) GetPage(prefix, cursor []byte, limit int) (keys, values [][]byte, nextCursor []byte, err error) {
+	if limit <= 0 {
+		return nil, nil, nil, fmt.Errorf("the limit of a page must be positive, got %d", limit)
+	}
+	it, err := store.Iterator(prefix, cursor, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer it.Close()
+
+	keys = make([][]byte, 0, limit)
+	values = make([][]byte, 0, limit)
+	for it.Next() {
+		if len(keys) == limit {
+			return keys, values, it.Key(), nil
+		}
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
+	}
+	return keys, values, nil, it.Err()
+}
+
+func (iter *badgerIterator) Next() bool {
+	if iter.err != nil {
+		return false
+	}
+	if iter.started {
+		iter.it.Next()
+	}
+	iter.started = true
+	if !iter.it.ValidForPrefix(iter.prefix) {
+		iter.key, iter.value = nil, nil
+		return false
+	}
+
+	item := iter.it.Item()
+	iter.key = item.KeyCopy(nil)
+	if iter.value, iter.err = item.ValueCopy(nil); iter.err != nil {
+		return false
+	}
+	return true
+}
+
+func (iter *badgerIterator) Key() []byte {
+	return iter.key
+}
+
+func (iter *badgerIterator) Value() []byte {
+	return iter.value
+}
+
+func (iter *badgerIterator) Err() error {
+	return iter.err
+}
+
+func (iter *badgerIterator) Close() {
+	iter.it.Close()
+	iter.txn.Discard()
+}
+
+// `iteratorSeekKey` returns the key to seek to so the iteration starts at `start`, bounded by `prefix`
+func iteratorSeekKey(prefix, start []byte, reverse bool) []byte {
+	if !reverse {
+		if start == nil || bytes.Compare(start, prefix) < 0 {
+			return prefix
+		}
+		return start
+	}
+	end := prefixEndBytes(prefix)
+	if start == nil || (end != nil && bytes.Compare(start, end) >= 0) {
+		return end
+	}
+	return start
+}
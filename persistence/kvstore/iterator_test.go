@@ -0,0 +1,86 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	diskStore, err := NewKVStore(t.TempDir())
+	require.NoError(t, err)
+	stores := map[string]KVStore{
+		"badger":    diskStore,
+		"in-memory": NewMemKVStore(),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			defer store.Stop()
+			for _, key := range []string{"a/1", "a/2", "a/3", "b", "a0"} {
+				require.NoError(t, store.Set([]byte(key), []byte("value_"+key)))
+			}
+
+			tests := []struct {
+				name         string
+				prefix       string
+				start        []byte
+				reverse      bool
+				expectedKeys []string
+			}{
+				{"ascending", "a/", nil, false, []string{"a/1", "a/2", "a/3"}},
+				{"descending", "a/", nil, true, []string{"a/3", "a/2", "a/1"}},
+				{"ascending from start", "a/", []byte("a/2"), false, []string{"a/2", "a/3"}},
+				{"descending from start", "a/", []byte("a/2"), true, []string{"a/2", "a/1"}},
+				{"start before prefix", "a/", []byte("a"), false, []string{"a/1", "a/2", "a/3"}},
+				{"start after prefix", "a/", []byte("b"), true, []string{"a/3", "a/2", "a/1"}},
+				{"no prefix", "", nil, false, []string{"a/1", "a/2", "a/3", "a0", "b"}},
+				{"unknown prefix", "c", nil, true, []string{}},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					it, err := store.Iterator([]byte(tt.prefix), tt.start, tt.reverse)
+					require.NoError(t, err)
+					defer it.Close()
+
+					keys := make([]string, 0)
+					for it.Next() {
+						keys = append(keys, string(it.Key()))
+						require.Equal(t, "value_"+string(it.Key()), string(it.Value()))
+					}
+					require.NoError(t, it.Err())
+					require.Equal(t, tt.expectedKeys, keys)
+				})
+			}
+		})
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	store := NewMemKVStore()
+	defer store.Stop()
+	for _, key := range []string{"a/1", "a/2", "a/3", "a/4", "a/5", "b"} {
+		require.NoError(t, store.Set([]byte(key), []byte("value_"+key)))
+	}
+
+	pages := make([][]string, 0)
+	var cursor []byte
+	for {
+		keys, values, nextCursor, err := store.GetPage([]byte("a/"), cursor, 2)
+		require.NoError(t, err)
+		page := make([]string, len(keys))
+		for i, key := range keys {
+			page[i] = string(key)
+			require.Equal(t, "value_"+string(key), string(values[i]))
+		}
+		pages = append(pages, page)
+		if nextCursor == nil {
+			break
+		}
+		cursor = nextCursor
+	}
+	require.Equal(t, [][]string{{"a/1", "a/2"}, {"a/3", "a/4"}, {"a/5"}}, pages)
+
+	_, _, _, err := store.GetPage([]byte("a/"), nil, 0)
+	require.Error(t, err)
+}
@@ -15,9 +15,13 @@ type KVStore interface {
 	Stop() error
 
 	// Accessors
-	// TODO: Add a proper iterator interface
-	// TODO: Add pagination for `GetAll`
 	GetAll(prefixKey []byte, descending bool) (keys [][]byte, values [][]byte, err error)
+	// `Iterator` streams the keys with `prefix` from `start` (inclusive), or from the first key in the order of the
+	// iteration if `start` is nil; the iterator must be closed
+	Iterator(prefix, start []byte, reverse bool) (Iterator, error)
+	// `GetPage` returns at most `limit` keys with `prefix` in ascending order from `cursor` (inclusive), along with
+	// the cursor of the next page, which is nil on the last page
+	GetPage(prefix, cursor []byte, limit int) (keys, values [][]byte, nextCursor []byte, err error)
 	Exists(key []byte) (bool, error)
 	ClearAll() error
 }
@@ -46,6 +50,16 @@ func NewKVStore(path string) (KVStore, error) {
 	return &badgerKVStore{db: db}, nil
 }
 
+// `NewSyncKVStore` returns a KVStore whose writes are synced to disk before returning, for the stores whose
+// writes must survive a crash of the node (e.g. write-ahead journals)
+func NewSyncKVStore(path string) (KVStore, error) {
+	db, err := badger.Open(badgerOptions(path).WithSyncWrites(true))
+	if err != nil {
+		return nil, err
+	}
+	return &badgerKVStore{db: db}, nil
+}
+
 func NewMemKVStore() KVStore {
 	db, err := badger.Open(badgerOptions("").WithInMemory(true))
 	if err != nil {
@@ -91,41 +105,27 @@ func (store *badgerKVStore) Delete(key []byte) error {
 	tx := store.db.NewTransaction(true)
 	defer tx.Discard()
 
-	return tx.Delete(key)
+	if err := tx.Delete(key); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (store *badgerKVStore) GetAll(prefix []byte, descending bool) (keys [][]byte, values [][]byte, err error) {
-	// INVESTIGATE: research `badger.views` for further improvements and optimizations
-	// Reference https://pkg.go.dev/github.com/dgraph-io/badger#readme-prefix-scans
-	txn := store.db.NewTransaction(false)
-	defer txn.Discard()
-
-	opt := badger.DefaultIteratorOptions
-	opt.Prefix = prefix
-	opt.Reverse = descending
-	if descending {
-		prefix = prefixEndBytes(prefix)
+	it, err := store.Iterator(prefix, nil, descending)
+	if err != nil {
+		return nil, nil, err
 	}
-	it := txn.NewIterator(opt)
 	defer it.Close()
 
 	keys = make([][]byte, 0)
 	values = make([][]byte, 0)
-
-	for it.Seek(prefix); it.Valid(); it.Next() {
-		item := it.Item()
-		err = item.Value(func(v []byte) error {
-			b := make([]byte, len(v))
-			copy(b, v)
-			keys = append(keys, item.Key())
-			values = append(values, b)
-			return nil
-		})
-		if err != nil {
-			return
-		}
+	for it.Next() {
+		keys = append(keys, it.Key())
+		values = append(values, it.Value())
 	}
-	return
+	return keys, values, it.Err()
 }
 
 func (store *badgerKVStore) Exists(key []byte) (bool, error) {
@@ -0,0 +1,142 @@
+package kvstore
+
+import (
+	"fmt"
+	"strings"
+)
+
+// `RelayStore` persists the relays serviced by this node until the claims of their session are settled.
+// Relays are keyed by `(session height, app public key, relay chain, relay hash)`.
+type RelayStore interface {
+	// `StoreRelay` persists the serialized relay under its session
+	StoreRelay(sessionHeight int64, appPublicKey, relayChain string, relayHash, relayBz []byte) error
+
+	// `GetSessionRelays` returns all the relays serviced for an application on a relay chain during a session
+	GetSessionRelays(sessionHeight int64, appPublicKey, relayChain string) ([][]byte, error)
+
+	// `GetRelayCount` returns the number of relays serviced for an application during a session
+	GetRelayCount(sessionHeight int64, appPublicKey string) (int, error)
+
+	// `GetRelaysByHashSuffix` returns all the relays of a session whose hex encoded hash ends with `hashSuffix`
+	GetRelaysByHashSuffix(sessionHeight int64, hashSuffix string) ([][]byte, error)
+
+	// `DeleteSessionRelays` deletes all the relays serviced for an application on a relay chain during a session.
+	// Empty trailing components delete the relays of every relay chain, or application, of the session.
+	DeleteSessionRelays(sessionHeight int64, appPublicKey, relayChain string) error
+
+	// Stop stops the underlying db connection
+	Stop() error
+}
+
+var _ RelayStore = &relayStore{}
+
+const (
+	relayPrefix           = 'r'
+	relayHashSuffixPrefix = 'x'
+)
+
+type relayStore struct {
+	db KVStore
+}
+
+func NewRelayStore(databasePath string) (RelayStore, error) {
+	if databasePath == "" {
+		return NewMemRelayStore(), nil
+	}
+
+	db, err := NewKVStore(databasePath)
+	return &relayStore{
+		db: db,
+	}, err
+}
+
+func NewMemRelayStore() RelayStore {
+	return &relayStore{
+		db: NewMemKVStore(),
+	}
+}
+
+func (store *relayStore) StoreRelay(sessionHeight int64, appPublicKey, relayChain string, relayHash, relayBz []byte) error {
+	key := store.relayKey(sessionHeight, appPublicKey, relayChain, fmt.Sprintf("%x", relayHash))
+	if err := store.db.Set(key, relayBz); err != nil {
+		return err
+	}
+	// The hash is stored reversed so that a hash suffix can be queried through a prefix scan
+	return store.db.Set(store.hashSuffixKey(sessionHeight, reverse(fmt.Sprintf("%x", relayHash))), key)
+}
+
+func (store *relayStore) GetSessionRelays(sessionHeight int64, appPublicKey, relayChain string) ([][]byte, error) {
+	_, relays, err := store.db.GetAll(store.relayKey(sessionHeight, appPublicKey, relayChain, ""), false)
+	return relays, err
+}
+
+func (store *relayStore) GetRelayCount(sessionHeight int64, appPublicKey string) (int, error) {
+	keys, _, err := store.db.GetAll(store.relayKey(sessionHeight, appPublicKey, "", ""), false)
+	return len(keys), err
+}
+
+func (store *relayStore) GetRelaysByHashSuffix(sessionHeight int64, hashSuffix string) ([][]byte, error) {
+	_, relayKeys, err := store.db.GetAll(store.hashSuffixKey(sessionHeight, reverse(strings.ToLower(hashSuffix))), false)
+	if err != nil {
+		return nil, err
+	}
+	relays := make([][]byte, 0, len(relayKeys))
+	for _, relayKey := range relayKeys {
+		relayBz, err := store.db.Get(relayKey)
+		if err != nil {
+			return nil, err
+		}
+		relays = append(relays, relayBz)
+	}
+	return relays, nil
+}
+
+func (store *relayStore) DeleteSessionRelays(sessionHeight int64, appPublicKey, relayChain string) error {
+	keys, _, err := store.db.GetAll(store.relayKey(sessionHeight, appPublicKey, relayChain, ""), false)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		// The hash of the relay is the last component of its key
+		components := strings.Split(strings.TrimSuffix(string(key), "/"), "/")
+		relayHash := components[len(components)-1]
+		if err := store.db.Delete(store.hashSuffixKey(sessionHeight, reverse(relayHash))); err != nil {
+			return err
+		}
+		if err := store.db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *relayStore) Stop() error {
+	return store.db.Stop()
+}
+
+// key helper functions
+
+// The session height is encoded as fixed width hex so keys are ordered by height. Empty trailing
+// components produce the prefix of all the keys nested under the preceding ones.
+func (store *relayStore) relayKey(sessionHeight int64, appPublicKey, relayChain, relayHash string) []byte {
+	key := fmt.Sprintf("%c/%016x/", relayPrefix, sessionHeight)
+	for _, component := range []string{appPublicKey, relayChain, relayHash} {
+		if component == "" {
+			break
+		}
+		key += component + "/"
+	}
+	return []byte(key)
+}
+
+func (store *relayStore) hashSuffixKey(sessionHeight int64, reversedHash string) []byte {
+	return []byte(fmt.Sprintf("%c/%016x/%s", relayHashSuffixPrefix, sessionHeight, reversedHash))
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,121 @@
+package kvstore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRelayChain  = "0001"
+	testAppPubKey   = "app1"
+	testAppPubKey2  = "app2"
+	testSessionOne  = int64(4)
+	testSessionNext = int64(8)
+)
+
+func TestRelayStore_StoreAndGetSessionRelays(t *testing.T) {
+	store := NewMemRelayStore()
+	defer store.Stop()
+
+	storeTestRelays(t, store, testSessionOne, testAppPubKey, testRelayChain, 3)
+	storeTestRelays(t, store, testSessionOne, testAppPubKey, "0002", 1)
+	storeTestRelays(t, store, testSessionOne, testAppPubKey2, testRelayChain, 2)
+	storeTestRelays(t, store, testSessionNext, testAppPubKey, testRelayChain, 4)
+
+	relays, err := store.GetSessionRelays(testSessionOne, testAppPubKey, testRelayChain)
+	require.NoError(t, err)
+	require.Len(t, relays, 3)
+
+	count, err := store.GetRelayCount(testSessionOne, testAppPubKey)
+	require.NoError(t, err)
+	require.Equal(t, 4, count, "the relay count should include all the relay chains of the application")
+
+	count, err = store.GetRelayCount(testSessionOne, testAppPubKey2)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	count, err = store.GetRelayCount(testSessionNext, testAppPubKey)
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+}
+
+func TestRelayStore_GetRelaysByHashSuffix(t *testing.T) {
+	store := NewMemRelayStore()
+	defer store.Stop()
+
+	hashes := storeTestRelays(t, store, testSessionOne, testAppPubKey, testRelayChain, 20)
+	storeTestRelays(t, store, testSessionNext, testAppPubKey, testRelayChain, 20)
+
+	for _, suffixLen := range []int{1, 2, 64} {
+		suffix := hashes[0][len(hashes[0])-suffixLen:]
+		expected := 0
+		for _, hash := range hashes {
+			if hash[len(hash)-suffixLen:] == suffix {
+				expected++
+			}
+		}
+
+		relays, err := store.GetRelaysByHashSuffix(testSessionOne, suffix)
+		require.NoError(t, err)
+		require.Len(t, relays, expected, "unexpected number of collisions for suffix %s", suffix)
+		for _, relayBz := range relays {
+			hash := hex.EncodeToString(crypto.SHA3Hash(relayBz))
+			require.Equal(t, suffix, hash[len(hash)-suffixLen:])
+		}
+	}
+}
+
+func TestRelayStore_DeleteSessionRelays(t *testing.T) {
+	store := NewMemRelayStore()
+	defer store.Stop()
+
+	hashes := storeTestRelays(t, store, testSessionOne, testAppPubKey, testRelayChain, 5)
+	otherChainHashes := storeTestRelays(t, store, testSessionOne, testAppPubKey, "0002", 5)
+	storeTestRelays(t, store, testSessionNext, testAppPubKey, testRelayChain, 5)
+
+	require.NoError(t, store.DeleteSessionRelays(testSessionOne, testAppPubKey, testRelayChain))
+
+	relays, err := store.GetSessionRelays(testSessionOne, testAppPubKey, testRelayChain)
+	require.NoError(t, err)
+	require.Empty(t, relays)
+	relays, err = store.GetRelaysByHashSuffix(testSessionOne, hashes[0])
+	require.NoError(t, err)
+	require.Empty(t, relays)
+
+	relays, err = store.GetSessionRelays(testSessionOne, testAppPubKey, "0002")
+	require.NoError(t, err)
+	require.Len(t, relays, 5, "relays of other relay chains should not be deleted")
+	relays, err = store.GetRelaysByHashSuffix(testSessionOne, otherChainHashes[0])
+	require.NoError(t, err)
+	require.Len(t, relays, 1)
+
+	// empty trailing components delete the relays of every relay chain, or application, of the session
+	require.NoError(t, store.DeleteSessionRelays(testSessionOne, "", ""))
+
+	count, err := store.GetRelayCount(testSessionOne, testAppPubKey)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	relays, err = store.GetRelaysByHashSuffix(testSessionOne, otherChainHashes[0])
+	require.NoError(t, err)
+	require.Empty(t, relays)
+
+	count, err = store.GetRelayCount(testSessionNext, testAppPubKey)
+	require.NoError(t, err)
+	require.Equal(t, 5, count, "relays of other sessions should not be deleted")
+}
+
+// storeTestRelays stores `n` distinct relays and returns their hex encoded hashes
+func storeTestRelays(t *testing.T, store RelayStore, sessionHeight int64, appPublicKey, relayChain string, n int) (hashes []string) {
+	for i := 0; i < n; i++ {
+		relayBz := []byte(fmt.Sprintf("%d/%s/%s/%d", sessionHeight, appPublicKey, relayChain, i))
+		relayHash := crypto.SHA3Hash(relayBz)
+		require.NoError(t, store.StoreRelay(sessionHeight, appPublicKey, relayChain, relayHash, relayBz))
+		hashes = append(hashes, hex.EncodeToString(relayHash))
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package kvstore
+
+import (
+	"sort"
+
+	"github.com/celestiaorg/smt"
+	badger "github.com/dgraph-io/badger/v3"
+)
+
+// `StagedStore` buffers the writes made on top of a `KVStore` in memory; persisting its `Writes` to the
+// underlying store is left to its owner (e.g. through a write-ahead journal). Every write is journaled so the store can be reverted to any previous `Mark`, which allows the
+// merkle trees built on top of it to be rolled back in lockstep with the SQL save points.
+type StagedStore struct {
+	store   KVStore
+	writes  map[string][]byte // A nil value is a staged deletion
+	journal []stagedWrite
+}
+
+// `stagedWrite` keeps what a key was staged to before a write so the write can be reverted
+type stagedWrite struct {
+	key    string
+	prev   []byte
+	staged bool
+}
+
+var _ smt.MapStore = &StagedStore{}
+
+func NewStagedStore(store KVStore) *StagedStore {
+	return &StagedStore{
+		store:   store,
+		writes:  make(map[string][]byte),
+		journal: make([]stagedWrite, 0),
+	}
+}
+
+func (s *StagedStore) Get(key []byte) ([]byte, error) {
+	if value, ok := s.writes[string(key)]; ok {
+		if value == nil {
+			return nil, badger.ErrKeyNotFound
+		}
+		return value, nil
+	}
+	return s.store.Get(key)
+}
+
+func (s *StagedStore) Set(key, value []byte) error {
+	s.stage(string(key), append(make([]byte, 0, len(value)), value...))
+	return nil
+}
+
+func (s *StagedStore) Delete(key []byte) error {
+	s.stage(string(key), nil)
+	return nil
+}
+
+// `Mark` returns a position in the journal that can later be reverted to
+func (s *StagedStore) Mark() int {
+	return len(s.journal)
+}
+
+// `RevertTo` undoes all the writes staged after `mark` was taken
+func (s *StagedStore) RevertTo(mark int) {
+	for i := len(s.journal) - 1; i >= mark; i-- {
+		write := s.journal[i]
+		if write.staged {
+			s.writes[write.key] = write.prev
+		} else {
+			delete(s.writes, write.key)
+		}
+	}
+	s.journal = s.journal[:mark]
+}
+
+// `Writes` returns the staged writes ordered by key, where a nil value is a deletion
+func (s *StagedStore) Writes() (keys, values [][]byte) {
+	sortedKeys := make([]string, 0, len(s.writes))
+	for key := range s.writes {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	keys = make([][]byte, len(sortedKeys))
+	values = make([][]byte, len(sortedKeys))
+	for i, key := range sortedKeys {
+		keys[i], values[i] = []byte(key), s.writes[key]
+	}
+	return
+}
+
+// `Discard` drops all the staged writes without touching the underlying store
+func (s *StagedStore) Discard() {
+	s.writes = make(map[string][]byte)
+	s.journal = s.journal[:0]
+}
+
+func (s *StagedStore) stage(key string, value []byte) {
+	prev, staged := s.writes[key]
+	s.journal = append(s.journal, stagedWrite{key: key, prev: prev, staged: staged})
+	s.writes[key] = value
+}
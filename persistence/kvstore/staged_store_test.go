@@ -0,0 +1,129 @@
+package kvstore
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/celestiaorg/smt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStagedStore_WritesAndDiscard(t *testing.T) {
+	store := NewMemKVStore()
+	defer store.Stop()
+	require.NoError(t, store.Set([]byte("deleted"), []byte("value")))
+
+	staged := NewStagedStore(store)
+	require.NoError(t, staged.Set([]byte("key"), []byte("value")))
+	require.NoError(t, staged.Delete([]byte("deleted")))
+
+	value, err := staged.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	_, err = staged.Get([]byte("deleted"))
+	require.Error(t, err, "a staged deletion should hide the underlying value")
+
+	// nothing reaches the underlying store until the writes are persisted
+	_, err = store.Get([]byte("key"))
+	require.Error(t, err)
+	value, err = store.Get([]byte("deleted"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	staged.Discard()
+	_, err = staged.Get([]byte("key"))
+	require.Error(t, err)
+	_, err = staged.Get([]byte("deleted"))
+	require.NoError(t, err)
+
+	require.NoError(t, staged.Set([]byte("key"), []byte("value")))
+	require.NoError(t, staged.Delete([]byte("deleted")))
+	persistStagedWrites(t, staged, store)
+
+	value, err = store.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	_, err = store.Get([]byte("deleted"))
+	require.Error(t, err)
+}
+
+func TestStagedStore_RevertTo(t *testing.T) {
+	store := NewMemKVStore()
+	defer store.Stop()
+	require.NoError(t, store.Set([]byte("key"), []byte("committed")))
+
+	staged := NewStagedStore(store)
+	require.NoError(t, staged.Set([]byte("key"), []byte("first")))
+	mark := staged.Mark()
+	require.NoError(t, staged.Set([]byte("key"), []byte("second")))
+	require.NoError(t, staged.Set([]byte("other"), []byte("second")))
+	require.NoError(t, staged.Delete([]byte("key")))
+
+	staged.RevertTo(mark)
+	value, err := staged.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), value)
+	_, err = staged.Get([]byte("other"))
+	require.Error(t, err)
+
+	staged.RevertTo(0)
+	value, err = staged.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("committed"), value)
+}
+
+func TestStagedStore_RevertMerkleTree(t *testing.T) {
+	nodeStore, valueStore := NewMemKVStore(), NewMemKVStore()
+	defer nodeStore.Stop()
+	defer valueStore.Stop()
+
+	nodes, values := NewStagedStore(nodeStore), NewStagedStore(valueStore)
+	tree := smt.NewSparseMerkleTree(nodes, values, sha256.New())
+	_, err := tree.Update([]byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	root := tree.Root()
+	nodesMark, valuesMark := nodes.Mark(), values.Mark()
+	_, err = tree.Update([]byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+	require.NotEqual(t, root, tree.Root())
+
+	nodes.RevertTo(nodesMark)
+	values.RevertTo(valuesMark)
+	tree.SetRoot(root)
+
+	value, err := tree.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// the reverted tree must be updatable and reach the same root as if the reverted update never happened
+	_, err = tree.Update([]byte("key3"), []byte("value3"))
+	require.NoError(t, err)
+	persistStagedWrites(t, nodes, nodeStore)
+	persistStagedWrites(t, values, valueStore)
+
+	expected := smt.NewSparseMerkleTree(smt.NewSimpleMap(), smt.NewSimpleMap(), sha256.New())
+	_, err = expected.Update([]byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+	_, err = expected.Update([]byte("key3"), []byte("value3"))
+	require.NoError(t, err)
+	require.Equal(t, expected.Root(), tree.Root())
+
+	committed := smt.ImportSparseMerkleTree(nodeStore, valueStore, sha256.New(), tree.Root())
+	value, err = committed.Get([]byte("key3"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), value)
+}
+
+func persistStagedWrites(t *testing.T, staged *StagedStore, store KVStore) {
+	keys, values := staged.Writes()
+	for i, key := range keys {
+		if values[i] == nil {
+			require.NoError(t, store.Delete(key))
+			continue
+		}
+		require.NoError(t, store.Set(key, values[i]))
+	}
+	staged.Discard()
+	require.Zero(t, staged.Mark())
+}
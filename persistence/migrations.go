@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+/*
+	The schema of the SQL database is versioned by an ordered list of up migrations. The version of the latest
+	migration applied to the database is recorded in the `schema_version` table, along with every migration applied
+	before it, and each migration is applied in the same transaction as its record.
+
+	On startup, the persistence module applies all the migrations to a new database but refuses to run against a
+	database with pending migrations, or one migrated by a newer version of the node. The pending migrations of an
+	existing database are applied explicitly, with the node stopped, through `ApplyMigrations` (i.e. `client Migrations Apply`).
+
+	Migrations MUST NOT be edited or reordered once released: any change to the schema is a new migration appended
+	to `migrations` with the next version.
+*/
+
+var ErrIncompatibleSchema = errors.New("incompatible database schema")
+
+type Migration struct {
+	Version     uint32
+	Description string
+	up          func(ctx context.Context, driver SQLDriver, conn SQLConn) error
+}
+
+type MigrationStatus struct {
+	CurrentVersion uint32 // 0 if no migration has been applied
+	LatestVersion  uint32
+	Pending        []*Migration
+}
+
+var migrations = []*Migration{
+	// The tables created before the schema was versioned are created with `IF NOT EXISTS`, so the databases
+	// of these nodes are migrated as new ones
+	{Version: 1, Description: "Create the initial tables", up: initializeAllTables},
+	{Version: 2, Description: "Add the sequence of the accounts", up: addAccountSequenceColumn},
+	{Version: 3, Description: "Add the proven height of the claims", up: addClaimProvenHeightColumn},
+	{Version: 4, Description: "Add the settled height of the test scores", up: addTestScoreSettledHeightColumn},
+}
+
+// `GetMigrationStatus` returns the version of the SQL database of the node and its pending migrations
+func GetMigrationStatus(cfg *configs.PersistenceConfig) (*MigrationStatus, error) {
+	var status *MigrationStatus
+	err := withSQLConn(cfg, func(ctx context.Context, _ SQLDriver, conn SQLConn) (err error) {
+		status, err = getMigrationStatus(ctx, conn)
+		return
+	})
+	return status, err
+}
+
+// `ApplyMigrations` applies the pending migrations to the SQL database of the node, in order, and returns them.
+// The node must be stopped.
+func ApplyMigrations(cfg *configs.PersistenceConfig) ([]*Migration, error) {
+	var applied []*Migration
+	err := withSQLConn(cfg, func(ctx context.Context, driver SQLDriver, conn SQLConn) error {
+		status, err := getMigrationStatus(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if status.CurrentVersion > status.LatestVersion {
+			return errNewerSchema(status)
+		}
+		for _, migration := range status.Pending {
+			if err := applyMigration(ctx, driver, conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// `checkMigrations` migrates a new database to the latest version, and returns `ErrIncompatibleSchema` if an
+// existing database is not at the latest version
+func checkMigrations(ctx context.Context, driver SQLDriver, conn SQLConn) error {
+	status, err := getMigrationStatus(ctx, conn)
+	if err != nil {
+		return err
+	}
+	switch {
+	case status.CurrentVersion > status.LatestVersion:
+		return errNewerSchema(status)
+	case status.CurrentVersion == 0:
+		for _, migration := range status.Pending {
+			if err := applyMigration(ctx, driver, conn, migration); err != nil {
+				return err
+			}
+		}
+		return nil
+	case len(status.Pending) > 0:
+		return fmt.Errorf("%w: the database is at version %d and has %d pending migrations up to version %d, apply them with `client Migrations Apply`",
+			ErrIncompatibleSchema, status.CurrentVersion, len(status.Pending), status.LatestVersion)
+	}
+	return nil
+}
+
+func getMigrationStatus(ctx context.Context, conn SQLConn) (*MigrationStatus, error) {
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`%s %s %s %s`, CreateTable, IfNotExists, types.SchemaVersionTableName, types.SchemaVersionTableSchema)); err != nil {
+		return nil, err
+	}
+	tx, err := conn.BeginTx(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	status := &MigrationStatus{
+		LatestVersion: migrations[len(migrations)-1].Version,
+		Pending:       make([]*Migration, 0),
+	}
+	var currentVersion int64
+	if err := tx.QueryRow(ctx, types.GetSchemaVersionQuery()).Scan(&currentVersion); err != nil {
+		return nil, err
+	}
+	status.CurrentVersion = uint32(currentVersion)
+	for _, migration := range migrations {
+		if migration.Version > status.CurrentVersion {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+func applyMigration(ctx context.Context, driver SQLDriver, conn SQLConn, migration *Migration) error {
+	tx, err := conn.BeginTx(ctx, false)
+	if err != nil {
+		return err
+	}
+	if err := migration.up(ctx, driver, tx.Conn()); err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("unable to apply the migration %d (%s): %v", migration.Version, migration.Description, err)
+	}
+	if _, err := tx.Exec(ctx, types.InsertSchemaVersionQuery(migration.Version, migration.Description)); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	log.Printf("Applied the migration %d: %s\n", migration.Version, migration.Description)
+	return nil
+}
+
+func addAccountSequenceColumn(ctx context.Context, _ SQLDriver, conn SQLConn) error {
+	_, err := conn.Exec(ctx, types.AddAccountSequenceColumnQuery())
+	return err
+}
+
+func addClaimProvenHeightColumn(ctx context.Context, _ SQLDriver, conn SQLConn) error {
+	_, err := conn.Exec(ctx, types.AddClaimProvenHeightColumnQuery())
+	return err
+}
+
+func addTestScoreSettledHeightColumn(ctx context.Context, _ SQLDriver, conn SQLConn) error {
+	_, err := conn.Exec(ctx, types.AddTestScoreSettledHeightColumnQuery())
+	return err
+}
+
+func errNewerSchema(status *MigrationStatus) error {
+	return fmt.Errorf("%w: the database is at version %d, which is newer than the latest version %d supported by the node",
+		ErrIncompatibleSchema, status.CurrentVersion, status.LatestVersion)
+}
+
+func withSQLConn(cfg *configs.PersistenceConfig, fn func(ctx context.Context, driver SQLDriver, conn SQLConn) error) error {
+	ctx := context.TODO()
+	driver, err := newSQLDriver(cfg)
+	if err != nil {
+		return err
+	}
+	defer driver.Close()
+	conn, err := driver.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	return fn(ctx, driver, conn)
+}
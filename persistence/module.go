@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/pokt-network/pocket/persistence/indexer"
 	"github.com/pokt-network/pocket/persistence/kvstore"
 	"github.com/pokt-network/pocket/runtime/configs"
@@ -27,9 +26,15 @@ type persistenceModule struct {
 	config       *configs.PersistenceConfig
 	genesisState *genesis.GenesisState
 
+	sqlDriver SQLDriver
+
 	blockStore kvstore.KVStore
 	txIndexer  indexer.TxIndexer
 	stateTrees *stateTrees
+	relayStore kvstore.RelayStore
+
+	commitJournal *commitJournal
+	pruner        *pruner
 
 	// TECHDEBT: Need to implement context pooling (for writes), timeouts (for read & writes), etc...
 	writeContext *PostgresContext // only one write context is allowed at a time
@@ -39,7 +44,17 @@ func Create(bus modules.Bus) (modules.Module, error) {
 	return new(persistenceModule).Create(bus)
 }
 
+// `CreateWithCommitFaultInjector` creates a persistence module whose commits call `commitFaultInjector` before every
+// step, so the fault-injection tests can interrupt them the same way a crash would
+func CreateWithCommitFaultInjector(bus modules.Bus, commitFaultInjector CommitFaultInjector) (modules.Module, error) {
+	return create(bus, commitFaultInjector)
+}
+
 func (*persistenceModule) Create(bus modules.Bus) (modules.Module, error) {
+	return create(bus, nil)
+}
+
+func create(bus modules.Bus, commitFaultInjector CommitFaultInjector) (modules.Module, error) {
 	m := &persistenceModule{
 		writeContext: nil,
 	}
@@ -50,11 +65,15 @@ func (*persistenceModule) Create(bus modules.Bus) (modules.Module, error) {
 	persistenceCfg := runtimeMgr.GetConfig().Persistence
 	genesisState := runtimeMgr.GetGenesis()
 
-	conn, err := connectToDatabase(persistenceCfg)
+	sqlDriver, err := newSQLDriver(persistenceCfg)
 	if err != nil {
 		return nil, err
 	}
-	if err := initializeDatabase(conn); err != nil {
+	conn, err := sqlDriver.Connect(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	if err := initializeDatabase(sqlDriver, conn); err != nil {
 		return nil, err
 	}
 	conn.Close(context.TODO())
@@ -75,12 +94,32 @@ func (*persistenceModule) Create(bus modules.Bus) (modules.Module, error) {
 		return nil, err
 	}
 
+	relayStore, err := kvstore.NewRelayStore(persistenceCfg.RelayStorePath)
+	if err != nil {
+		return nil, err
+	}
+
+	commitJournal, err := newCommitJournal(persistenceCfg.CommitJournalPath, commitFaultInjector)
+	if err != nil {
+		return nil, err
+	}
+
+	pruner, err := newPruner(persistenceCfg, sqlDriver, blockStore, txIndexer, stateTrees)
+	if err != nil {
+		return nil, err
+	}
+	stateTrees.pruner = pruner
+
 	m.config = persistenceCfg
 	m.genesisState = genesisState
+	m.sqlDriver = sqlDriver
 
 	m.blockStore = blockStore
 	m.txIndexer = txIndexer
 	m.stateTrees = stateTrees
+	m.relayStore = relayStore
+	m.commitJournal = commitJournal
+	m.pruner = pruner
 
 	// TECHDEBT: reconsider if this is the best place to call `populateGenesisState`. Note that
 	// 		     this forces the genesis state to be reloaded on every node startup until state
@@ -101,11 +140,16 @@ func (*persistenceModule) Create(bus modules.Bus) (modules.Module, error) {
 
 func (m *persistenceModule) Start() error {
 	log.Println("Starting persistence module...")
-	return nil
+	// Recover the commit interrupted by a crash of the node, if any
+	return m.recoverCommit()
 }
 
 func (m *persistenceModule) Stop() error {
+	m.pruner.wait()
 	m.blockStore.Stop()
+	m.relayStore.Stop()
+	m.commitJournal.stop()
+	m.sqlDriver.Close()
 	return nil
 }
 
@@ -128,16 +172,13 @@ func (m *persistenceModule) NewRWContext(height int64) (modules.PersistenceRWCon
 	if m.writeContext != nil && !m.writeContext.conn.IsClosed() {
 		return nil, fmt.Errorf("write context already exists")
 	}
-	conn, err := connectToDatabase(m.config)
+	conn, err := m.sqlDriver.Connect(context.TODO())
 	if err != nil {
 		return nil, err
 	}
-	tx, err := conn.BeginTx(context.TODO(), pgx.TxOptions{
-		IsoLevel:       pgx.ReadUncommitted,
-		AccessMode:     pgx.ReadWrite,
-		DeferrableMode: pgx.Deferrable, // TODO(andrew): Research if this should be `Deferrable`
-	})
+	tx, err := conn.BeginTx(context.TODO(), false)
 	if err != nil {
+		conn.Close(context.TODO())
 		return nil, err
 	}
 
@@ -147,10 +188,13 @@ func (m *persistenceModule) NewRWContext(height int64) (modules.PersistenceRWCon
 		tx:     tx,
 
 		stateHash: "",
+		pending:   newPendingWrites(),
 
-		blockStore: m.blockStore,
-		txIndexer:  m.txIndexer,
-		stateTrees: m.stateTrees,
+		blockStore:    m.blockStore,
+		txIndexer:     m.txIndexer,
+		stateTrees:    m.stateTrees,
+		commitJournal: m.commitJournal,
+		pruner:        m.pruner,
 	}
 
 	return m.writeContext, nil
@@ -158,16 +202,17 @@ func (m *persistenceModule) NewRWContext(height int64) (modules.PersistenceRWCon
 }
 
 func (m *persistenceModule) NewReadContext(height int64) (modules.PersistenceReadContext, error) {
-	conn, err := connectToDatabase(m.config)
+	if err := m.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
+	// Every read context holds its own connection of the pool until it is closed
+	conn, err := m.sqlDriver.Connect(context.TODO())
 	if err != nil {
 		return nil, err
 	}
-	tx, err := conn.BeginTx(context.TODO(), pgx.TxOptions{
-		IsoLevel:       pgx.ReadCommitted,
-		AccessMode:     pgx.ReadOnly,
-		DeferrableMode: pgx.NotDeferrable, // TODO(andrew): Research if this should be `Deferrable`
-	})
+	tx, err := conn.BeginTx(context.TODO(), true)
 	if err != nil {
+		conn.Close(context.TODO())
 		return nil, err
 	}
 
@@ -181,11 +226,13 @@ func (m *persistenceModule) NewReadContext(height int64) (modules.PersistenceRea
 		blockStore: m.blockStore,
 		txIndexer:  m.txIndexer,
 		stateTrees: m.stateTrees,
+		pruner:     m.pruner,
 	}, nil
 }
 
 func (m *persistenceModule) ReleaseWriteContext() error {
 	if m.writeContext != nil {
+		m.writeContext.discardPendingWrites()
 		if err := m.writeContext.resetContext(); err != nil {
 			log.Println("[TODO][ERROR] Error releasing write context...", err)
 		}
@@ -198,6 +245,10 @@ func (m *persistenceModule) GetBlockStore() kvstore.KVStore {
 	return m.blockStore
 }
 
+func (m *persistenceModule) GetRelayStore() kvstore.RelayStore {
+	return m.relayStore
+}
+
 func (m *persistenceModule) NewWriteContext() modules.PersistenceRWContext {
 	return m.writeContext
 }
@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+const (
+	CreateSchema    = "CREATE SCHEMA"
+	SetSearchPathTo = "SET search_path TO"
+
+	CreateEnumType = "CREATE TYPE %s AS ENUM"
+
+	// Ignores the DUPLICATE OBJECT error within the statement itself, since an error would otherwise abort the
+	// transaction of the migration creating the type. For reference: https://www.postgresql.org/docs/current/errcodes-appendix.html
+	IgnoreDuplicateObject = "DO $$ BEGIN %s; EXCEPTION WHEN duplicate_object THEN NULL; END $$"
+
+	// The status of a connection outside of a transaction. For reference: https://www.postgresql.org/docs/current/protocol-message-formats.html
+	txStatusIdle = 'I'
+)
+
+var (
+	_ SQLDriver = &postgresDriver{}
+	_ SQLConn   = &postgresConn{}
+	_ SQLTx     = &postgresTx{}
+	_ SQLRows   = pgx.Rows(nil)
+)
+
+// `postgresDriver` holds the pool of connections of the node: every context acquires its own connection from the
+// pool, so the read contexts run alongside the write context, and releases it back to the pool once done.
+type postgresDriver struct {
+	pool *pgxpool.Pool
+}
+
+type postgresConn struct {
+	conn     *pgxpool.Conn
+	isClosed bool
+}
+
+type postgresTx struct {
+	tx   pgx.Tx
+	conn *postgresConn
+}
+
+type postgresRow struct {
+	row pgx.Row
+}
+
+func newPostgresDriver(cfg *configs.PersistenceConfig) (*postgresDriver, error) {
+	config, err := pgxpool.ParseConfig(cfg.GetPostgresUrl())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create database config: %v", err)
+	}
+	maxConnLifetime, err := time.ParseDuration(cfg.GetMaxConnLifetime())
+	if err == nil {
+		config.MaxConnLifetime = maxConnLifetime
+	} else {
+		return nil, fmt.Errorf("unable to set max connection lifetime: %v", err)
+	}
+	maxConnIdleTime, err := time.ParseDuration(cfg.GetMaxConnIdleTime())
+	if err == nil {
+		config.MaxConnIdleTime = maxConnIdleTime
+	} else {
+		return nil, fmt.Errorf("unable to set max connection idle time : %v", err)
+	}
+	config.MaxConns = cfg.GetMaxConnsCount()
+	config.MinConns = cfg.GetMinConnsCount()
+	healthCheckPeriod, err := time.ParseDuration(cfg.GetHealthCheckPeriod())
+	if err == nil {
+		config.HealthCheckPeriod = healthCheckPeriod
+	} else {
+		return nil, fmt.Errorf("unable to set healthcheck period: %v", err)
+	}
+
+	nodeSchema := cfg.GetNodeSchema()
+	// Creating and setting a new schema so we can run multiple nodes on one postgres instance.
+	// See more details at https://github.com/go-pg/pg/issues/351.
+	// The search path is set on every connection of the pool since it is specific to a session.
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		if _, err := conn.Exec(ctx, fmt.Sprintf("%s %s %s", CreateSchema, IfNotExists, nodeSchema)); err != nil {
+			return err
+		}
+		_, err := conn.Exec(ctx, fmt.Sprintf("%s %s", SetSearchPathTo, nodeSchema))
+		return err
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %v", err)
+	}
+	return &postgresDriver{pool: pool}, nil
+}
+
+// `Connect` acquires a connection from the pool, waiting for one to be released if all of them are in use
+func (d *postgresDriver) Connect(ctx context.Context) (SQLConn, error) {
+	conn, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %v", err)
+	}
+	return &postgresConn{conn: conn}, nil
+}
+
+func (d *postgresDriver) CreateEnumType(ctx context.Context, conn SQLConn, name, values string) error {
+	createEnumType := fmt.Sprintf(`%s %s`, fmt.Sprintf(CreateEnumType, name), values)
+	_, err := conn.Exec(ctx, fmt.Sprintf(IgnoreDuplicateObject, createEnumType))
+	return err
+}
+
+// `Close` closes the pool once all its connections are released
+func (d *postgresDriver) Close() error {
+	d.pool.Close()
+	return nil
+}
+
+func (c *postgresConn) Exec(ctx context.Context, query string) (int64, error) {
+	tag, err := c.conn.Exec(ctx, query)
+	return tag.RowsAffected(), err
+}
+
+func (c *postgresConn) BeginTx(ctx context.Context, readOnly bool) (SQLTx, error) {
+	txOptions := pgx.TxOptions{
+		IsoLevel:       pgx.ReadUncommitted,
+		AccessMode:     pgx.ReadWrite,
+		DeferrableMode: pgx.Deferrable, // TODO(andrew): Research if this should be `Deferrable`
+	}
+	if readOnly {
+		txOptions = pgx.TxOptions{
+			IsoLevel:       pgx.ReadCommitted,
+			AccessMode:     pgx.ReadOnly,
+			DeferrableMode: pgx.NotDeferrable, // TODO(andrew): Research if this should be `Deferrable`
+		}
+	}
+	tx, err := c.conn.BeginTx(ctx, txOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresTx{tx: tx, conn: c}, nil
+}
+
+// `Close` releases the connection back to the pool. A connection is only reused once its transaction, if any, has ended.
+func (c *postgresConn) Close(ctx context.Context) error {
+	if c.isClosed {
+		return nil
+	}
+	c.isClosed = true
+	defer c.conn.Release()
+	if c.conn.Conn().PgConn().TxStatus() != txStatusIdle {
+		if _, err := c.conn.Exec(ctx, "ROLLBACK"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *postgresConn) IsClosed() bool {
+	return c.isClosed
+}
+
+func (t *postgresTx) Exec(ctx context.Context, query string) (int64, error) {
+	tag, err := t.tx.Exec(ctx, query)
+	return tag.RowsAffected(), err
+}
+
+func (t *postgresTx) Query(ctx context.Context, query string) (SQLRows, error) {
+	return t.tx.Query(ctx, query)
+}
+
+func (t *postgresTx) QueryRow(ctx context.Context, query string) SQLRow {
+	return postgresRow{row: t.tx.QueryRow(ctx, query)}
+}
+
+func (t *postgresTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+func (t *postgresTx) Rollback(ctx context.Context) error {
+	return t.tx.Rollback(ctx)
+}
+
+func (t *postgresTx) Conn() SQLConn {
+	return t.conn
+}
+
+func (r postgresRow) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != pgx.ErrNoRows {
+		return err
+	}
+	return errNoRows
+}
@@ -0,0 +1,241 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+/*
+	Every account, pool, param, flag and protocol actor table keeps a version of each row per height it was
+	updated at. The pruner deletes, in the background, the versions superseded before the earliest height
+	retained by the pruning mode of the node, along with the blocks below that height in the SQL database and in
+	the block store (with their transaction results and events), the transactions of the tx indexer and the history of
+	the state trees below that height.
+	The latest version of each row at or below the earliest retained height is always kept, so the state can still be
+	queried at any retained height.
+
+	Queries for a height below the earliest retained height return `ErrHeightPruned`.
+*/
+
+const (
+	// Nothing is ever pruned (default)
+	PruningModeArchive = "archive"
+	// Only the last `pruning_keep_recent` heights are retained, pruned every `defaultPruningInterval` heights
+	PruningModeKeepRecent = "keep_recent"
+	// Only the last `pruning_keep_recent` heights are retained, pruned every `pruning_interval` heights
+	PruningModeCustom = "custom"
+
+	defaultPruningInterval = 10
+)
+
+var ErrHeightPruned = errors.New("height pruned")
+
+type pruner struct {
+	keepRecent int64 // 0 if nothing is pruned
+	interval   int64
+
+	sqlDriver  SQLDriver
+	blockStore kvstore.KVStore
+	txIndexer  indexer.TxIndexer
+	stateTrees *stateTrees
+
+	mu             sync.Mutex
+	earliestHeight int64 // the earliest height which can be queried
+	targetHeight   int64 // the earliest height to retain once the pruning in progress, if any, completes
+	isPruning      bool
+	wg             sync.WaitGroup
+}
+
+func newPruner(cfg *configs.PersistenceConfig, sqlDriver SQLDriver, blockStore kvstore.KVStore, txIndexer indexer.TxIndexer, stateTrees *stateTrees) (*pruner, error) {
+	p := &pruner{
+		sqlDriver:  sqlDriver,
+		blockStore: blockStore,
+		txIndexer:  txIndexer,
+		stateTrees: stateTrees,
+	}
+	switch cfg.GetPruningMode() {
+	case "", PruningModeArchive:
+	case PruningModeKeepRecent:
+		p.keepRecent, p.interval = cfg.GetPruningKeepRecent(), defaultPruningInterval
+	case PruningModeCustom:
+		p.keepRecent, p.interval = cfg.GetPruningKeepRecent(), cfg.GetPruningInterval()
+		if p.interval <= 0 {
+			return nil, fmt.Errorf("the pruning interval must be positive in the %s pruning mode", PruningModeCustom)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pruning mode: %s", cfg.GetPruningMode())
+	}
+	if p.interval > 0 && p.keepRecent <= 0 {
+		return nil, fmt.Errorf("the number of recent heights to keep must be positive in the %s pruning mode", cfg.GetPruningMode())
+	}
+
+	if err := p.loadEarliestHeight(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// `checkHeight` returns `ErrHeightPruned` if the state at `height` has been pruned. Negative heights do not
+// identify the state at a height and are never pruned.
+func (p *pruner) checkHeight(height int64) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if height >= 0 && height < p.earliestHeight {
+		return fmt.Errorf("%w: %d is below the earliest retained height %d", ErrHeightPruned, height, p.earliestHeight)
+	}
+	return nil
+}
+
+func (p *pruner) getEarliestHeight() int64 {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.earliestHeight
+}
+
+// `onCommit` prunes, in the background, the heights which are no longer retained once `height` is committed
+func (p *pruner) onCommit(height int64) {
+	if p == nil || p.interval <= 0 || height%p.interval != 0 {
+		return
+	}
+	pruneHeight := height - p.keepRecent + 1
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pruneHeight <= p.targetHeight {
+		return
+	}
+	p.targetHeight = pruneHeight
+	// The pruning in progress, if any, picks up the new target once done
+	if p.isPruning {
+		return
+	}
+	p.isPruning = true
+	p.wg.Add(1)
+	go p.run()
+}
+
+func (p *pruner) run() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		fromHeight, pruneHeight := p.earliestHeight, p.targetHeight
+		if pruneHeight <= fromHeight {
+			p.isPruning = false
+			p.mu.Unlock()
+			return
+		}
+		// The pruned heights cannot be queried as soon as their deletion starts
+		p.earliestHeight = pruneHeight
+		p.mu.Unlock()
+
+		if err := p.prune(fromHeight, pruneHeight); err != nil {
+			log.Printf("[ERROR] Error pruning the heights below %d: %v\n", pruneHeight, err)
+			p.mu.Lock()
+			p.isPruning = false
+			p.mu.Unlock()
+			return
+		}
+		log.Printf("Pruned the heights below %d\n", pruneHeight)
+	}
+}
+
+// The blocks are deleted from the block store, the tx indexer and the history of the state trees first: the earliest
+// height is loaded from the SQL database on startup, so they are pruned again if the node crashes before it is.
+func (p *pruner) prune(fromHeight, pruneHeight int64) error {
+	for height := fromHeight; height < pruneHeight; height++ {
+		if err := p.blockStore.Delete(heightToBytes(height)); err != nil {
+			return err
+		}
+		if err := p.blockStore.Delete(blockTxResultsKey(height)); err != nil {
+			return err
+		}
+		if err := deleteBlockEvents(p.blockStore, height); err != nil {
+			return err
+		}
+	}
+	if err := indexer.Prune(p.txIndexer, pruneHeight); err != nil {
+		return err
+	}
+	if err := p.stateTrees.pruneHistory(fromHeight, pruneHeight); err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	conn, err := p.sqlDriver.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	tx, err := conn.BeginTx(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, query := range pruneQueries(pruneHeight) {
+		if _, err := tx.Exec(ctx, query); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func pruneQueries(pruneHeight int64) []string {
+	queries := []string{
+		types.PruneVersionsQuery(types.Account.GetAccountSpecificColName(), pruneHeight, types.AccountTableName),
+		types.PruneVersionsQuery(types.Pool.GetAccountSpecificColName(), pruneHeight, types.PoolTableName),
+		types.PruneVersionsQuery(types.NameCol, pruneHeight, types.ParamsTableName),
+		types.PruneVersionsQuery(types.NameCol, pruneHeight, types.FlagsTableName),
+	}
+	for _, actor := range protocolActorSchemas {
+		queries = append(queries, types.PruneVersionsQuery(types.AddressCol, pruneHeight, actor.GetTableName()))
+		if actor.GetChainsTableName() != "" {
+			queries = append(queries, types.PruneChainsQuery(pruneHeight, actor.GetTableName(), actor.GetChainsTableName()))
+		}
+	}
+	return append(queries, types.PruneBlocksQuery(pruneHeight))
+}
+
+func (p *pruner) loadEarliestHeight() error {
+	ctx := context.TODO()
+	conn, err := p.sqlDriver.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	tx, err := conn.BeginTx(ctx, true)
+	if err != nil {
+		return err
+	}
+	var earliestHeight int64
+	if err := tx.QueryRow(ctx, types.GetEarliestBlockHeightQuery()).Scan(&earliestHeight); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.earliestHeight, p.targetHeight = earliestHeight, earliestHeight
+	return nil
+}
+
+// `reset` waits for the pruning in progress and reloads the earliest height after the state is cleared
+func (p *pruner) reset() error {
+	p.wait()
+	return p.loadEarliestHeight()
+}
+
+func (p *pruner) wait() {
+	p.wg.Wait()
+}
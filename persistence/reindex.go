@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/modules"
+)
+
+// `RebuildTxIndexer` rebuilds the transaction indexer from the blocks in the block store, from the earliest retained
+// height to the latest committed one, resuming from the checkpoint of an interrupted rebuild unless `restart` is set.
+// `progress`, if set, is called once the block at `height` is reindexed.
+//
+// The rebuild starts once the pruning in progress, if any, is done, so the blocks are retained from the earliest height.
+// The heights pruned while rebuilding are pruned from the rebuilt indexer once done, so it holds the same heights as
+// an indexer kept up to date by the pruner.
+//
+// The results of the transactions are only stored alongside the blocks committed since they started to be, so the
+// blocks committed before then cannot be reindexed; they are checked for before the indexer is cleared so an
+// unsupported rebuild leaves the current index intact.
+func (m *persistenceModule) RebuildTxIndexer(restart bool, progress func(height, toHeight int64, numTxs int)) error {
+	readCtx, err := m.NewReadContext(-1)
+	if err != nil {
+		return err
+	}
+	latestHeight, err := readCtx.GetLatestBlockHeight()
+	readCtx.Close()
+	if err != nil {
+		return err
+	}
+
+	m.pruner.wait()
+	fromHeight := m.pruner.getEarliestHeight()
+	if err := m.checkBlockTxResultsStored(fromHeight, int64(latestHeight)); err != nil {
+		return err
+	}
+
+	err = indexer.Reindex(m.txIndexer, &indexer.ReindexConfig{
+		FromHeight:   fromHeight,
+		ToHeight:     int64(latestHeight),
+		GetTxResults: m.getBlockTxResults,
+		Restart:      restart,
+		Progress:     progress,
+	})
+	if err != nil {
+		return err
+	}
+	m.pruner.wait()
+	return indexer.Prune(m.txIndexer, m.pruner.getEarliestHeight())
+}
+
+// `checkBlockTxResultsStored` returns an error naming the latest block, between `fromHeight` and `toHeight`, that has
+// transactions but no results stored for them
+func (m *persistenceModule) checkBlockTxResultsStored(fromHeight, toHeight int64) error {
+	for height := toHeight; height >= fromHeight; height-- {
+		stored, err := m.blockStore.Exists(blockTxResultsKey(height))
+		if err != nil {
+			return err
+		}
+		if stored {
+			continue
+		}
+		block, err := m.GetBlock(height)
+		if err != nil {
+			return err
+		}
+		if len(block.Transactions) != 0 {
+			return fmt.Errorf("rebuilding the tx indexer is unsupported before height %d: the results of the %d transactions of the block at height %d are not stored", height+1, len(block.Transactions), height)
+		}
+	}
+	return nil
+}
+
+// `getBlockTxResults` reads the results of the transactions of the block at `height` from the block store, and checks
+// they match the transactions of the block
+func (m *persistenceModule) getBlockTxResults(height int64) ([]modules.TxResult, error) {
+	block, err := m.GetBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTxResultsBz, err := m.blockStore.Get(blockTxResultsKey(height))
+	if err != nil {
+		if len(block.Transactions) == 0 {
+			return []modules.TxResult{}, nil
+		}
+		return nil, fmt.Errorf("unable to get the results of the %d transactions of the block: %v", len(block.Transactions), err)
+	}
+	blockTxResults := new(types.BlockTxResults)
+	if err := codec.GetCodec().Unmarshal(blockTxResultsBz, blockTxResults); err != nil {
+		return nil, err
+	}
+	if len(blockTxResults.TxResults) != len(block.Transactions) {
+		return nil, fmt.Errorf("the block has %d transactions but %d results", len(block.Transactions), len(blockTxResults.TxResults))
+	}
+
+	txResults := make([]modules.TxResult, len(blockTxResults.TxResults))
+	for i, txResultBz := range blockTxResults.TxResults {
+		txResult, err := new(indexer.TxRes).FromBytes(txResultBz)
+		if err != nil {
+			return nil, err
+		}
+		if txResult.GetHeight() != height || !bytes.Equal(txResult.GetTx(), block.Transactions[i]) {
+			return nil, fmt.Errorf("the result %d does not match the transaction %d of the block", i, i)
+		}
+		txResults[i] = txResult
+	}
+	return txResults, nil
+}
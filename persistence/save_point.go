@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"sort"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/modules"
+)
+
+// `pendingWrites` holds the writes of a write context that live outside of its SQL transaction (i.e. the
+// indexed transactions and the emitted events) until the context is committed, so they can be rolled back in
+// lockstep with it.
+type pendingWrites struct {
+	txResults  []modules.TxResult
+	events     []*coreTypes.StateChangeEvent
+	savePoints []savePoint
+}
+
+// `savePoint` captures the state of a write context prior to applying the transaction it is keyed by
+type savePoint struct {
+	txHash       string // hex encoded
+	sqlName      string
+	numTxResults int
+	numEvents    int
+	trees        treesSavePoint
+}
+
+func newPendingWrites() *pendingWrites {
+	return &pendingWrites{
+		txResults:  make([]modules.TxResult, 0),
+		events:     make([]*coreTypes.StateChangeEvent, 0),
+		savePoints: make([]savePoint, 0),
+	}
+}
+
+// `getSavePointIndex` returns the position of the save point keyed by `txHash` or -1 if it does not exist
+func (w *pendingWrites) getSavePointIndex(txHash string) int {
+	for i, savePoint := range w.savePoints {
+		if savePoint.txHash == txHash {
+			return i
+		}
+	}
+	return -1
+}
+
+// `getTxResults` returns the pending transactions the same way the indexer would return them by height
+// once they are indexed: ordered by index, where a transaction replaces any previous one at the same index.
+func (w *pendingWrites) getTxResults(descending bool) []modules.TxResult {
+	txResultsByIndex := make(map[int32]modules.TxResult, len(w.txResults))
+	for _, txResult := range w.txResults {
+		txResultsByIndex[txResult.GetIndex()] = txResult
+	}
+	txResults := make([]modules.TxResult, 0, len(txResultsByIndex))
+	for _, txResult := range txResultsByIndex {
+		txResults = append(txResults, txResult)
+	}
+	sort.Slice(txResults, func(i, j int) bool {
+		if descending {
+			return txResults[i].GetIndex() > txResults[j].GetIndex()
+		}
+		return txResults[i].GetIndex() < txResults[j].GetIndex()
+	})
+	return txResults
+}
+
+func (w *pendingWrites) reset() {
+	w.txResults = w.txResults[:0]
+	w.events = w.events[:0]
+	w.savePoints = w.savePoints[:0]
+}
@@ -0,0 +1,623 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+	A snapshot captures the state at a height so a new node can be bootstrapped from it rather than by replaying
+	every block since genesis. It is stored in a directory containing:
+	  - `manifest`: the version of the snapshot format, the height and block of the snapshot, the roots of the
+	    state trees and the hash of every chunk
+	  - `chunk_<index>`: the content of the snapshot, split into chunks of at most `snapshotChunkSize` entries.
+	    Every entry is either the latest version of an actor, account, pool, param or flag row at the height of
+	    the snapshot, or a node reachable from the root of a state tree at that height or the value of one of its
+	    leaves. The history of the trees is not part of the snapshot.
+
+	A snapshot can be exported at any retained height since the state trees are reopened as they were committed at it.
+
+	Importing a snapshot replaces the state of the node. Since the snapshot comes from an untrusted peer, the state
+	hash it must have is supplied by the caller, e.g. from a block hash the operator trusts, rather than read from it:
+	  - every chunk is verified against the manifest and the block of the snapshot must be at the trusted state hash
+	  - the state hash recomputed from the imported trees with `ComputeStateHash` must be the trusted one
+	  - the leaves of the trees are rebuilt from the imported SQL rows and must be the ones of the imported trees,
+	    except for the transactions tree which has no rows and the params and flags trees which also hold their
+	    previous versions
+*/
+
+const (
+	SnapshotVersion = 3 // 2: the account rows hold the sequence of the accounts; 3: the trees are exported at the height of the snapshot
+
+	snapshotManifestFileName    = "manifest"
+	snapshotChunkFileNameFormat = "chunk_%06d"
+	snapshotChunkSize           = 1000 // The maximum number of entries of a chunk
+)
+
+func (m *persistenceModule) ExportSnapshot(height int64, dir string) error {
+	readCtx, err := m.NewReadContext(height)
+	if err != nil {
+		return err
+	}
+	defer readCtx.Close()
+	p := readCtx.(PostgresContext)
+
+	if height < 0 {
+		return fmt.Errorf("invalid snapshot height %d", height)
+	}
+	if err := m.pruner.checkHeight(height); err != nil {
+		return err
+	}
+
+	blockBz, err := m.blockStore.Get(heightToBytes(height))
+	if err != nil {
+		return fmt.Errorf("unable to get the block at height %d: %v", height, err)
+	}
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(blockBz, block); err != nil {
+		return err
+	}
+	treesAtHeight, err := m.stateTrees.AtHeight(height)
+	if err != nil {
+		return err
+	}
+	roots := treesAtHeight.roots
+	if stateHash := getStateHashFromRoots(roots); stateHash != block.BlockHeader.StateHash {
+		return fmt.Errorf("the state trees are at state hash %s rather than the one of height %d: %s", stateHash, height, block.BlockHeader.StateHash)
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	w := &snapshotWriter{dir: dir, chunk: new(types.SnapshotChunk)}
+	if err := p.exportSQLRows(height, w); err != nil {
+		return err
+	}
+	if err := treesAtHeight.export(w); err != nil {
+		return err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	manifest := &types.SnapshotManifest{
+		Version:     SnapshotVersion,
+		Height:      height,
+		StateHash:   block.BlockHeader.StateHash,
+		Block:       blockBz,
+		TreeRoots:   roots,
+		ChunkHashes: w.chunkHashes,
+	}
+	manifestBz, err := codec.GetCodec().Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotManifestFileName), manifestBz, 0o600); err != nil {
+		return err
+	}
+	log.Printf("Exported the snapshot at height %d in %d chunks\n", height, len(w.chunkHashes))
+	return nil
+}
+
+// `ImportSnapshot` replaces the state of the node with the snapshot stored in `dir`, which must be at the trusted
+// `stateHash`. The state is cleared if the snapshot cannot be imported.
+func (m *persistenceModule) ImportSnapshot(dir, stateHash string) error {
+	manifest, err := readSnapshotManifest(dir)
+	if err != nil {
+		return err
+	}
+	if err := m.clearAllState(nil); err != nil {
+		return err
+	}
+	if err := m.importSnapshot(dir, manifest, stateHash); err != nil {
+		if releaseErr := m.ReleaseWriteContext(); releaseErr != nil {
+			log.Printf("[ERROR] Error releasing the write context of the snapshot import: %v\n", releaseErr)
+		}
+		if clearErr := m.clearAllState(nil); clearErr != nil {
+			log.Printf("[ERROR] Error clearing the state of the snapshot import: %v\n", clearErr)
+		}
+		return err
+	}
+	log.Printf("Imported the snapshot at height %d with state hash %s\n", manifest.Height, manifest.StateHash)
+	return m.pruner.reset()
+}
+
+func (m *persistenceModule) importSnapshot(dir string, manifest *types.SnapshotManifest, trustedStateHash string) error {
+	block := new(coreTypes.Block)
+	if err := codec.GetCodec().Unmarshal(manifest.Block, block); err != nil {
+		return err
+	}
+	if block.BlockHeader.Height != uint64(manifest.Height) || block.BlockHeader.StateHash != trustedStateHash {
+		return fmt.Errorf("the block of the snapshot is not at height %d with the trusted state hash %s", manifest.Height, trustedStateHash)
+	}
+
+	rwCtx, err := m.NewRWContext(manifest.Height)
+	if err != nil {
+		return err
+	}
+	p := rwCtx.(*PostgresContext)
+
+	rowLeaves := newSnapshotLeaves()
+	govHeights := make(map[int64]struct{})
+	for i, chunkHash := range manifest.ChunkHashes {
+		chunk, err := readSnapshotChunk(dir, i, chunkHash)
+		if err != nil {
+			return err
+		}
+		if err := p.importSQLRows(chunk); err != nil {
+			return err
+		}
+		if err := rowLeaves.addRows(chunk); err != nil {
+			return err
+		}
+		for _, row := range chunk.GovRows {
+			govHeights[row.Height] = struct{}{}
+		}
+		if err := m.stateTrees.importEntries(manifest.Height, chunk.TreeEntries); err != nil {
+			return err
+		}
+	}
+	// The gov rows are read back with the queries of their trees since the types their values are scanned with
+	// depend on the SQL driver
+	for height := range govHeights {
+		if err := rowLeaves.addGovRows(p, height); err != nil {
+			return err
+		}
+	}
+	if err := m.stateTrees.importRoots(manifest.Height, manifest.TreeRoots); err != nil {
+		return err
+	}
+
+	stateHash, err := p.ComputeStateHash()
+	if err != nil {
+		return err
+	}
+	if stateHash != trustedStateHash {
+		return fmt.Errorf("the state hash of the imported snapshot is %s, expected %s", stateHash, trustedStateHash)
+	}
+	if err := rowLeaves.verify(m.stateTrees); err != nil {
+		return err
+	}
+
+	if err := p.insertBlock(block); err != nil {
+		return err
+	}
+	if err := p.getTx().Commit(context.TODO()); err != nil {
+		return err
+	}
+	if err := m.blockStore.Set(heightToBytes(manifest.Height), manifest.Block); err != nil {
+		return err
+	}
+	// The recomputed state hash did not change the trees, which are already at the roots of the snapshot
+	return m.ReleaseWriteContext()
+}
+
+func (p PostgresContext) exportSQLRows(height int64, w *snapshotWriter) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	for _, actorSchema := range protocolActorSchemas {
+		rows, err := tx.Query(ctx, actorSchema.GetAllQuery(height))
+		if err != nil {
+			return err
+		}
+		var actors []*coreTypes.Actor
+		var actorHeights []int64
+		for rows.Next() {
+			actor, actorHeight, err := p.getActorFromRow(actorSchema.GetActorType(), rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			actors = append(actors, actor)
+			actorHeights = append(actorHeights, actorHeight)
+		}
+		rows.Close()
+
+		for i, actor := range actors {
+			actor, err := p.getChainsForActor(ctx, tx, actorSchema, actor, height)
+			if err != nil {
+				return err
+			}
+			if err := w.addActorRow(&types.SnapshotActorRow{
+				Table:           actorSchema.GetTableName(),
+				Address:         actor.Address,
+				PublicKey:       actor.PublicKey,
+				StakedTokens:    actor.StakedAmount,
+				GenericParam:    actor.GenericParam,
+				OutputAddress:   actor.Output,
+				PausedHeight:    actor.PausedHeight,
+				UnstakingHeight: actor.UnstakingHeight,
+				Height:          actorHeights[i],
+				Chains:          actor.Chains,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, accountSchema := range []types.ProtocolAccountSchema{types.Account, types.Pool} {
+		rows, err := tx.Query(ctx, accountSchema.GetAllQuery(height))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			row := &types.SnapshotAccountRow{Table: accountSchema.GetTableName()}
+			if err := rows.Scan(&row.Identifier, &row.Balance, &row.Sequence, &row.Height); err != nil {
+				rows.Close()
+				return err
+			}
+			if err := w.addAccountRow(row); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+	}
+
+	for _, tableName := range []string{types.ParamsTableName, types.FlagsTableName} {
+		rows, err := tx.Query(ctx, types.SelectGovRows(tableName, height))
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			row := &types.SnapshotGovRow{Table: tableName}
+			dest := []any{&row.Name, &row.Height, &row.Type, &row.Value}
+			if tableName == types.FlagsTableName {
+				dest = append(dest, &row.Enabled)
+			}
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return err
+			}
+			if err := w.addGovRow(row); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+	}
+
+	return nil
+}
+
+func (p *PostgresContext) importSQLRows(chunk *types.SnapshotChunk) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range chunk.ActorRows {
+		actorSchema, err := getActorSchemaByTableName(row.Table)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, actorSchema.InsertQuery(row.Address, row.PublicKey, row.StakedTokens, row.GenericParam,
+			row.OutputAddress, row.PausedHeight, row.UnstakingHeight, row.Height)); err != nil {
+			return err
+		}
+		if actorSchema.GetChainsTableName() != "" && len(row.Chains) > 0 {
+			if _, err := tx.Exec(ctx, actorSchema.InsertChainsQuery(row.Address, row.Chains, row.Height)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, row := range chunk.AccountRows {
+		var accountSchema types.ProtocolAccountSchema
+		switch row.Table {
+		case types.AccountTableName:
+			accountSchema = types.Account
+		case types.PoolTableName:
+			accountSchema = types.Pool
+		default:
+			return fmt.Errorf("unknown account table in snapshot: %s", row.Table)
+		}
+		if _, err := tx.Exec(ctx, accountSchema.InsertAccountQuery(row.Identifier, row.Balance, row.Height)); err != nil {
+			return err
+		}
+		if row.Sequence != 0 {
+			if _, err := tx.Exec(ctx, types.InsertAccountSequence(row.Identifier, row.Sequence, row.Height)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, row := range chunk.GovRows {
+		if row.Table != types.ParamsTableName && row.Table != types.FlagsTableName {
+			return fmt.Errorf("unknown gov table in snapshot: %s", row.Table)
+		}
+		if _, err := tx.Exec(ctx, types.InsertGovRow(row.Table, row.Name, row.Height, row.Type, row.Value, row.Enabled)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// `snapshotLeaves` are the leaves of the state trees rebuilt from the SQL rows of a snapshot, i.e. the hash of their
+// value keyed by their path
+type snapshotLeaves map[merkleTree]map[string][]byte
+
+func newSnapshotLeaves() snapshotLeaves {
+	leaves := make(snapshotLeaves, int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		leaves[tree] = make(map[string][]byte)
+	}
+	return leaves
+}
+
+func (l snapshotLeaves) add(tree merkleTree, key, value []byte) error {
+	path, valueHash := sha256.Sum256(key), sha256.Sum256(value)
+	if _, ok := l[tree][string(path[:])]; ok {
+		return fmt.Errorf("the snapshot has more than one row for the %s leaf %x", merkleTreeToString[tree], key)
+	}
+	l[tree][string(path[:])] = valueHash[:]
+	return nil
+}
+
+// `addRows` adds the leaves of the actor and account rows of a chunk, built as when their trees are updated
+func (l snapshotLeaves) addRows(chunk *types.SnapshotChunk) error {
+	for _, row := range chunk.ActorRows {
+		actorSchema, err := getActorSchemaByTableName(row.Table)
+		if err != nil {
+			return err
+		}
+		actor := &coreTypes.Actor{
+			ActorType:       actorSchema.GetActorType(),
+			Address:         row.Address,
+			PublicKey:       row.PublicKey,
+			Chains:          row.Chains,
+			GenericParam:    row.GenericParam,
+			StakedAmount:    row.StakedTokens,
+			PausedHeight:    row.PausedHeight,
+			UnstakingHeight: row.UnstakingHeight,
+			Output:          row.OutputAddress,
+		}
+		key, value, err := getActorTreeLeaf(actor)
+		if err != nil {
+			return err
+		}
+		if err := l.add(actorTypeToMerkleTreeName[actor.ActorType], key, value); err != nil {
+			return err
+		}
+	}
+	for _, row := range chunk.AccountRows {
+		account := &coreTypes.Account{Address: row.Identifier, Amount: row.Balance, Sequence: row.Sequence}
+		tree, getTreeLeaf := accountMerkleTree, getAccountTreeLeaf
+		switch row.Table {
+		case types.AccountTableName:
+		case types.PoolTableName:
+			tree, getTreeLeaf = poolMerkleTree, getPoolTreeLeaf
+		default:
+			return fmt.Errorf("unknown account table in snapshot: %s", row.Table)
+		}
+		key, value, err := getTreeLeaf(account)
+		if err != nil {
+			return err
+		}
+		if err := l.add(tree, key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `addGovRows` adds the params and flags imported at `height`. Their leaves are keyed by their version-less value
+// rather than by their path, see `verify`.
+func (l snapshotLeaves) addGovRows(p *PostgresContext, height int64) error {
+	params, err := p.getParamsUpdated(height)
+	if err != nil {
+		return err
+	}
+	for _, param := range params {
+		_, value, err := getParamTreeLeaf(param)
+		if err != nil {
+			return err
+		}
+		if err := l.addGovLeaf(paramsMerkleTree, value); err != nil {
+			return err
+		}
+	}
+	flags, err := p.getFlagsUpdated(height)
+	if err != nil {
+		return err
+	}
+	for _, flag := range flags {
+		_, value, err := getFlagTreeLeaf(flag)
+		if err != nil {
+			return err
+		}
+		if err := l.addGovLeaf(flagsMerkleTree, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l snapshotLeaves) addGovLeaf(tree merkleTree, value []byte) error {
+	unversionedValue, err := getUnversionedGovLeafValue(tree, value)
+	if err != nil {
+		return err
+	}
+	l[tree][unversionedValue] = nil
+	return nil
+}
+
+// `getUnversionedGovLeafValue` returns the value of a leaf of the params or flags tree without the height of the version
+func getUnversionedGovLeafValue(tree merkleTree, value []byte) (string, error) {
+	var leaf proto.Message
+	switch tree {
+	case paramsMerkleTree:
+		param := new(coreTypes.Param)
+		if err := codec.GetCodec().Unmarshal(value, param); err != nil {
+			return "", err
+		}
+		param.Height, leaf = 0, param
+	case flagsMerkleTree:
+		flag := new(coreTypes.Flag)
+		if err := codec.GetCodec().Unmarshal(value, flag); err != nil {
+			return "", err
+		}
+		flag.Height, leaf = 0, flag
+	default:
+		return "", fmt.Errorf("the %s tree is not versioned", merkleTreeToString[tree])
+	}
+	bz, err := codec.GetCodec().Marshal(leaf)
+	return string(bz), err
+}
+
+// `verify` ensures the leaves rebuilt from the rows are the ones of the imported trees. The params and flags trees
+// hold every version of the params and flags, so only their latest versions are expected to be among their leaves.
+// These are compared regardless of the height of their version: the rows of a genesis state exported from the state
+// of a node are at the genesis height while their leaves are at the height they were set at.
+func (l snapshotLeaves) verify(trees *stateTrees) error {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		switch tree {
+		case transactionsMerkleTree:
+			continue
+		case paramsMerkleTree, flagsMerkleTree:
+			if err := l.verifyGovLeaves(trees, tree); err != nil {
+				return err
+			}
+			continue
+		}
+		treeLeaves, err := trees.getLeaves(tree)
+		if err != nil {
+			return err
+		}
+		if len(treeLeaves) != len(l[tree]) {
+			return fmt.Errorf("the snapshot has %d rows for the %d leaves of the %s tree", len(l[tree]), len(treeLeaves), merkleTreeToString[tree])
+		}
+		for path, valueHash := range l[tree] {
+			if !bytes.Equal(treeLeaves[path], valueHash) {
+				return fmt.Errorf("a row of the snapshot does not match the %s tree", merkleTreeToString[tree])
+			}
+		}
+	}
+	return nil
+}
+
+func (l snapshotLeaves) verifyGovLeaves(trees *stateTrees, tree merkleTree) error {
+	treeLeaves := make(map[string]struct{})
+	err := walkTree(tree, trees.nodeStores[tree], trees.valueStores[tree], trees.committedRoots[tree], nil, func(path, value []byte) error {
+		unversionedValue, err := getUnversionedGovLeafValue(tree, value)
+		treeLeaves[unversionedValue] = struct{}{}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for unversionedValue := range l[tree] {
+		if _, ok := treeLeaves[unversionedValue]; !ok {
+			return fmt.Errorf("a row of the snapshot does not match the %s tree", merkleTreeToString[tree])
+		}
+	}
+	return nil
+}
+
+func getActorSchemaByTableName(tableName string) (types.ProtocolActorSchema, error) {
+	for _, actorSchema := range protocolActorSchemas {
+		if actorSchema.GetTableName() == tableName {
+			return actorSchema, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown actor table in snapshot: %s", tableName)
+}
+
+// `snapshotWriter` accumulates the entries of a snapshot and writes them to a new chunk every `snapshotChunkSize` entries
+type snapshotWriter struct {
+	dir         string
+	chunk       *types.SnapshotChunk
+	numEntries  int
+	chunkHashes [][]byte
+}
+
+func (w *snapshotWriter) addActorRow(row *types.SnapshotActorRow) error {
+	w.chunk.ActorRows = append(w.chunk.ActorRows, row)
+	return w.added()
+}
+
+func (w *snapshotWriter) addAccountRow(row *types.SnapshotAccountRow) error {
+	w.chunk.AccountRows = append(w.chunk.AccountRows, row)
+	return w.added()
+}
+
+func (w *snapshotWriter) addGovRow(row *types.SnapshotGovRow) error {
+	w.chunk.GovRows = append(w.chunk.GovRows, row)
+	return w.added()
+}
+
+func (w *snapshotWriter) addTreeEntry(entry *types.SnapshotTreeEntry) error {
+	w.chunk.TreeEntries = append(w.chunk.TreeEntries, entry)
+	return w.added()
+}
+
+func (w *snapshotWriter) added() error {
+	w.numEntries++
+	if w.numEntries < snapshotChunkSize {
+		return nil
+	}
+	return w.flush()
+}
+
+// `flush` writes the entries accumulated since the last chunk to a new chunk, if any
+func (w *snapshotWriter) flush() error {
+	if w.numEntries == 0 {
+		return nil
+	}
+	chunkBz, err := codec.GetCodec().Marshal(w.chunk)
+	if err != nil {
+		return err
+	}
+	chunkFileName := fmt.Sprintf(snapshotChunkFileNameFormat, len(w.chunkHashes))
+	if err := os.WriteFile(filepath.Join(w.dir, chunkFileName), chunkBz, 0o600); err != nil {
+		return err
+	}
+	chunkHash := sha256.Sum256(chunkBz)
+	w.chunkHashes = append(w.chunkHashes, chunkHash[:])
+	w.chunk, w.numEntries = new(types.SnapshotChunk), 0
+	return nil
+}
+
+func readSnapshotManifest(dir string) (*types.SnapshotManifest, error) {
+	manifestBz, err := os.ReadFile(filepath.Join(dir, snapshotManifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the snapshot manifest: %v", err)
+	}
+	manifest := new(types.SnapshotManifest)
+	if err := codec.GetCodec().Unmarshal(manifestBz, manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d, expected %d", manifest.Version, SnapshotVersion)
+	}
+	return manifest, nil
+}
+
+func readSnapshotChunk(dir string, index int, expectedHash []byte) (*types.SnapshotChunk, error) {
+	chunkBz, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf(snapshotChunkFileNameFormat, index)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot chunk %d: %v", index, err)
+	}
+	if chunkHash := sha256.Sum256(chunkBz); !bytes.Equal(chunkHash[:], expectedHash) {
+		return nil, fmt.Errorf("the hash of snapshot chunk %d does not match the manifest", index)
+	}
+	chunk := new(types.SnapshotChunk)
+	if err := codec.GetCodec().Unmarshal(chunkBz, chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pokt-network/pocket/runtime/configs"
+)
+
+/*
+	The SQL database backing the persistence module is abstracted behind the `SQLDriver` interface so the module
+	can either run on top of Postgres (i.e. the production setup) or on top of an embedded SQLite database which
+	does not require any external process (e.g. for tests or for a LocalNet running in a single process).
+
+	All the queries built in `persistence/types` are restricted to the SQL dialect supported by both databases.
+*/
+
+const (
+	PostgresSQLDriver = "postgres"
+	SQLiteSQLDriver   = "sqlite"
+)
+
+// Returned by `SQLRow.Scan` when the query did not return any row, regardless of the driver
+var errNoRows = errors.New("no rows in result set")
+
+// `SQLDriver` opens the connections to the SQL database selected in the persistence config
+type SQLDriver interface {
+	// `Connect` acquires a connection to the database from the pool of the driver, scoped to the schema of the node.
+	// The connections are independent, so the read contexts can run alongside the write context.
+	Connect(ctx context.Context) (SQLConn, error)
+	// `CreateEnumType` creates the enum type `name` if it does not exist yet. It is a no-op for the databases
+	// without custom types, in which case the values of the columns of that type are not constrained.
+	CreateEnumType(ctx context.Context, conn SQLConn, name, values string) error
+	Close() error
+}
+
+type SQLConn interface {
+	Exec(ctx context.Context, query string) (rowsAffected int64, err error)
+	// `BeginTx` starts a read committed transaction if `readOnly`, and a read-write one otherwise
+	BeginTx(ctx context.Context, readOnly bool) (SQLTx, error)
+	// `Close` rolls back the transaction in progress on the connection, if any, and releases it back to the pool
+	Close(ctx context.Context) error
+	IsClosed() bool
+}
+
+type SQLTx interface {
+	Exec(ctx context.Context, query string) (rowsAffected int64, err error)
+	Query(ctx context.Context, query string) (SQLRows, error)
+	QueryRow(ctx context.Context, query string) SQLRow
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+	Conn() SQLConn
+}
+
+type SQLRows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close()
+}
+
+type SQLRow interface {
+	Scan(dest ...any) error
+}
+
+func newSQLDriver(cfg *configs.PersistenceConfig) (SQLDriver, error) {
+	switch cfg.GetSqlDriver() {
+	case "", PostgresSQLDriver:
+		return newPostgresDriver(cfg)
+	case SQLiteSQLDriver:
+		return newSQLiteDriver(cfg.GetSqlitePath())
+	default:
+		return nil, fmt.Errorf("unsupported SQL driver: %s", cfg.GetSqlDriver())
+	}
+}
@@ -0,0 +1,176 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite" // Registers the pure Go `sqlite` driver of `database/sql`
+)
+
+// The connections of the embedded database wait for the lock of the database rather than failing right away when
+// it is held by another one, and write-ahead logging lets the read contexts run alongside the write context.
+const sqliteConnParams = "_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+
+var (
+	_ SQLDriver = &sqliteDriver{}
+	_ SQLConn   = &sqliteConn{}
+	_ SQLTx     = &sqliteTx{}
+	_ SQLRows   = &sqliteRows{}
+)
+
+// `sqliteDriver` backs the persistence module with an embedded SQLite database stored in a single file. Since the
+// file is specific to a node, the `node_schema` of the config is not needed to run multiple nodes in one process.
+type sqliteDriver struct {
+	db *sql.DB
+}
+
+type sqliteConn struct {
+	conn     *sql.Conn
+	isInTx   bool
+	isClosed bool
+}
+
+type sqliteTx struct {
+	conn *sqliteConn
+}
+
+type sqliteRows struct {
+	rows *sql.Rows
+}
+
+type sqliteRow struct {
+	row *sql.Row
+}
+
+func newSQLiteDriver(path string) (*sqliteDriver, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the sqlite path must be set to use the %s SQL driver", SQLiteSQLDriver)
+	}
+	db, err := sql.Open(SQLiteSQLDriver, fmt.Sprintf("%s?%s", path, sqliteConnParams))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the sqlite database: %v", err)
+	}
+	return &sqliteDriver{db: db}, nil
+}
+
+func (d *sqliteDriver) Connect(ctx context.Context) (SQLConn, error) {
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %v", err)
+	}
+	return &sqliteConn{conn: conn}, nil
+}
+
+// SQLite has no custom types: the columns of an enum type are created with the numeric type affinity
+func (d *sqliteDriver) CreateEnumType(_ context.Context, _ SQLConn, _, _ string) error {
+	return nil
+}
+
+func (d *sqliteDriver) Close() error {
+	return d.db.Close()
+}
+
+func (c *sqliteConn) Exec(ctx context.Context, query string) (int64, error) {
+	result, err := c.conn.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// The transactions are managed with SQL statements rather than with `database/sql` so the save points of the
+// persistence module can be executed within them. The write transactions take the lock of the database when they
+// begin so they cannot fail to upgrade their lock once they started reading.
+func (c *sqliteConn) BeginTx(ctx context.Context, readOnly bool) (SQLTx, error) {
+	beginQuery := "BEGIN IMMEDIATE"
+	if readOnly {
+		beginQuery = "BEGIN DEFERRED"
+	}
+	if _, err := c.Exec(ctx, beginQuery); err != nil {
+		return nil, err
+	}
+	c.isInTx = true
+	return &sqliteTx{conn: c}, nil
+}
+
+func (c *sqliteConn) Close(ctx context.Context) error {
+	if c.isClosed {
+		return nil
+	}
+	// The connection is returned to the pool of the driver when closed, so its transaction must not outlive it
+	if c.isInTx {
+		if _, err := c.Exec(ctx, "ROLLBACK"); err != nil {
+			return err
+		}
+		c.isInTx = false
+	}
+	c.isClosed = true
+	return c.conn.Close()
+}
+
+func (c *sqliteConn) IsClosed() bool {
+	return c.isClosed
+}
+
+func (t *sqliteTx) Exec(ctx context.Context, query string) (int64, error) {
+	return t.conn.Exec(ctx, query)
+}
+
+func (t *sqliteTx) Query(ctx context.Context, query string) (SQLRows, error) {
+	rows, err := t.conn.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{rows: rows}, nil
+}
+
+func (t *sqliteTx) QueryRow(ctx context.Context, query string) SQLRow {
+	return sqliteRow{row: t.conn.conn.QueryRowContext(ctx, query)}
+}
+
+func (t *sqliteTx) Commit(ctx context.Context) error {
+	return t.end(ctx, "COMMIT")
+}
+
+func (t *sqliteTx) Rollback(ctx context.Context) error {
+	return t.end(ctx, "ROLLBACK")
+}
+
+func (t *sqliteTx) Conn() SQLConn {
+	return t.conn
+}
+
+func (t *sqliteTx) end(ctx context.Context, query string) error {
+	if !t.conn.isInTx {
+		return fmt.Errorf("the sqlite transaction has already been committed or rolled back")
+	}
+	if _, err := t.conn.Exec(ctx, query); err != nil {
+		return err
+	}
+	t.conn.isInTx = false
+	return nil
+}
+
+func (r *sqliteRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r *sqliteRows) Scan(dest ...any) error {
+	return r.rows.Scan(dest...)
+}
+
+func (r *sqliteRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r *sqliteRows) Close() {
+	r.rows.Close()
+}
+
+func (r sqliteRow) Scan(dest ...any) error {
+	if err := r.row.Scan(dest...); err != sql.ErrNoRows {
+		return err
+	}
+	return errNoRows
+}
@@ -17,6 +17,9 @@ import (
 
 type merkleTree float64
 
+// The prefix of the leaf nodes of the trees, as opposed to their inner nodes, in `celestiaorg/smt`
+const smtLeafPrefix = byte(0)
+
 type stateTrees struct {
 	merkleTrees map[merkleTree]*smt.SparseMerkleTree
 
@@ -24,6 +27,22 @@ type stateTrees struct {
 	// and debugging purposes
 	nodeStores  map[merkleTree]kvstore.KVStore
 	valueStores map[merkleTree]kvstore.KVStore
+
+	// The trees are built on top of staged stores so their updates are only persisted when the
+	// context is committed, and can be rolled back in lockstep with the SQL save points until then
+	stagedNodeStores  map[merkleTree]*kvstore.StagedStore
+	stagedValueStores map[merkleTree]*kvstore.StagedStore
+	committedRoots    map[merkleTree][]byte
+
+	// The trees cannot be reopened below the earliest height retained by the pruner
+	pruner *pruner
+}
+
+// `treesSavePoint` captures the state of every tree when a save point is created
+type treesSavePoint struct {
+	roots       map[merkleTree][]byte
+	nodesMarks  map[merkleTree]int
+	valuesMarks map[merkleTree]int
 }
 
 // A list of Merkle Trees used to maintain the state hash.
@@ -47,7 +66,8 @@ const (
 	paramsMerkleTree
 	flagsMerkleTree
 
-	// Used for iteration purposes only; see https://stackoverflow.com/a/64178235/768439 as a reference
+	// Used for iteration purposes only; see https://stackoverflow.com/a/64178235/768439 as a reference.
+	// IMPORTANT: `NumStateTrees` in `shared/core/types` must be updated along with it to verify the state proofs.
 	numMerkleTrees
 )
 
@@ -71,6 +91,14 @@ var merkleTreeToString = map[merkleTree]string{
 	flagsMerkleTree:        "flags",
 }
 
+var stringToMerkleTree = func() map[string]merkleTree {
+	trees := make(map[string]merkleTree, len(merkleTreeToString))
+	for tree, treeName := range merkleTreeToString {
+		trees[treeName] = tree
+	}
+	return trees
+}()
+
 var actorTypeToMerkleTreeName = map[coreTypes.ActorType]merkleTree{
 	coreTypes.ActorType_ACTOR_TYPE_APP:         appMerkleTree,
 	coreTypes.ActorType_ACTOR_TYPE_VAL:         valMerkleTree,
@@ -97,11 +125,7 @@ func newStateTrees(treesStoreDir string) (*stateTrees, error) {
 		return newMemStateTrees()
 	}
 
-	stateTrees := &stateTrees{
-		merkleTrees: make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
-		nodeStores:  make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-		valueStores: make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-	}
+	stateTrees := newEmptyStateTrees()
 
 	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
 		nodeStore, err := kvstore.NewKVStore(fmt.Sprintf("%s/%s_nodes", treesStoreDir, merkleTreeToString[tree]))
@@ -114,27 +138,240 @@ func newStateTrees(treesStoreDir string) (*stateTrees, error) {
 		}
 		stateTrees.nodeStores[tree] = nodeStore
 		stateTrees.valueStores[tree] = valueStore
-		stateTrees.merkleTrees[tree] = smt.NewSparseMerkleTree(nodeStore, valueStore, sha256.New())
+		stateTrees.resetTree(tree)
 	}
 	return stateTrees, nil
 }
 
 func newMemStateTrees() (*stateTrees, error) {
-	stateTrees := &stateTrees{
-		merkleTrees: make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
-		nodeStores:  make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-		valueStores: make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
-	}
+	stateTrees := newEmptyStateTrees()
 	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
 		nodeStore := kvstore.NewMemKVStore() // For testing, `smt.NewSimpleMap()` can be used as well
 		valueStore := kvstore.NewMemKVStore()
 		stateTrees.nodeStores[tree] = nodeStore
 		stateTrees.valueStores[tree] = valueStore
-		stateTrees.merkleTrees[tree] = smt.NewSparseMerkleTree(nodeStore, valueStore, sha256.New())
+		stateTrees.resetTree(tree)
 	}
 	return stateTrees, nil
 }
 
+func newEmptyStateTrees() *stateTrees {
+	return &stateTrees{
+		merkleTrees:       make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
+		nodeStores:        make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
+		valueStores:       make(map[merkleTree]kvstore.KVStore, int(numMerkleTrees)),
+		stagedNodeStores:  make(map[merkleTree]*kvstore.StagedStore, int(numMerkleTrees)),
+		stagedValueStores: make(map[merkleTree]*kvstore.StagedStore, int(numMerkleTrees)),
+		committedRoots:    make(map[merkleTree][]byte, int(numMerkleTrees)),
+	}
+}
+
+// `resetTree` (re)builds an empty tree on top of fresh staged stores over the tree's underlying stores
+func (t *stateTrees) resetTree(tree merkleTree) {
+	t.stagedNodeStores[tree] = kvstore.NewStagedStore(t.nodeStores[tree])
+	t.stagedValueStores[tree] = kvstore.NewStagedStore(t.valueStores[tree])
+	t.merkleTrees[tree] = smt.NewSparseMerkleTree(&retainedNodeStore{t.stagedNodeStores[tree]}, t.stagedValueStores[tree], sha256.New())
+	t.committedRoots[tree] = t.merkleTrees[tree].Root()
+}
+
+func (t *stateTrees) savePoint() treesSavePoint {
+	savePoint := treesSavePoint{
+		roots:       make(map[merkleTree][]byte, int(numMerkleTrees)),
+		nodesMarks:  make(map[merkleTree]int, int(numMerkleTrees)),
+		valuesMarks: make(map[merkleTree]int, int(numMerkleTrees)),
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		savePoint.roots[tree] = t.merkleTrees[tree].Root()
+		savePoint.nodesMarks[tree] = t.stagedNodeStores[tree].Mark()
+		savePoint.valuesMarks[tree] = t.stagedValueStores[tree].Mark()
+	}
+	return savePoint
+}
+
+func (t *stateTrees) rollbackToSavePoint(savePoint treesSavePoint) {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		t.stagedNodeStores[tree].RevertTo(savePoint.nodesMarks[tree])
+		t.stagedValueStores[tree].RevertTo(savePoint.valuesMarks[tree])
+		t.merkleTrees[tree].SetRoot(savePoint.roots[tree])
+	}
+}
+
+// `getStagedWrites` returns the staged updates of every tree so they can be journaled before being committed
+func (t *stateTrees) getStagedWrites() []*types.CommitJournalTree {
+	trees := make([]*types.CommitJournalTree, int(numMerkleTrees))
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		trees[int(tree)] = &types.CommitJournalTree{
+			Root:        t.merkleTrees[tree].Root(),
+			NodeWrites:  newCommitJournalWrites(t.stagedNodeStores[tree].Writes()),
+			ValueWrites: newCommitJournalWrites(t.stagedValueStores[tree].Writes()),
+		}
+	}
+	return trees
+}
+
+// `applyJournaledWrites` persists the journaled updates of every tree at `height` to their underlying stores, along
+// with their history, and moves the trees to their journaled roots. It is idempotent so it can be replayed.
+func (t *stateTrees) applyJournaledWrites(height int64, trees []*types.CommitJournalTree) error {
+	if len(trees) != int(numMerkleTrees) {
+		return fmt.Errorf("expected the writes of %d trees to be journaled, got %d", int(numMerkleTrees), len(trees))
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		journaledTree := trees[int(tree)]
+		if err := applyCommitJournalWrites(t.nodeStores[tree], journaledTree.NodeWrites); err != nil {
+			return err
+		}
+		if err := applyCommitJournalWrites(t.valueStores[tree], journaledTree.ValueWrites); err != nil {
+			return err
+		}
+		valueKeys, values := commitJournalWritesToKeyValues(journaledTree.ValueWrites)
+		if err := writeTreeHistory(t.nodeStores[tree], t.valueStores[tree], height, journaledTree.Root, valueKeys, values); err != nil {
+			return err
+		}
+		t.stagedNodeStores[tree].Discard()
+		t.stagedValueStores[tree].Discard()
+		t.merkleTrees[tree].SetRoot(journaledTree.Root)
+		t.committedRoots[tree] = journaledTree.Root
+	}
+	return nil
+}
+
+// `discard` drops the staged updates of every tree and resets them to their last committed root
+func (t *stateTrees) discard() {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		t.stagedNodeStores[tree].Discard()
+		t.stagedValueStores[tree].Discard()
+		t.merkleTrees[tree].SetRoot(t.committedRoots[tree])
+	}
+}
+
+// `setCommittedRoots` moves the trees to the given roots, whose nodes must already be in the underlying stores
+func (t *stateTrees) setCommittedRoots(roots [][]byte) error {
+	if len(roots) != int(numMerkleTrees) {
+		return fmt.Errorf("expected the roots of %d trees, got %d", int(numMerkleTrees), len(roots))
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		t.merkleTrees[tree].SetRoot(roots[int(tree)])
+		t.committedRoots[tree] = roots[int(tree)]
+	}
+	return nil
+}
+
+// `export` adds the nodes reachable from the roots of the trees at their height, and the values of their leaves, to a
+// snapshot. The history of the trees is left out so the snapshot only holds the state at its height.
+func (t *stateTreesAtHeight) export(w *snapshotWriter) error {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		onNode := func(nodeHash, node []byte) error {
+			return w.addTreeEntry(&types.SnapshotTreeEntry{Tree: uint32(tree), Key: nodeHash, Value: node})
+		}
+		onLeaf := func(path, value []byte) error {
+			return w.addTreeEntry(&types.SnapshotTreeEntry{Tree: uint32(tree), IsValue: true, Key: path, Value: value})
+		}
+		if err := walkTree(tree, t.nodeStores[tree], t.valueStores[tree], t.roots[int(tree)], onNode, onLeaf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `importEntries` writes the nodes and values of a snapshot at `height` directly to the underlying stores of the trees,
+// the values being written along with their version at `height`. The nodes must be keyed by their hash and the values
+// by their path, so the entries cannot overwrite the history of the trees.
+func (t *stateTrees) importEntries(height int64, entries []*types.SnapshotTreeEntry) error {
+	for _, entry := range entries {
+		tree := merkleTree(entry.Tree)
+		if tree >= numMerkleTrees {
+			return fmt.Errorf("unknown state tree in snapshot: %d", entry.Tree)
+		}
+		if len(entry.Key) != sha256.Size {
+			return fmt.Errorf("invalid key %x of the %s tree in snapshot", entry.Key, merkleTreeToString[tree])
+		}
+		if !entry.IsValue {
+			if digest := sha256.Sum256(entry.Value); !bytes.Equal(digest[:], entry.Key) {
+				return fmt.Errorf("invalid node %x of the %s tree in snapshot", entry.Key, merkleTreeToString[tree])
+			}
+			if err := t.nodeStores[tree].Set(entry.Key, entry.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := t.valueStores[tree].Set(entry.Key, entry.Value); err != nil {
+			return err
+		}
+		if err := t.valueStores[tree].Set(treeValueVersionKey(entry.Key, height), entry.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `importRoots` stores the roots of the trees imported at `height` and moves the trees to them
+func (t *stateTrees) importRoots(height int64, roots [][]byte) error {
+	if err := t.setCommittedRoots(roots); err != nil {
+		return err
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		if err := writeTreeHistory(t.nodeStores[tree], t.valueStores[tree], height, roots[int(tree)], nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `getLeaves` returns the value hash of every leaf of the tree at its committed root, keyed by the path of the leaf
+func (t *stateTrees) getLeaves(tree merkleTree) (map[string][]byte, error) {
+	leaves := make(map[string][]byte)
+	err := walkTree(tree, t.nodeStores[tree], t.valueStores[tree], t.committedRoots[tree], nil, func(path, value []byte) error {
+		valueHash := sha256.Sum256(value)
+		leaves[string(path)] = valueHash[:]
+		return nil
+	})
+	return leaves, err
+}
+
+// `walkTree` walks the nodes of `tree` reachable from `root`, calling `onNode` with every node and `onLeaf` with the
+// path and the value of every leaf. Since the nodes are walked from the root and the values read by path, the hash of
+// every node and value is checked against the reference to it so the content of the stores is authenticated by the root.
+func walkTree(tree merkleTree, nodes, values smt.MapStore, root []byte, onNode func(nodeHash, node []byte) error, onLeaf func(path, value []byte) error) error {
+	placeholder := make([]byte, sha256.Size)
+	nodeHashes := [][]byte{root}
+	for len(nodeHashes) > 0 {
+		nodeHash := nodeHashes[len(nodeHashes)-1]
+		nodeHashes = nodeHashes[:len(nodeHashes)-1]
+		if bytes.Equal(nodeHash, placeholder) {
+			continue
+		}
+		node, err := nodes.Get(nodeHash)
+		if err != nil {
+			return fmt.Errorf("missing node %x of the %s tree: %w", nodeHash, merkleTreeToString[tree], err)
+		}
+		if digest := sha256.Sum256(node); len(node) != 1+2*sha256.Size || !bytes.Equal(digest[:], nodeHash) {
+			return fmt.Errorf("invalid node %x of the %s tree", nodeHash, merkleTreeToString[tree])
+		}
+		if onNode != nil {
+			if err := onNode(nodeHash, node); err != nil {
+				return err
+			}
+		}
+		left, right := node[1:1+sha256.Size], node[1+sha256.Size:]
+		if node[0] != smtLeafPrefix {
+			nodeHashes = append(nodeHashes, left, right)
+			continue
+		}
+		// The children of a leaf are its path and the hash of its value
+		value, err := values.Get(left)
+		if err != nil {
+			return fmt.Errorf("missing value %x of the %s tree: %w", left, merkleTreeToString[tree], err)
+		}
+		if digest := sha256.Sum256(value); !bytes.Equal(digest[:], right) {
+			return fmt.Errorf("invalid value %x of the %s tree", left, merkleTreeToString[tree])
+		}
+		if err := onLeaf(left, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *PostgresContext) updateMerkleTrees() (string, error) {
 	// Update all the merkle trees
 	for treeType := merkleTree(0); treeType < numMerkleTrees; treeType++ {
@@ -194,13 +431,12 @@ func (p *PostgresContext) getStateHash() string {
 	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
 		roots = append(roots, p.stateTrees.merkleTrees[tree].Root())
 	}
+	return getStateHashFromRoots(roots)
+}
 
-	// Get the state hash
-	rootsConcat := bytes.Join(roots, []byte{})
-	stateHash := sha256.Sum256(rootsConcat)
-
-	// Convert the array to a slice and return it
-	return hex.EncodeToString(stateHash[:])
+func getStateHashFromRoots(roots [][]byte) string {
+	// The hashing of the roots is shared with the verification of state proofs
+	return coreTypes.GetStateHash(roots)
 }
 
 // Transactions Hash Helpers
@@ -208,10 +444,7 @@ func (p *PostgresContext) getStateHash() string {
 // Returns a digest (a single hash) of all the transactions included in the block.
 // This allows separating the integrity of the transactions from their storage.
 func (p PostgresContext) getTxsHash() (txs []byte, err error) {
-	txResults, err := p.txIndexer.GetByHeight(p.Height, txsOrderInBlockHashDescending)
-	if err != nil {
-		return nil, err
-	}
+	txResults := p.pending.getTxResults(txsOrderInBlockHashDescending)
 
 	for _, txResult := range txResults {
 		txHash, err := txResult.Hash()
@@ -233,12 +466,7 @@ func (p *PostgresContext) updateActorsTree(actorType coreTypes.ActorType) error
 	}
 
 	for _, actor := range actors {
-		bzAddr, err := hex.DecodeString(actor.GetAddress())
-		if err != nil {
-			return err
-		}
-
-		actorBz, err := codec.GetCodec().Marshal(actor)
+		bzAddr, actorBz, err := getActorTreeLeaf(actor)
 		if err != nil {
 			return err
 		}
@@ -255,6 +483,14 @@ func (p *PostgresContext) updateActorsTree(actorType coreTypes.ActorType) error
 	return nil
 }
 
+func getActorTreeLeaf(actor *coreTypes.Actor) (key, value []byte, err error) {
+	if key, err = hex.DecodeString(actor.GetAddress()); err != nil {
+		return
+	}
+	value, err = codec.GetCodec().Marshal(actor)
+	return
+}
+
 func (p *PostgresContext) getActorsUpdatedAtHeight(actorType coreTypes.ActorType, height int64) (actors []*coreTypes.Actor, err error) {
 	actorSchema, ok := actorTypeToSchemaName[actorType]
 	if !ok {
@@ -293,12 +529,7 @@ func (p *PostgresContext) updateAccountTrees() error {
 	}
 
 	for _, account := range accounts {
-		bzAddr, err := hex.DecodeString(account.GetAddress())
-		if err != nil {
-			return err
-		}
-
-		accBz, err := codec.GetCodec().Marshal(account)
+		bzAddr, accBz, err := getAccountTreeLeaf(account)
 		if err != nil {
 			return err
 		}
@@ -311,6 +542,14 @@ func (p *PostgresContext) updateAccountTrees() error {
 	return nil
 }
 
+func getAccountTreeLeaf(account *coreTypes.Account) (key, value []byte, err error) {
+	if key, err = hex.DecodeString(account.GetAddress()); err != nil {
+		return
+	}
+	value, err = codec.GetCodec().Marshal(account)
+	return
+}
+
 func (p *PostgresContext) updatePoolTrees() error {
 	pools, err := p.GetPoolsUpdated(p.Height)
 	if err != nil {
@@ -318,8 +557,7 @@ func (p *PostgresContext) updatePoolTrees() error {
 	}
 
 	for _, pool := range pools {
-		bzAddr := []byte(pool.GetAddress())
-		accBz, err := codec.GetCodec().Marshal(pool)
+		bzAddr, accBz, err := getPoolTreeLeaf(pool)
 		if err != nil {
 			return err
 		}
@@ -332,13 +570,15 @@ func (p *PostgresContext) updatePoolTrees() error {
 	return nil
 }
 
+func getPoolTreeLeaf(pool *coreTypes.Account) (key, value []byte, err error) {
+	value, err = codec.GetCodec().Marshal(pool)
+	return []byte(pool.GetAddress()), value, err
+}
+
 // Data Tree Helpers
 
 func (p *PostgresContext) updateTransactionsTree() error {
-	txResults, err := p.txIndexer.GetByHeight(p.Height, false)
-	if err != nil {
-		return err
-	}
+	txResults := p.pending.getTxResults(false)
 
 	for _, txResult := range txResults {
 		txHash, err := txResult.Hash()
@@ -360,12 +600,11 @@ func (p *PostgresContext) updateParamsTree() error {
 	}
 
 	for _, param := range params {
-		paramKey := crypto.SHA3Hash([]byte(param.String()))
-		paramBz, err := codec.GetCodec().Marshal(param)
+		paramKey, paramBz, err := getParamTreeLeaf(param)
 		if err != nil {
 			return err
 		}
-		if _, err := p.stateTrees.merkleTrees[paramsMerkleTree].Update(paramKey[:], paramBz); err != nil {
+		if _, err := p.stateTrees.merkleTrees[paramsMerkleTree].Update(paramKey, paramBz); err != nil {
 			return err
 		}
 	}
@@ -373,6 +612,11 @@ func (p *PostgresContext) updateParamsTree() error {
 	return nil
 }
 
+func getParamTreeLeaf(param *coreTypes.Param) (key, value []byte, err error) {
+	value, err = codec.GetCodec().Marshal(param)
+	return crypto.SHA3Hash([]byte(param.String())), value, err
+}
+
 func (p *PostgresContext) updateFlagsTree() error {
 	flags, err := p.getFlagsUpdated(p.Height)
 	if err != nil {
@@ -380,15 +624,19 @@ func (p *PostgresContext) updateFlagsTree() error {
 	}
 
 	for _, flag := range flags {
-		flagKey := crypto.SHA3Hash([]byte(flag.String()))
-		flagBz, err := codec.GetCodec().Marshal(flag)
+		flagKey, flagBz, err := getFlagTreeLeaf(flag)
 		if err != nil {
 			return err
 		}
-		if _, err := p.stateTrees.merkleTrees[flagsMerkleTree].Update(flagKey[:], flagBz); err != nil {
+		if _, err := p.stateTrees.merkleTrees[flagsMerkleTree].Update(flagKey, flagBz); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func getFlagTreeLeaf(flag *coreTypes.Flag) (key, value []byte, err error) {
+	value, err = codec.GetCodec().Marshal(flag)
+	return crypto.SHA3Hash([]byte(flag.String())), value, err
+}
@@ -0,0 +1,214 @@
+package persistence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+)
+
+/*
+	The state trees keep their history so they can be reopened read-only at any past height:
+	  - The nodes of a tree are never deleted. They are content addressed, so the nodes of every past root remain
+	    in the node store.
+	  - The root of every tree is stored in its node store at every committed height, under `root/<height>`.
+	  - Every value written to a tree is also stored in its value store under `value/<path><height>`, where an empty
+	    value is a deletion, alongside the latest value of the path the tree itself reads.
+
+	The versioned entries are written when the writes of a commit are applied so they are replayed with it.
+
+	The pruner deletes the roots below the earliest retained height, along with the versions of the values superseded
+	at or below it, so the trees can still be reopened at any retained height. Reopening them below the earliest
+	retained height returns `ErrHeightPruned`.
+
+	TECHDEBT: The nodes of the trees are not garbage collected, so the nodes only reachable from pruned roots are kept.
+*/
+
+var (
+	treeRootKeyPrefix      = []byte("root/")
+	treeValueVersionPrefix = []byte("value/")
+)
+
+// `stateTreesAtHeight` is a read-only view of the state trees as they were committed at `height`
+type stateTreesAtHeight struct {
+	height      int64
+	merkleTrees map[merkleTree]*smt.SparseMerkleTree
+	nodeStores  map[merkleTree]smt.MapStore
+	valueStores map[merkleTree]smt.MapStore
+	roots       [][]byte
+}
+
+// `AtHeight` reopens the state trees read-only as they were committed at `height`
+func (t *stateTrees) AtHeight(height int64) (*stateTreesAtHeight, error) {
+	if err := t.pruner.checkHeight(height); err != nil {
+		return nil, err
+	}
+	treesAtHeight := &stateTreesAtHeight{
+		height:      height,
+		merkleTrees: make(map[merkleTree]*smt.SparseMerkleTree, int(numMerkleTrees)),
+		nodeStores:  make(map[merkleTree]smt.MapStore, int(numMerkleTrees)),
+		valueStores: make(map[merkleTree]smt.MapStore, int(numMerkleTrees)),
+		roots:       make([][]byte, int(numMerkleTrees)),
+	}
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		root, err := t.nodeStores[tree].Get(treeRootKey(height))
+		if err != nil {
+			return nil, fmt.Errorf("no root of the %s tree at height %d: %v", merkleTreeToString[tree], height, err)
+		}
+		nodes := &readOnlyMapStore{t.nodeStores[tree]}
+		values := &treeValuesAtHeight{store: t.valueStores[tree], height: height}
+		treesAtHeight.merkleTrees[tree] = smt.ImportSparseMerkleTree(nodes, values, sha256.New(), root)
+		treesAtHeight.nodeStores[tree], treesAtHeight.valueStores[tree] = nodes, values
+		treesAtHeight.roots[int(tree)] = root
+	}
+	return treesAtHeight, nil
+}
+
+// `get` returns the value of `key` in `tree`, which is empty if the key is not in the tree
+func (t *stateTreesAtHeight) get(tree merkleTree, key []byte) ([]byte, error) {
+	return t.merkleTrees[tree].Get(key)
+}
+
+// `retainedNodeStore` retains the nodes a tree deletes when it is updated, so the past roots of the tree remain readable
+type retainedNodeStore struct {
+	*kvstore.StagedStore
+}
+
+func (s *retainedNodeStore) Delete(key []byte) error {
+	return nil
+}
+
+var errReadOnlyStateTrees = fmt.Errorf("the state trees reopened at a height are read-only")
+
+// `readOnlyMapStore` rejects the updates of a tree reopened at a height
+type readOnlyMapStore struct {
+	store smt.MapStore
+}
+
+func (s *readOnlyMapStore) Get(key []byte) ([]byte, error) {
+	return s.store.Get(key)
+}
+
+func (s *readOnlyMapStore) Set(key, value []byte) error {
+	return errReadOnlyStateTrees
+}
+
+func (s *readOnlyMapStore) Delete(key []byte) error {
+	return errReadOnlyStateTrees
+}
+
+// `treeValuesAtHeight` reads the values of a tree as they were at `height` from the versioned values of its value store
+type treeValuesAtHeight struct {
+	store  kvstore.KVStore
+	height int64
+}
+
+func (s *treeValuesAtHeight) Get(path []byte) ([]byte, error) {
+	// The first version when iterating backwards from `height` is the latest one at `height`
+	it, err := s.store.Iterator(treeValueVersionKeyPrefix(path), treeValueVersionKey(path, s.height), true)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	if it.Next() && len(it.Value()) > 0 {
+		return it.Value(), nil
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	// The tree returns its default (i.e. empty) value for missing keys
+	return nil, &smt.InvalidKeyError{Key: path}
+}
+
+func (s *treeValuesAtHeight) Set(key, value []byte) error {
+	return errReadOnlyStateTrees
+}
+
+func (s *treeValuesAtHeight) Delete(key []byte) error {
+	return errReadOnlyStateTrees
+}
+
+// `writeTreeHistory` stores the root of a tree at `height` and the versions of the values written to it
+func writeTreeHistory(nodeStore, valueStore kvstore.KVStore, height int64, root []byte, valueKeys, values [][]byte) error {
+	for i, path := range valueKeys {
+		if err := valueStore.Set(treeValueVersionKey(path, height), values[i]); err != nil {
+			return err
+		}
+	}
+	return nodeStore.Set(treeRootKey(height), root)
+}
+
+// `pruneHistory` deletes the roots of the trees from `fromHeight` to below `pruneHeight`, along with the versions of
+// their values superseded at or below `pruneHeight`
+func (t *stateTrees) pruneHistory(fromHeight, pruneHeight int64) error {
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		for height := fromHeight; height < pruneHeight; height++ {
+			if err := t.nodeStores[tree].Delete(treeRootKey(height)); err != nil {
+				return err
+			}
+		}
+		if err := pruneTreeValueVersions(t.valueStores[tree], pruneHeight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// `pruneTreeValueVersions` deletes the versions of the values superseded at or below `pruneHeight`. The versions of a
+// path are iterated in the order of their heights, so a version is superseded by the next one of the same path.
+func pruneTreeValueVersions(valueStore kvstore.KVStore, pruneHeight int64) error {
+	it, err := valueStore.Iterator(treeValueVersionPrefix, nil, false)
+	if err != nil {
+		return err
+	}
+	var prevKey, prevPath []byte
+	supersededKeys := make([][]byte, 0)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(treeValueVersionPrefix)+sha256.Size+8 {
+			continue
+		}
+		path := key[len(treeValueVersionPrefix) : len(key)-8]
+		height := int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+		if bytes.Equal(path, prevPath) && height <= pruneHeight {
+			supersededKeys = append(supersededKeys, prevKey)
+		}
+		prevKey, prevPath = key, path
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range supersededKeys {
+		if err := valueStore.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Heights are big endian encoded in the keys below so their lexicographic order is the order of the heights
+
+func treeRootKey(height int64) []byte {
+	return append(append([]byte{}, treeRootKeyPrefix...), uint64ToBytes(uint64(height))...)
+}
+
+func treeValueVersionKeyPrefix(path []byte) []byte {
+	return bytes.Join([][]byte{treeValueVersionPrefix, path}, nil)
+}
+
+func treeValueVersionKey(path []byte, height int64) []byte {
+	return append(treeValueVersionKeyPrefix(path), uint64ToBytes(uint64(height))...)
+}
+
+func uint64ToBytes(n uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, n)
+	return bz
+}
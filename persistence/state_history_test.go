@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateTrees_PruneHistory(t *testing.T) {
+	trees, err := newMemStateTrees()
+	require.NoError(t, err)
+
+	updatedPath := sha256.Sum256([]byte("updated"))
+	deletedPath := sha256.Sum256([]byte("deleted"))
+	unchangedPath := sha256.Sum256([]byte("unchanged"))
+	writes := map[int64]map[[sha256.Size]byte][]byte{
+		0: {updatedPath: []byte("0"), deletedPath: []byte("0"), unchangedPath: []byte("0")},
+		1: {updatedPath: []byte("1")},
+		2: {deletedPath: []byte{}},
+		3: {updatedPath: []byte("3")},
+		5: {updatedPath: []byte("5")},
+	}
+	lastHeight := int64(5)
+	for height := int64(0); height <= lastHeight; height++ {
+		for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+			valueKeys, values := make([][]byte, 0), make([][]byte, 0)
+			for path, value := range writes[height] {
+				valueKeys, values = append(valueKeys, append([]byte{}, path[:]...)), append(values, value)
+			}
+			root := []byte{byte(height)}
+			require.NoError(t, writeTreeHistory(trees.nodeStores[tree], trees.valueStores[tree], height, root, valueKeys, values))
+		}
+	}
+
+	pruneHeight := int64(4)
+	trees.pruner = &pruner{earliestHeight: pruneHeight}
+	require.NoError(t, trees.pruneHistory(0, pruneHeight))
+
+	// The roots below the earliest retained height are deleted
+	for tree := merkleTree(0); tree < numMerkleTrees; tree++ {
+		for height := int64(0); height <= lastHeight; height++ {
+			_, err := trees.nodeStores[tree].Get(treeRootKey(height))
+			if height < pruneHeight {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	// Only the latest version of each value at or below the earliest retained height is kept
+	valueStore := trees.valueStores[appMerkleTree]
+	for _, key := range [][]byte{
+		treeValueVersionKey(updatedPath[:], 0),
+		treeValueVersionKey(updatedPath[:], 1),
+		treeValueVersionKey(deletedPath[:], 0),
+	} {
+		exists, err := valueStore.Exists(key)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+	for _, key := range [][]byte{
+		treeValueVersionKey(updatedPath[:], 3),
+		treeValueVersionKey(updatedPath[:], 5),
+		treeValueVersionKey(deletedPath[:], 2),
+		treeValueVersionKey(unchangedPath[:], 0),
+	} {
+		exists, err := valueStore.Exists(key)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// The trees are still readable at the retained heights
+	for height, expectedValue := range map[int64]string{4: "3", 5: "5"} {
+		values := &treeValuesAtHeight{store: valueStore, height: height}
+		value, err := values.Get(updatedPath[:])
+		require.NoError(t, err)
+		require.Equal(t, []byte(expectedValue), value)
+		value, err = values.Get(unchangedPath[:])
+		require.NoError(t, err)
+		require.Equal(t, []byte("0"), value)
+		_, err = values.Get(deletedPath[:])
+		require.Error(t, err)
+	}
+	treesAtHeight, err := trees.AtHeight(pruneHeight)
+	require.NoError(t, err)
+	require.Equal(t, []byte{byte(pruneHeight)}, treesAtHeight.roots[appMerkleTree])
+
+	_, err = trees.AtHeight(pruneHeight - 1)
+	require.ErrorIs(t, err, ErrHeightPruned)
+}
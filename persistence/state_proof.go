@@ -0,0 +1,44 @@
+package persistence
+
+import (
+	"fmt"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+)
+
+// `GetStateProof` returns a proof of inclusion or exclusion of `key` in the state tree named `treeName` (e.g. `app` or
+// `account`) at `height`, which can be verified against the header of the block at `height` with `VerifyStateProof`.
+func (m *persistenceModule) GetStateProof(treeName string, key []byte, height int64) (*coreTypes.StateProof, error) {
+	tree, ok := stringToMerkleTree[treeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown state tree: %s", treeName)
+	}
+
+	treesAtHeight, err := m.stateTrees.AtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return treesAtHeight.prove(tree, key)
+}
+
+// `prove` generates a proof of `key` against the root of `tree` at the height of the trees
+func (t *stateTreesAtHeight) prove(tree merkleTree, key []byte) (*coreTypes.StateProof, error) {
+	smtProof, err := t.merkleTrees[tree].Prove(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.get(tree, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreTypes.StateProof{
+		Height:                t.height,
+		TreeIndex:             uint32(tree),
+		Key:                   key,
+		Value:                 value,
+		SideNodes:             smtProof.SideNodes,
+		NonMembershipLeafData: smtProof.NonMembershipLeafData,
+		TreeRoots:             t.roots,
+	}, nil
+}
@@ -209,6 +209,46 @@ func TestSubAccountAmount(t *testing.T) {
 	require.Equal(t, expectedAccountAmount, accountAmount, "unexpected amount after sub")
 }
 
+func TestAccountSequence(t *testing.T) {
+	db := NewTestPostgresContext(t, 0)
+	account := newTestAccount(t)
+	addrBz, err := hex.DecodeString(account.Address)
+	require.NoError(t, err)
+
+	sequence, err := db.GetAccountSequence(addrBz, db.Height)
+	require.NoError(t, err)
+	require.Zero(t, sequence, "unexpected sequence of a non existent account")
+
+	// The sequence and the balance of an account carry over each other's updates
+	require.NoError(t, db.SetAccountAmount(addrBz, DefaultStake))
+	require.NoError(t, db.SetAccountSequence(addrBz, 1))
+	accountAmount, err := db.GetAccountAmount(addrBz, db.Height)
+	require.NoError(t, err)
+	require.Equal(t, DefaultStake, accountAmount, "unexpected amount after setting the sequence")
+
+	db.Height++
+	amountToAddBig := big.NewInt(100)
+	require.NoError(t, db.AddAccountAmount(addrBz, converters.BigIntToString(amountToAddBig)))
+	sequence, err = db.GetAccountSequence(addrBz, db.Height)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence, "unexpected sequence after adding to the amount")
+
+	require.NoError(t, db.SetAccountSequence(addrBz, 2))
+	accountAmount, err = db.GetAccountAmount(addrBz, db.Height)
+	require.NoError(t, err)
+	require.Equal(t, converters.BigIntToString((&big.Int{}).Add(DefaultStakeBig, amountToAddBig)), accountAmount, "unexpected amount after setting the sequence")
+
+	sequence, err = db.GetAccountSequence(addrBz, db.Height-1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence, "unexpected sequence at the previous height")
+
+	accs, err := db.GetAccountsUpdated(db.Height)
+	require.NoError(t, err)
+	require.Len(t, accs, 1)
+	require.Equal(t, account.Address, accs[0].Address)
+	require.Equal(t, uint64(2), accs[0].Sequence)
+}
+
 func FuzzPoolAmount(f *testing.F) {
 	db := NewTestPostgresContext(f, 0)
 	operations := []string{
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertGetAndProveClaim(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	servicerAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	appPublicKeyHex := hex.EncodeToString(appPublicKey.Bytes())
+	merkleRoot := []byte("merkle_root")
+
+	exists, err := db.GetClaimExists(servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, db.InsertClaim(servicerAddress, appPublicKeyHex, 0, "0001", "geozone", merkleRoot))
+
+	exists, err = db.GetClaimExists(servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = db.GetClaimExists(servicerAddress, appPublicKeyHex, 0, "0002", 1)
+	require.NoError(t, err)
+	require.False(t, exists, "claims are keyed by relay chain")
+
+	geoZone, gotMerkleRoot, claimHeight, provenHeight, err := db.GetClaim(servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.Equal(t, "geozone", geoZone)
+	require.Equal(t, merkleRoot, gotMerkleRoot)
+	require.Equal(t, int64(1), claimHeight)
+	require.Equal(t, int64(-1), provenHeight)
+
+	require.NoError(t, db.SetClaimProvenHeight(servicerAddress, appPublicKeyHex, 0, "0001", 2))
+
+	_, _, _, provenHeight, err = db.GetClaim(servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), provenHeight)
+
+	// the proven claim is kept as the record that its session was settled
+	exists, err = db.GetClaimExists(servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestInsertClaimTwice(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	servicerAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	appPublicKeyHex := hex.EncodeToString(appPublicKey.Bytes())
+
+	require.NoError(t, db.InsertClaim(servicerAddress, appPublicKeyHex, 0, "0001", "geozone", []byte("merkle_root")))
+	require.Error(t, db.InsertClaim(servicerAddress, appPublicKeyHex, 0, "0001", "geozone", []byte("merkle_root")), "a claim cannot be submitted twice for the same session")
+}
+
+func TestInsertClaimEscapesStrings(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	servicerAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	appPublicKeyHex := hex.EncodeToString(appPublicKey.Bytes())
+	geoZone := "'); DELETE FROM claim; --"
+
+	require.NoError(t, db.InsertClaim(servicerAddress, appPublicKeyHex, 0, "0'01", geoZone, []byte("merkle_root")))
+
+	gotGeoZone, _, _, _, err := db.GetClaim(servicerAddress, appPublicKeyHex, 0, "0'01", 1)
+	require.NoError(t, err)
+	require.Equal(t, geoZone, gotGeoZone)
+}
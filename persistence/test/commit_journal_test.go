@@ -0,0 +1,116 @@
+package test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+var errCommitInterrupted = errors.New("commit interrupted")
+
+func TestPersistenceModule_RecoverInterruptedCommit(t *testing.T) {
+	testCases := []struct {
+		name        string
+		step        persistence.CommitStep
+		isCommitted bool // whether the commit is replayed rather than rolled back
+	}{
+		{name: "before writing the journal", step: persistence.CommitStepWriteJournal, isCommitted: false},
+		{name: "before committing the SQL transaction", step: persistence.CommitStepSQL, isCommitted: false},
+		{name: "before storing the block", step: persistence.CommitStepBlockStore, isCommitted: true},
+		{name: "before indexing the transactions", step: persistence.CommitStepTxIndexer, isCommitted: true},
+		{name: "before persisting the state trees", step: persistence.CommitStepStateTrees, isCommitted: true},
+		{name: "before clearing the journal", step: persistence.CommitStepClearJournal, isCommitted: true},
+	}
+
+	// The commits of the module are interrupted at `interruptedStep`, if set
+	var interruptedStep *persistence.CommitStep
+	faultMod := newTestPersistenceModuleWithCommitFaultInjector(
+		newIsolatedTestPersistenceConfig("commit_journal_test"),
+		func(step persistence.CommitStep) error {
+			if interruptedStep != nil && step == *interruptedStep {
+				return errCommitInterrupted
+			}
+			return nil
+		},
+	)
+	defer faultMod.Stop()
+	resetToGenesis := func() {
+		require.NoError(t, faultMod.ReleaseWriteContext())
+		require.NoError(t, faultMod.HandleDebugMessage(&messaging.DebugMessage{
+			Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+		}))
+	}
+	newRWContext := func(height int64) *persistence.PostgresContext {
+		ctx, err := faultMod.NewRWContext(height)
+		require.NoError(t, err)
+		return ctx.(*persistence.PostgresContext)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resetToGenesis()
+			defer resetToGenesis()
+
+			height := int64(1)
+			db := newRWContext(height)
+
+			apps, err := db.GetAllApps(height)
+			require.NoError(t, err)
+			addr, err := hex.DecodeString(apps[0].GetAddress())
+			require.NoError(t, err)
+			require.NoError(t, db.SetAppStakeAmount(addr, strconv.Itoa(int(tc.step)+1)))
+
+			txResult := newTestTxResult(height, 0, "a tx interrupted "+tc.name)
+			require.NoError(t, db.IndexTransaction(txResult))
+			stateHash, err := db.ComputeStateHash()
+			require.NoError(t, err)
+
+			step := tc.step
+			interruptedStep = &step
+			err = db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert"))
+			interruptedStep = nil
+			require.ErrorIs(t, err, errCommitInterrupted)
+
+			// Simulate a restart of the node: the context is lost and the persistence module is started again
+			require.NoError(t, faultMod.ReleaseWriteContext())
+			require.NoError(t, faultMod.Start())
+
+			readCtx, err := faultMod.NewReadContext(height)
+			require.NoError(t, err)
+			defer readCtx.Close()
+
+			blockHash, err := readCtx.GetBlockHash(height)
+			require.Equal(t, tc.isCommitted, err == nil, "the block should only be in the SQL DB if the commit is replayed")
+
+			_, err = faultMod.GetBlockStore().Get(heightToBytes(height))
+			require.Equal(t, tc.isCommitted, err == nil, "the block should only be in the block store if the commit is replayed")
+
+			exists, err := faultMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+			require.NoError(t, err)
+			require.Equal(t, tc.isCommitted, exists, "the transaction should only be indexed if the commit is replayed")
+
+			// The state trees are at the state of the last block committed: computing the state hash of the next
+			// height without any change returns its state hash
+			expectedStateHash := stateHash
+			if tc.isCommitted {
+				require.Equal(t, stateHash, blockHash)
+			} else {
+				expectedStateHash, err = readCtx.GetBlockHash(height - 1)
+				require.NoError(t, err)
+			}
+			require.NoError(t, faultMod.ReleaseWriteContext())
+			nextDb := newRWContext(height + 1)
+			nextStateHash, err := nextDb.ComputeStateHash()
+			require.NoError(t, err)
+			require.Equal(t, expectedStateHash, nextStateHash)
+
+			// The journal is cleared once the commit is recovered so restarting again is a no-op
+			require.NoError(t, faultMod.Start())
+		})
+	}
+}
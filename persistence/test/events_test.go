@@ -0,0 +1,73 @@
+package test
+
+import (
+	"testing"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_GetEvents(t *testing.T) {
+	resetStateToGenesis()
+
+	height := int64(1)
+	db := NewTestPostgresContext(t, height)
+	sender, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	recipient, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	transfer := func(amount string) *coreTypes.StateChangeEvent {
+		return &coreTypes.StateChangeEvent{
+			Type:         coreTypes.EventType_EVENT_TYPE_TRANSFER,
+			Address:      sender.String(),
+			Counterparty: recipient.String(),
+			Amount:       amount,
+		}
+	}
+
+	// the events of a transaction rolled back to its save point are discarded
+	firstTx, failedTx := newTestTxResult(height, 0, "first"), newTestTxResult(height, 1, "failed")
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, firstTx)))
+	require.NoError(t, db.EmitEvent(transfer("1")))
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, failedTx)))
+	require.NoError(t, db.EmitEvent(transfer("2")))
+	require.NoError(t, db.RollbackToSavePoint(getTestTxHash(t, failedTx)))
+	require.NoError(t, db.EmitEvent(&coreTypes.StateChangeEvent{
+		Type:   coreTypes.EventType_EVENT_TYPE_DAO_REWARD,
+		Pool:   coreTypes.Pools_POOLS_DAO.FriendlyName(),
+		Amount: "3",
+	}))
+
+	// the events are only stored once the block is committed
+	_, err = testPersistenceMod.GetEventsByHeight(height)
+	require.Error(t, err)
+
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+	require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+
+	events, err := testPersistenceMod.GetEventsByHeight(height)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "1", events[0].Amount)
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_DAO_REWARD, events[1].Type)
+	for i, event := range events {
+		require.Equal(t, height, event.Height)
+		require.Equal(t, int32(i), event.Index)
+	}
+
+	// the events are found by both of the addresses they are about
+	for _, address := range []crypto.Address{sender, recipient} {
+		addressEvents, err := testPersistenceMod.GetEventsByAddress(address)
+		require.NoError(t, err)
+		require.Len(t, addressEvents, 1)
+		require.Equal(t, "1", addressEvents[0].Amount)
+	}
+
+	// the genesis block has no events
+	genesisEvents, err := testPersistenceMod.GetEventsByHeight(0)
+	require.NoError(t, err)
+	require.Empty(t, genesisEvents)
+}
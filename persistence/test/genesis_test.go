@@ -0,0 +1,149 @@
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime/genesis"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_ExportGenesis(t *testing.T) {
+	exportingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("export_genesis_test"))
+	defer exportingMod.Stop()
+	require.NoError(t, exportingMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	// Update accounts, pools and actors (with their chains, pause and unstaking heights) past genesis
+	height := int64(1)
+	ctx, err := exportingMod.NewRWContext(height)
+	require.NoError(t, err)
+	db := ctx.(*persistence.PostgresContext)
+
+	accounts, err := db.GetAllAccounts(height)
+	require.NoError(t, err)
+	accountAddr, err := hex.DecodeString(accounts[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.AddAccountAmount(accountAddr, "100"))
+	pools, err := db.GetAllPools(height)
+	require.NoError(t, err)
+	require.NoError(t, db.AddPoolAmount(pools[0].GetAddress(), "100"))
+
+	apps, err := db.GetAllApps(height)
+	require.NoError(t, err)
+	appAddr, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.UpdateApp(appAddr, apps[0].GetGenericParam(), StakeToUpdate, ChainsToUpdate))
+	require.NoError(t, db.SetAppPauseHeight(appAddr, height))
+	fishermen, err := db.GetAllFishermen(height)
+	require.NoError(t, err)
+	fishermanAddr, err := hex.DecodeString(fishermen[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.SetFishermanUnstakingHeightAndStatus(fishermanAddr, height+10, persistence.UnstakingStatus))
+
+	stateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+	require.NoError(t, exportingMod.ReleaseWriteContext())
+
+	_, err = exportingMod.ExportGenesis(height + 1)
+	require.Error(t, err)
+
+	genesisState, err := exportingMod.ExportGenesis(height)
+	require.NoError(t, err)
+	require.Len(t, genesisState.GetApplications(), genesisStateNumApplications)
+	require.Equal(t, ChainsToUpdate, genesisState.GetApplications()[0].GetChains())
+	require.Equal(t, height, genesisState.GetApplications()[0].GetPausedHeight())
+	require.Equal(t, height+10, genesisState.GetFishermen()[0].GetUnstakingHeight())
+
+	// The genesis state is re-imported from its JSON file
+	genesisJson, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	importedGenesisState := new(genesis.GenesisState)
+	require.NoError(t, json.Unmarshal(genesisJson, importedGenesisState))
+
+	importingMod := newTestPersistenceModuleWithGenesis(newIsolatedTestPersistenceConfig("import_genesis_test"), importedGenesisState)
+	defer importingMod.Stop()
+	require.NoError(t, importingMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	readCtx, err := importingMod.NewReadContext(0)
+	require.NoError(t, err)
+	defer readCtx.Close()
+	importedStateHash, err := readCtx.GetBlockHash(0)
+	require.NoError(t, err)
+	require.Equal(t, stateHash, importedStateHash)
+
+	reexportedGenesisState, err := importingMod.ExportGenesis(0)
+	require.NoError(t, err)
+	reexportedGenesisJson, err := json.Marshal(reexportedGenesisState)
+	require.NoError(t, err)
+	require.JSONEq(t, string(genesisJson), string(reexportedGenesisJson))
+}
+
+func TestPersistenceModule_ExportGenesisAfterTransaction(t *testing.T) {
+	exportingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("export_genesis_tx_test"))
+	defer exportingMod.Stop()
+	require.NoError(t, exportingMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	// Apply a transaction and change a param and a flag, which the transactions, params and flags trees commit to
+	height := int64(1)
+	ctx, err := exportingMod.NewRWContext(height)
+	require.NoError(t, err)
+	db := ctx.(*persistence.PostgresContext)
+
+	accounts, err := db.GetAllAccounts(height)
+	require.NoError(t, err)
+	accountAddr, err := hex.DecodeString(accounts[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.AddAccountAmount(accountAddr, "100"))
+	require.NoError(t, db.IndexTransaction(newTestTxResult(height, 0, "tx at height 1")))
+	require.NoError(t, db.SetParam(AppMaxChainsParamName, 20))
+	require.NoError(t, db.SetFlag(AppMaxChainsParamName, 21, false))
+
+	stateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+	require.NoError(t, exportingMod.ReleaseWriteContext())
+
+	genesisState, err := exportingMod.ExportGenesis(height)
+	require.NoError(t, err)
+	require.Len(t, genesisState.GetStateTrees(), 3)
+
+	genesisJson, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	importedGenesisState := new(genesis.GenesisState)
+	require.NoError(t, json.Unmarshal(genesisJson, importedGenesisState))
+
+	importingMod := newTestPersistenceModuleWithGenesis(newIsolatedTestPersistenceConfig("import_genesis_tx_test"), importedGenesisState)
+	defer importingMod.Stop()
+	require.NoError(t, importingMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	// The genesis of the new node has the state hash of the exported height, and the latest params and flags
+	readCtx, err := importingMod.NewReadContext(0)
+	require.NoError(t, err)
+	defer readCtx.Close()
+	importedStateHash, err := readCtx.GetBlockHash(0)
+	require.NoError(t, err)
+	require.Equal(t, stateHash, importedStateHash)
+	maxChains, err := readCtx.GetIntParam(AppMaxChainsParamName, 0)
+	require.NoError(t, err)
+	require.Equal(t, 20, maxChains)
+
+	// The params and flags of the new node are at the genesis height while the leaves of their trees are at the height
+	// they were set at, which a snapshot of the new node is still verified against
+	snapshotDir := t.TempDir()
+	require.NoError(t, importingMod.ExportSnapshot(0, snapshotDir))
+	snapshotMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("import_genesis_tx_snapshot_test"))
+	defer snapshotMod.Stop()
+	require.NoError(t, snapshotMod.ImportSnapshot(snapshotDir, stateHash))
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+// The read contexts draw their own connections from the pool, so they run alongside the write context of the block
+// being committed and only ever see the state of the committed heights
+func TestPersistenceModule_ConcurrentReadContextsDuringCommits(t *testing.T) {
+	const (
+		numBlocks  = 20
+		numReaders = 8 // More than the connections of the pool, so the readers also wait for the released ones
+	)
+
+	cfg := newIsolatedTestPersistenceConfig("load_test")
+	loadMod := newTestPersistenceModule(cfg)
+	defer loadMod.Stop()
+	require.NoError(t, loadMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	readCtx, err := loadMod.NewReadContext(0)
+	require.NoError(t, err)
+	apps, err := readCtx.GetAllApps(0)
+	require.NoError(t, err)
+	require.NoError(t, readCtx.Close())
+	appAddr, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+
+	var committedHeight int64
+	var numReads int64
+	done := make(chan struct{})
+	errs := make(chan error, numReaders+1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numReaders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if err := readCommittedHeight(loadMod, atomic.LoadInt64(&committedHeight), appAddr); err != nil {
+					errs <- err
+					return
+				}
+				atomic.AddInt64(&numReads, 1)
+			}
+		}()
+	}
+
+	// The blocks are committed while the readers are running
+	for height := int64(1); height <= numBlocks; height++ {
+		ctx, err := loadMod.NewRWContext(height)
+		require.NoError(t, err)
+		db := ctx.(*persistence.PostgresContext)
+		require.NoError(t, db.SetAppStakeAmount(appAddr, strconv.Itoa(int(height))))
+		_, err = db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, loadMod.ReleaseWriteContext())
+		atomic.StoreInt64(&committedHeight, height)
+	}
+	close(done)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.GreaterOrEqual(t, atomic.LoadInt64(&numReads), int64(numReaders))
+}
+
+// `readCommittedHeight` reads the state at `height` from a new read context, which must not see the writes of the
+// block being committed on top of it
+func readCommittedHeight(persistenceMod modules.PersistenceModule, height int64, appAddr []byte) error {
+	readCtx, err := persistenceMod.NewReadContext(height)
+	if err != nil {
+		return err
+	}
+	defer readCtx.Close()
+
+	if height == 0 {
+		_, err := readCtx.GetAllApps(height)
+		return err
+	}
+	stakeAmount, err := readCtx.GetAppStakeAmount(height, appAddr)
+	if err != nil {
+		return err
+	}
+	if stakeAmount != strconv.Itoa(int(height)) {
+		return fmt.Errorf("read the stake amount %s at height %d", stakeAmount, height)
+	}
+	_, err = readCtx.GetBlockHash(height)
+	return err
+}
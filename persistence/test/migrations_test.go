@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/runtime"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_ApplyToNewDatabase(t *testing.T) {
+	cfg := newIsolatedTestPersistenceConfig("migrations_test")
+
+	status, err := persistence.GetMigrationStatus(cfg)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), status.CurrentVersion)
+	require.NotZero(t, status.LatestVersion)
+	require.Equal(t, status.LatestVersion, status.Pending[len(status.Pending)-1].Version)
+
+	applied, err := persistence.ApplyMigrations(cfg)
+	require.NoError(t, err)
+	require.Equal(t, status.Pending, applied)
+
+	status, err = persistence.GetMigrationStatus(cfg)
+	require.NoError(t, err)
+	require.Equal(t, status.LatestVersion, status.CurrentVersion)
+	require.Empty(t, status.Pending)
+
+	// Applying the migrations of an up to date database is a no-op
+	applied, err = persistence.ApplyMigrations(cfg)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	migratedMod := newTestPersistenceModule(cfg)
+	require.NoError(t, migratedMod.Stop())
+}
+
+func TestMigrations_RefuseNewerSchema(t *testing.T) {
+	// The database of the other tests is migrated when the module is created
+	status, err := persistence.GetMigrationStatus(newTestPersistenceConfig(testDatabaseCfg))
+	require.NoError(t, err)
+	require.Equal(t, status.LatestVersion, status.CurrentVersion)
+
+	cfg := newIsolatedTestPersistenceConfig("newer_schema_test")
+	_, err = persistence.ApplyMigrations(cfg)
+	require.NoError(t, err)
+	execTestSQL(t, cfg, fmt.Sprintf(`INSERT INTO schema_version(version, description) VALUES(%d, 'From the future')`, status.LatestVersion+1))
+
+	_, err = persistence.ApplyMigrations(cfg)
+	require.ErrorIs(t, err, persistence.ErrIncompatibleSchema)
+
+	runtimeMgr := runtime.NewManager(&configs.Config{Persistence: cfg}, nil)
+	bus, err := runtime.CreateBus(runtimeMgr)
+	require.NoError(t, err)
+	_, err = persistence.Create(bus)
+	require.ErrorIs(t, err, persistence.ErrIncompatibleSchema)
+}
+
+// `execTestSQL` runs `query` directly against the database of `cfg`, bypassing the persistence module
+func execTestSQL(t *testing.T, cfg *configs.PersistenceConfig, query string) {
+	ctx := context.Background()
+	if cfg.SqlDriver == persistence.SQLiteSQLDriver {
+		db, err := sql.Open(persistence.SQLiteSQLDriver, cfg.SqlitePath)
+		require.NoError(t, err)
+		defer db.Close()
+		_, err = db.ExecContext(ctx, query)
+		require.NoError(t, err)
+		return
+	}
+	conn, err := pgx.Connect(ctx, cfg.PostgresUrl)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, fmt.Sprintf("SET search_path TO %s", cfg.NodeSchema))
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, query)
+	require.NoError(t, err)
+}
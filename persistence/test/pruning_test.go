@@ -0,0 +1,146 @@
+package test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/messaging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_PruneHistoricalState(t *testing.T) {
+	// The pruning node runs against its own database so it does not prune the state of the other tests
+	cfg := newIsolatedTestPersistenceConfig("pruning_test")
+	cfg.PruningMode = persistence.PruningModeCustom
+	cfg.PruningKeepRecent = 2
+	cfg.PruningInterval = 1
+
+	pruningMod := newTestPersistenceModule(cfg)
+	require.NoError(t, pruningMod.HandleDebugMessage(&messaging.DebugMessage{
+		Action: messaging.DebugMessageAction_DEBUG_PERSISTENCE_RESET_TO_GENESIS,
+	}))
+
+	// Update the stake of an app at every height so each height has its own version of the app
+	var appAddr []byte
+	txResults := make([]*indexer.TxRes, 0)
+	lastHeight := int64(5)
+	for height := int64(1); height <= lastHeight; height++ {
+		ctx, err := pruningMod.NewRWContext(height)
+		require.NoError(t, err)
+		db := ctx.(*persistence.PostgresContext)
+
+		apps, err := db.GetAllApps(height)
+		require.NoError(t, err)
+		appAddr, err = hex.DecodeString(apps[0].GetAddress())
+		require.NoError(t, err)
+		require.NoError(t, db.SetAppStakeAmount(appAddr, strconv.Itoa(int(height))))
+		require.NoError(t, db.EmitEvent(&coreTypes.StateChangeEvent{
+			Type:    coreTypes.EventType_EVENT_TYPE_EDIT_STAKE,
+			Address: apps[0].GetAddress(),
+		}))
+		txResult := newTestTxResult(height, 0, fmt.Sprintf("tx at height %d", height))
+		require.NoError(t, db.IndexTransaction(txResult))
+		txResults = append(txResults, txResult)
+
+		_, err = db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, pruningMod.ReleaseWriteContext())
+	}
+
+	// Only the last 2 heights are retained
+	earliestHeight := lastHeight - cfg.PruningKeepRecent + 1
+	require.Eventually(t, func() bool {
+		_, err := pruningMod.GetBlockStore().Get(heightToBytes(earliestHeight - 1))
+		return err != nil
+	}, 10*time.Second, 10*time.Millisecond)
+	_, err := pruningMod.GetBlockStore().Get(heightToBytes(earliestHeight))
+	require.NoError(t, err)
+
+	// The transactions are pruned from the tx indexer along with their block
+	require.Eventually(t, func() bool {
+		exists, err := pruningMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResults[earliestHeight-2])))
+		return err == nil && !exists
+	}, 10*time.Second, 10*time.Millisecond)
+	requireTxsIndexed := func() {
+		for _, txResult := range txResults {
+			exists, err := pruningMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+			require.NoError(t, err)
+			require.Equal(t, txResult.GetHeight() >= earliestHeight, exists)
+		}
+	}
+	requireTxsIndexed()
+
+	// Rebuilding the tx indexer only reindexes the retained heights
+	reindexedHeights := make([]int64, 0)
+	require.NoError(t, pruningMod.RebuildTxIndexer(true, func(height, _ int64, _ int) {
+		reindexedHeights = append(reindexedHeights, height)
+	}))
+	require.Equal(t, earliestHeight, reindexedHeights[0])
+	requireTxsIndexed()
+
+	// The state trees cannot be reopened below the earliest retained height, and still prove the state at it
+	_, err = pruningMod.GetStateProof("app", appAddr, earliestHeight-1)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+	blockBz, err := pruningMod.GetBlockStore().Get(heightToBytes(earliestHeight))
+	require.NoError(t, err)
+	block := new(coreTypes.Block)
+	require.NoError(t, codec.GetCodec().Unmarshal(blockBz, block))
+	proof, err := pruningMod.GetStateProof("app", appAddr, earliestHeight)
+	require.NoError(t, err)
+	require.NoError(t, coreTypes.VerifyStateProof(proof, block.BlockHeader))
+	app := new(coreTypes.Actor)
+	require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, app))
+	require.Equal(t, strconv.Itoa(int(earliestHeight)), app.StakedAmount)
+
+	// The events are pruned along with their block
+	_, err = pruningMod.GetEventsByHeight(earliestHeight - 1)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+	events, err := pruningMod.GetEventsByAddress(appAddr)
+	require.NoError(t, err)
+	require.Len(t, events, int(cfg.PruningKeepRecent))
+	require.Equal(t, earliestHeight, events[0].Height)
+
+	// The earliest retained height is loaded from the database when the node restarts, once the pruning completes
+	require.NoError(t, pruningMod.Stop())
+	pruningMod = newTestPersistenceModule(cfg)
+	defer pruningMod.Stop()
+
+	_, err = pruningMod.NewReadContext(earliestHeight - 1)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+
+	readCtx, err := pruningMod.NewReadContext(lastHeight)
+	require.NoError(t, err)
+	defer readCtx.Close()
+
+	_, err = readCtx.GetAppStakeAmount(earliestHeight-1, appAddr)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+	_, err = readCtx.GetBlockHash(earliestHeight - 1)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+
+	for height := earliestHeight; height <= lastHeight; height++ {
+		stakeAmount, err := readCtx.GetAppStakeAmount(height, appAddr)
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(int(height)), stakeAmount)
+
+		_, err = readCtx.GetBlockHash(height)
+		require.NoError(t, err)
+	}
+
+	// The latest version of the state which has not changed since genesis is left intact
+	validators, err := readCtx.GetAllValidators(earliestHeight)
+	require.NoError(t, err)
+	require.Len(t, validators, genesisStateNumValidators)
+	accounts, err := readCtx.GetAllAccounts(earliestHeight)
+	require.NoError(t, err)
+	require.NotEmpty(t, accounts)
+	_, err = readCtx.GetIntParam(persistence.BlocksPerSessionParamName, earliestHeight)
+	require.NoError(t, err)
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_RebuildTxIndexer(t *testing.T) {
+	resetStateToGenesis()
+
+	// commit 3 blocks with 2 transactions each
+	txResults := make([]*indexer.TxRes, 0)
+	for height := int64(1); height <= 3; height++ {
+		db := NewTestPostgresContext(t, height)
+		for index := int32(0); index < 2; index++ {
+			txResult := newTestTxResult(height, index, fmt.Sprintf("tx %d at height %d", index, height))
+			require.NoError(t, db.IndexTransaction(txResult))
+			txResults = append(txResults, txResult)
+		}
+		_, err := db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+	}
+
+	// the blocks store their transactions
+	blockBz, err := testPersistenceMod.GetBlockStore().Get(heightToBytes(2))
+	require.NoError(t, err)
+	block := new(coreTypes.Block)
+	require.NoError(t, codec.GetCodec().Unmarshal(blockBz, block))
+	require.Len(t, block.Transactions, 2)
+
+	reindexedHeights := make([]int64, 0)
+	numTxs := 0
+	progress := func(height, toHeight int64, blockNumTxs int) {
+		require.Equal(t, int64(3), toHeight)
+		reindexedHeights = append(reindexedHeights, height)
+		numTxs += blockNumTxs
+	}
+	require.NoError(t, testPersistenceMod.RebuildTxIndexer(true, progress))
+	require.Equal(t, []int64{0, 1, 2, 3}, reindexedHeights)
+	require.Equal(t, len(txResults), numTxs)
+
+	for _, txResult := range txResults {
+		exists, err := testPersistenceMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	// the rebuild leaves no checkpoint behind, so the next one starts over from the first height
+	reindexedHeights = reindexedHeights[:0]
+	numTxs = 0
+	require.NoError(t, testPersistenceMod.RebuildTxIndexer(false, progress))
+	require.Equal(t, []int64{0, 1, 2, 3}, reindexedHeights)
+}
+
+func TestPersistenceModule_RebuildTxIndexerWithoutStoredTxResults(t *testing.T) {
+	resetStateToGenesis()
+
+	txResults := make([]*indexer.TxRes, 0)
+	for height := int64(1); height <= 3; height++ {
+		db := NewTestPostgresContext(t, height)
+		txResult := newTestTxResult(height, 0, fmt.Sprintf("tx at height %d", height))
+		require.NoError(t, db.IndexTransaction(txResult))
+		txResults = append(txResults, txResult)
+		_, err := db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+	}
+
+	// the block at height 2 was committed before the results of its transactions were stored alongside it
+	require.NoError(t, testPersistenceMod.GetBlockStore().Delete(append([]byte("tx_results/"), heightToBytes(2)...)))
+
+	err := testPersistenceMod.RebuildTxIndexer(true, nil)
+	require.ErrorContains(t, err, "unsupported before height 3")
+
+	// the index is left intact
+	for _, txResult := range txResults {
+		exists, err := testPersistenceMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence/indexer"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresContext_RollbackToSavePoint(t *testing.T) {
+	// The block is committed on top of the genesis block, which may have been cleared by the previous tests
+	resetStateToGenesis()
+	db := NewTestPostgresContext(t, 1)
+
+	addr, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	firstTx, failedTx, lastTx := newTestTxResult(1, 0, "first"), newTestTxResult(1, 1, "failed"), newTestTxResult(1, 1, "last")
+
+	// a successful transaction
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, firstTx)))
+	require.NoError(t, db.SetAccountAmount(addr, "1"))
+	require.NoError(t, db.IndexTransaction(firstTx))
+	stateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+
+	// a failed transaction is rolled back, along with the trees and the transactions indexed since its save point
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, failedTx)))
+	require.Error(t, db.NewSavePoint(getTestTxHash(t, failedTx)), "save points must be unique per transaction")
+	require.NoError(t, db.SetAccountAmount(addr, "2"))
+	require.NoError(t, db.IndexTransaction(failedTx))
+	failedStateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NotEqual(t, stateHash, failedStateHash)
+
+	require.NoError(t, db.RollbackToSavePoint(getTestTxHash(t, failedTx)))
+	require.Error(t, db.RollbackToSavePoint(getTestTxHash(t, failedTx)), "the save point is released by the rollback")
+
+	amount, err := db.GetAccountAmount(addr, 1)
+	require.NoError(t, err)
+	require.Equal(t, "1", amount, "the writes prior to the save point should be kept")
+
+	rolledBackStateHash, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.Equal(t, stateHash, rolledBackStateHash)
+
+	// the block keeps being built on top of the rolled back state
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, lastTx)))
+	require.NoError(t, db.SetAccountAmount(addr, "3"))
+	require.NoError(t, db.IndexTransaction(lastTx))
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+
+	for _, txResult := range []*indexer.TxRes{firstTx, failedTx, lastTx} {
+		exists, err := testPersistenceMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+		require.NoError(t, err)
+		require.Equal(t, txResult != failedTx, exists)
+	}
+}
+
+func TestPostgresContext_ReleaseDiscardsPendingWrites(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+	txResult := newTestTxResult(1, 0, "released")
+
+	require.NoError(t, db.NewSavePoint(getTestTxHash(t, txResult)))
+	require.NoError(t, db.IndexTransaction(txResult))
+	_, err := db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Release())
+
+	exists, err := testPersistenceMod.TransactionExists(hex.EncodeToString(getTestTxHash(t, txResult)))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func newTestTxResult(height int64, index int32, tx string) *indexer.TxRes {
+	return &indexer.TxRes{
+		Tx:            []byte(tx),
+		Height:        height,
+		Index:         index,
+		ResultCode:    0,
+		Error:         "",
+		SignerAddr:    "TODO",
+		RecipientAddr: "TODO",
+		MessageType:   "TODO",
+	}
+}
+
+func getTestTxHash(t *testing.T, txResult *indexer.TxRes) []byte {
+	txHash, err := txResult.Hash()
+	require.NoError(t, err)
+	return txHash
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/pokt-network/pocket/persistence/types"
 	"github.com/pokt-network/pocket/runtime"
 	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/runtime/genesis"
 	"github.com/pokt-network/pocket/runtime/test_artifacts"
 	"github.com/pokt-network/pocket/runtime/test_artifacts/keygenerator"
 	"github.com/pokt-network/pocket/shared/converters"
@@ -55,15 +57,19 @@ var (
 	genesisStateNumApplications = 1
 	genesisStateNumFishermen    = 1
 )
-var testPersistenceMod modules.PersistenceModule // initialized in TestMain
+var (
+	testDatabaseCfg    *configs.PersistenceConfig // initialized in TestMain
+	testPersistenceMod modules.PersistenceModule  // initialized in TestMain
+)
 
 // See https://github.com/ory/dockertest as reference for the template of this code
 // Postgres example can be found here: https://github.com/ory/dockertest/blob/v3/examples/PostgreSQL.md
 func TestMain(m *testing.M) {
-	pool, resource, dbUrl := test_artifacts.SetupPostgresDocker()
-	testPersistenceMod = newTestPersistenceModule(dbUrl)
+	databaseCfg, cleanupDatabase := test_artifacts.SetupTestDatabase()
+	testDatabaseCfg = databaseCfg
+	testPersistenceMod = newTestPersistenceModule(newTestPersistenceConfig(databaseCfg))
 	exitCode := m.Run()
-	test_artifacts.CleanupPostgresDocker(m, pool, resource)
+	cleanupDatabase()
 	os.Exit(exitCode)
 }
 
@@ -86,25 +92,59 @@ func NewTestPostgresContext(t testing.TB, height int64) *persistence.PostgresCon
 	return db
 }
 
+func newTestPersistenceConfig(databaseCfg *configs.PersistenceConfig) *configs.PersistenceConfig {
+	return &configs.PersistenceConfig{
+		SqlDriver:         databaseCfg.SqlDriver,
+		PostgresUrl:       databaseCfg.PostgresUrl,
+		SqlitePath:        databaseCfg.SqlitePath,
+		NodeSchema:        testSchema,
+		BlockStorePath:    "",
+		TxIndexerPath:     "",
+		TreesStoreDir:     "",
+		MaxConnsCount:     4,
+		MinConnsCount:     0,
+		MaxConnLifetime:   "1h",
+		MaxConnIdleTime:   "30m",
+		HealthCheckPeriod: "5m",
+	}
+}
+
+// Returns the config of a node with its own database, so its state is isolated from the one of the other tests
+func newIsolatedTestPersistenceConfig(name string) *configs.PersistenceConfig {
+	cfg := newTestPersistenceConfig(testDatabaseCfg)
+	cfg.NodeSchema = fmt.Sprintf("%s_schema", name)
+	if cfg.SqlitePath != "" {
+		cfg.SqlitePath = filepath.Join(filepath.Dir(cfg.SqlitePath), fmt.Sprintf("%s.db", name))
+	}
+	return cfg
+}
+
 // TODO(olshansky): Take in `t testing.T` as a parameter and error if there's an issue
-func newTestPersistenceModule(databaseUrl string) modules.PersistenceModule {
-	teardownDeterministicKeygen := keygenerator.GetInstance().SetSeed(42)
-	defer teardownDeterministicKeygen()
+func newTestPersistenceModule(persistenceCfg *configs.PersistenceConfig) modules.PersistenceModule {
+	return newTestPersistenceModuleWithGenesis(persistenceCfg, newTestGenesisState())
+}
 
-	cfg := &configs.Config{
-		Persistence: &configs.PersistenceConfig{
-			PostgresUrl:       databaseUrl,
-			NodeSchema:        testSchema,
-			BlockStorePath:    "",
-			TxIndexerPath:     "",
-			TreesStoreDir:     "",
-			MaxConnsCount:     4,
-			MinConnsCount:     0,
-			MaxConnLifetime:   "1h",
-			MaxConnIdleTime:   "30m",
-			HealthCheckPeriod: "5m",
-		},
+// Returns a persistence module populated with `genesisState` if its database is empty
+func newTestPersistenceModuleWithGenesis(persistenceCfg *configs.PersistenceConfig, genesisState *genesis.GenesisState) modules.PersistenceModule {
+	persistenceMod, err := persistence.Create(newTestBus(persistenceCfg, genesisState))
+	if err != nil {
+		log.Fatalf("Error creating persistence module: %s", err)
 	}
+	return persistenceMod.(modules.PersistenceModule)
+}
+
+// Returns a persistence module whose commits are interrupted at the steps `commitFaultInjector` returns an error for
+func newTestPersistenceModuleWithCommitFaultInjector(persistenceCfg *configs.PersistenceConfig, commitFaultInjector persistence.CommitFaultInjector) modules.PersistenceModule {
+	persistenceMod, err := persistence.CreateWithCommitFaultInjector(newTestBus(persistenceCfg, newTestGenesisState()), commitFaultInjector)
+	if err != nil {
+		log.Fatalf("Error creating persistence module: %s", err)
+	}
+	return persistenceMod.(modules.PersistenceModule)
+}
+
+func newTestGenesisState() *genesis.GenesisState {
+	teardownDeterministicKeygen := keygenerator.GetInstance().SetSeed(42)
+	defer teardownDeterministicKeygen()
 
 	genesisState, _ := test_artifacts.NewGenesisState(
 		genesisStateNumValidators,
@@ -112,17 +152,20 @@ func newTestPersistenceModule(databaseUrl string) modules.PersistenceModule {
 		genesisStateNumApplications,
 		genesisStateNumServiceNodes,
 	)
+	return genesisState
+}
+
+func newTestBus(persistenceCfg *configs.PersistenceConfig, genesisState *genesis.GenesisState) modules.Bus {
+	cfg := &configs.Config{
+		Persistence: persistenceCfg,
+	}
+
 	runtimeMgr := runtime.NewManager(cfg, genesisState)
 	bus, err := runtime.CreateBus(runtimeMgr)
 	if err != nil {
 		log.Fatalf("Error creating bus: %s", err)
 	}
-
-	persistenceMod, err := persistence.Create(bus)
-	if err != nil {
-		log.Fatalf("Error creating persistence module: %s", err)
-	}
-	return persistenceMod.(modules.PersistenceModule)
+	return bus
 }
 
 // IMPROVE(team): Extend this to more complex and variable test cases challenging & randomizing the state of persistence.
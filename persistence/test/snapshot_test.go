@@ -0,0 +1,221 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pokt-network/pocket/persistence"
+	"github.com/pokt-network/pocket/persistence/types"
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_ExportAndImportSnapshot(t *testing.T) {
+	resetStateToGenesis()
+
+	height := int64(1)
+	db := NewTestPostgresContext(t, height)
+	apps, err := db.GetAllApps(height)
+	require.NoError(t, err)
+	appAddr, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.SetAppStakeAmount(appAddr, "42"))
+	accounts, err := db.GetAllAccounts(height)
+	require.NoError(t, err)
+	accountAddr, err := hex.DecodeString(accounts[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.SetAccountSequence(accountAddr, 7))
+	require.NoError(t, db.IndexTransaction(newTestTxResult(height, 0, "a tx before the snapshot")))
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+	require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+
+	snapshotDir := t.TempDir()
+	require.NoError(t, testPersistenceMod.ExportSnapshot(height, snapshotDir))
+
+	importingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("snapshot_test"))
+	defer importingMod.Stop()
+	require.Error(t, importingMod.ImportSnapshot(snapshotDir, hex.EncodeToString(make([]byte, 32))), "the snapshot is not at the trusted state hash")
+	require.NoError(t, importingMod.ImportSnapshot(snapshotDir, getTestStateHash(t, height)))
+
+	exportedCtx, err := testPersistenceMod.NewReadContext(height)
+	require.NoError(t, err)
+	defer exportedCtx.Close()
+	importedCtx, err := importingMod.NewReadContext(height)
+	require.NoError(t, err)
+	defer importedCtx.Close()
+	requireSameState(t, exportedCtx, importedCtx, height)
+
+	// The state below the snapshot is not part of it
+	_, err = importedCtx.GetBlockHash(height - 1)
+	require.ErrorIs(t, err, persistence.ErrHeightPruned)
+
+	// Both nodes compute the same state hash for the next block
+	nextHeight := height + 1
+	nextStateHashes := make([]string, 0, 2)
+	for _, mod := range []modules.PersistenceModule{testPersistenceMod, importingMod} {
+		ctx, err := mod.NewRWContext(nextHeight)
+		require.NoError(t, err)
+		require.NoError(t, ctx.SetAppStakeAmount(appAddr, "43"))
+		stateHash, err := ctx.ComputeStateHash()
+		require.NoError(t, err)
+		nextStateHashes = append(nextStateHashes, stateHash)
+		require.NoError(t, mod.ReleaseWriteContext())
+	}
+	require.Equal(t, nextStateHashes[0], nextStateHashes[1])
+}
+
+func TestPersistenceModule_ExportSnapshotAtPastHeight(t *testing.T) {
+	resetStateToGenesis()
+
+	// Commit two heights updating the same app, and export the snapshot of the first one
+	var appAddr []byte
+	for i, stakeAmount := range []string{"42", "43"} {
+		height := int64(i + 1)
+		db := NewTestPostgresContext(t, height)
+		apps, err := db.GetAllApps(height)
+		require.NoError(t, err)
+		appAddr, err = hex.DecodeString(apps[0].GetAddress())
+		require.NoError(t, err)
+		require.NoError(t, db.SetAppStakeAmount(appAddr, stakeAmount))
+		_, err = db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+	}
+
+	height := int64(1)
+	snapshotDir := t.TempDir()
+	require.NoError(t, testPersistenceMod.ExportSnapshot(height, snapshotDir))
+
+	// The snapshot holds the nodes and values of the trees but none of their history
+	manifestBz, err := os.ReadFile(filepath.Join(snapshotDir, "manifest"))
+	require.NoError(t, err)
+	manifest := new(types.SnapshotManifest)
+	require.NoError(t, codec.GetCodec().Unmarshal(manifestBz, manifest))
+	for i := range manifest.ChunkHashes {
+		chunkBz, err := os.ReadFile(filepath.Join(snapshotDir, fmt.Sprintf("chunk_%06d", i)))
+		require.NoError(t, err)
+		chunk := new(types.SnapshotChunk)
+		require.NoError(t, codec.GetCodec().Unmarshal(chunkBz, chunk))
+		for _, entry := range chunk.TreeEntries {
+			require.Len(t, entry.Key, sha256.Size)
+		}
+	}
+
+	importingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("past_snapshot_test"))
+	defer importingMod.Stop()
+	require.NoError(t, importingMod.ImportSnapshot(snapshotDir, getTestStateHash(t, height)))
+
+	exportedCtx, err := testPersistenceMod.NewReadContext(height)
+	require.NoError(t, err)
+	defer exportedCtx.Close()
+	importedCtx, err := importingMod.NewReadContext(height)
+	require.NoError(t, err)
+	defer importedCtx.Close()
+	requireSameState(t, exportedCtx, importedCtx, height)
+
+	// Applying the next height on top of the snapshot computes the state hash of the exporting node at that height
+	ctx, err := importingMod.NewRWContext(height + 1)
+	require.NoError(t, err)
+	require.NoError(t, ctx.SetAppStakeAmount(appAddr, "43"))
+	stateHash, err := ctx.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, importingMod.ReleaseWriteContext())
+	require.Equal(t, getTestStateHash(t, height+1), stateHash)
+}
+
+func TestPersistenceModule_ImportCorruptedSnapshot(t *testing.T) {
+	resetStateToGenesis()
+	snapshotDir := t.TempDir()
+	require.NoError(t, testPersistenceMod.ExportSnapshot(0, snapshotDir))
+
+	chunkPath := filepath.Join(snapshotDir, "chunk_000000")
+	chunkBz, err := os.ReadFile(chunkPath)
+	require.NoError(t, err)
+	chunkBz[len(chunkBz)-1] ^= 1
+	require.NoError(t, os.WriteFile(chunkPath, chunkBz, 0o600))
+
+	importingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("corrupted_snapshot_test"))
+	defer importingMod.Stop()
+	require.Error(t, importingMod.ImportSnapshot(snapshotDir, getTestStateHash(t, 0)))
+
+	// The state of a failed import is cleared
+	readCtx, err := importingMod.NewReadContext(0)
+	require.NoError(t, err)
+	defer readCtx.Close()
+	_, err = readCtx.GetLatestBlockHeight()
+	require.Error(t, err)
+}
+
+func TestPersistenceModule_ImportSnapshotWithRowsNotInTrees(t *testing.T) {
+	resetStateToGenesis()
+	snapshotDir := t.TempDir()
+	require.NoError(t, testPersistenceMod.ExportSnapshot(0, snapshotDir))
+
+	// Tamper with an account row and rehash its chunk into the manifest, leaving the trees and their roots untouched
+	manifestPath := filepath.Join(snapshotDir, "manifest")
+	manifestBz, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	manifest := new(types.SnapshotManifest)
+	require.NoError(t, codec.GetCodec().Unmarshal(manifestBz, manifest))
+
+	chunkPath := filepath.Join(snapshotDir, "chunk_000000")
+	chunkBz, err := os.ReadFile(chunkPath)
+	require.NoError(t, err)
+	chunk := new(types.SnapshotChunk)
+	require.NoError(t, codec.GetCodec().Unmarshal(chunkBz, chunk))
+	require.NotEmpty(t, chunk.AccountRows)
+	chunk.AccountRows[0].Balance += "0"
+
+	chunkBz, err = codec.GetCodec().Marshal(chunk)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(chunkPath, chunkBz, 0o600))
+	chunkHash := sha256.Sum256(chunkBz)
+	manifest.ChunkHashes[0] = chunkHash[:]
+	manifestBz, err = codec.GetCodec().Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestPath, manifestBz, 0o600))
+
+	importingMod := newTestPersistenceModule(newIsolatedTestPersistenceConfig("tampered_snapshot_test"))
+	defer importingMod.Stop()
+	require.Error(t, importingMod.ImportSnapshot(snapshotDir, getTestStateHash(t, 0)))
+}
+
+func getTestStateHash(t *testing.T, height int64) string {
+	block, err := testPersistenceMod.GetBlock(height)
+	require.NoError(t, err)
+	return block.BlockHeader.StateHash
+}
+
+func requireSameState(t *testing.T, expected, actual modules.PersistenceReadContext, height int64) {
+	expectedBlockHash, err := expected.GetBlockHash(height)
+	require.NoError(t, err)
+	actualBlockHash, err := actual.GetBlockHash(height)
+	require.NoError(t, err)
+	require.Equal(t, expectedBlockHash, actualBlockHash)
+
+	for _, getAll := range []func(modules.PersistenceReadContext) (any, error){
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllApps(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllValidators(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllServiceNodes(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllFishermen(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllAccounts(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) { return ctx.GetAllPools(height) },
+		func(ctx modules.PersistenceReadContext) (any, error) {
+			return ctx.GetIntParam(persistence.BlocksPerSessionParamName, height)
+		},
+	} {
+		expectedState, err := getAll(expected)
+		require.NoError(t, err)
+		actualState, err := getAll(actual)
+		require.NoError(t, err)
+		require.Equal(t, expectedState, actualState)
+	}
+}
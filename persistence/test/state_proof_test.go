@@ -0,0 +1,128 @@
+package test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistenceModule_GetStateProof(t *testing.T) {
+	resetStateToGenesis()
+
+	height := int64(1)
+	db := NewTestPostgresContext(t, height)
+	apps, err := db.GetAllApps(height)
+	require.NoError(t, err)
+	appAddr, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+	require.NoError(t, db.SetAppStakeAmount(appAddr, "42"))
+	_, err = db.ComputeStateHash()
+	require.NoError(t, err)
+	require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+	require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+
+	blockBz, err := testPersistenceMod.GetBlockStore().Get(heightToBytes(height))
+	require.NoError(t, err)
+	block := new(coreTypes.Block)
+	require.NoError(t, codec.GetCodec().Unmarshal(blockBz, block))
+	blockHeader := block.BlockHeader
+
+	// Inclusion of the updated application
+	proof, err := testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	require.True(t, proof.IsInclusion())
+	require.Len(t, proof.TreeRoots, coreTypes.NumStateTrees)
+	require.NoError(t, coreTypes.VerifyStateProof(proof, blockHeader))
+	app := new(coreTypes.Actor)
+	require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, app))
+	require.Equal(t, "42", app.StakedAmount)
+
+	// Exclusion of an unknown application
+	unknownAddr := make([]byte, len(appAddr))
+	proof, err = testPersistenceMod.GetStateProof("app", unknownAddr, height)
+	require.NoError(t, err)
+	require.False(t, proof.IsInclusion())
+	require.NoError(t, coreTypes.VerifyStateProof(proof, blockHeader))
+
+	// Tampered proofs are rejected
+	proof, err = testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	proof.Value = []byte("tampered")
+	require.Error(t, coreTypes.VerifyStateProof(proof, blockHeader))
+
+	proof, err = testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	proof.TreeRoots[proof.TreeIndex+1] = proof.TreeRoots[proof.TreeIndex]
+	require.Error(t, coreTypes.VerifyStateProof(proof, blockHeader), "the tree roots no longer hash to the state hash")
+
+	// Moving the boundaries between the roots keeps the state hash but must not pass off other bytes as a root
+	proof, err = testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	shiftedRoots := append([][]byte{}, proof.TreeRoots...)
+	shiftedRoots[0] = proof.TreeRoots[0][:coreTypes.StateTreeRootLen-1]
+	shiftedRoots[1] = append(append([]byte{}, proof.TreeRoots[0][coreTypes.StateTreeRootLen-1:]...), proof.TreeRoots[1]...)
+	require.Equal(t, coreTypes.GetStateHash(proof.TreeRoots), coreTypes.GetStateHash(shiftedRoots))
+	proof.TreeRoots = shiftedRoots
+	require.Error(t, coreTypes.VerifyStateProof(proof, blockHeader))
+
+	proof, err = testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	proof.TreeRoots = append(proof.TreeRoots, []byte{})
+	require.Equal(t, blockHeader.StateHash, coreTypes.GetStateHash(proof.TreeRoots))
+	require.Error(t, coreTypes.VerifyStateProof(proof, blockHeader), "an empty root does not change the state hash")
+
+	proof, err = testPersistenceMod.GetStateProof("app", appAddr, height)
+	require.NoError(t, err)
+	require.Error(t, coreTypes.VerifyStateProof(proof, &coreTypes.BlockHeader{Height: uint64(height), StateHash: "deadbeef"}))
+
+	_, err = testPersistenceMod.GetStateProof("unknown", appAddr, height)
+	require.Error(t, err)
+	_, err = testPersistenceMod.GetStateProof("app", appAddr, height+1)
+	require.Error(t, err, "the height is not committed yet")
+}
+
+func TestPersistenceModule_GetStateProofAtPastHeights(t *testing.T) {
+	resetStateToGenesis()
+
+	readCtx, err := testPersistenceMod.NewReadContext(0)
+	require.NoError(t, err)
+	apps, err := readCtx.GetAllApps(0)
+	require.NoError(t, err)
+	require.NoError(t, readCtx.Close())
+	appAddr, err := hex.DecodeString(apps[0].GetAddress())
+	require.NoError(t, err)
+
+	stakeAmounts := map[int64]string{0: apps[0].GetStakedAmount()}
+	numHeights := int64(5)
+	for height := int64(1); height <= numHeights; height++ {
+		db := NewTestPostgresContext(t, height)
+		stakeAmounts[height] = fmt.Sprintf("%d", 1000+height)
+		require.NoError(t, db.SetAppStakeAmount(appAddr, stakeAmounts[height]))
+		_, err := db.ComputeStateHash()
+		require.NoError(t, err)
+		require.NoError(t, db.Commit([]byte("placeholderProposer"), []byte("placeholderQuorumCert")))
+		require.NoError(t, testPersistenceMod.ReleaseWriteContext())
+	}
+
+	for height := int64(0); height <= numHeights; height++ {
+		blockBz, err := testPersistenceMod.GetBlockStore().Get(heightToBytes(height))
+		require.NoError(t, err)
+		block := new(coreTypes.Block)
+		require.NoError(t, codec.GetCodec().Unmarshal(blockBz, block))
+
+		proof, err := testPersistenceMod.GetStateProof("app", appAddr, height)
+		require.NoError(t, err)
+
+		// The roots of the trees reopened at the height match the state hash of its block
+		require.Equal(t, block.BlockHeader.StateHash, coreTypes.GetStateHash(proof.TreeRoots))
+		require.NoError(t, coreTypes.VerifyStateProof(proof, block.BlockHeader))
+
+		app := new(coreTypes.Actor)
+		require.NoError(t, codec.GetCodec().Unmarshal(proof.Value, app))
+		require.Equal(t, stakeAmounts[height], app.StakedAmount)
+	}
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertProveAndSettleTestScore(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	fishermanAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	servicerAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	appPublicKeyHex := hex.EncodeToString(appPublicKey.Bytes())
+	samplesRoot := []byte("samples_root")
+
+	exists, err := db.GetTestScoreExists(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, db.InsertTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", "geozone", samplesRoot, 3))
+	require.Error(t, db.InsertTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", "geozone", samplesRoot, 3), "a fisherman can only score a servicer once per session")
+
+	exists, err = db.GetTestScoreExists(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	geoZone, gotSamplesRoot, numNullSamples, scoreHeight, provenHeight, settledHeight, err := db.GetTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.Equal(t, "geozone", geoZone)
+	require.Equal(t, samplesRoot, gotSamplesRoot)
+	require.Equal(t, uint64(3), numNullSamples)
+	require.Equal(t, int64(1), scoreHeight)
+	require.Equal(t, int64(-1), provenHeight)
+	require.Equal(t, int64(-1), settledHeight)
+
+	require.NoError(t, db.SetTestScoreProvenHeight(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 2))
+
+	_, _, _, _, provenHeight, _, err = db.GetTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), provenHeight)
+
+	require.NoError(t, db.SetTestScoreSettledHeight(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 3))
+
+	_, _, _, _, _, settledHeight, err = db.GetTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), settledHeight)
+
+	// the settled test score is kept so it can neither be submitted nor settle its session again
+	exists, err = db.GetTestScoreExists(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0001", 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestInsertTestScoreEscapesStrings(t *testing.T) {
+	db := NewTestPostgresContext(t, 1)
+
+	fishermanAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	servicerAddress, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	appPublicKeyHex := hex.EncodeToString(appPublicKey.Bytes())
+	geoZone := "'); DELETE FROM test_score; --"
+
+	require.NoError(t, db.InsertTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0'01", geoZone, []byte("samples_root"), 1))
+
+	gotGeoZone, _, _, _, _, _, err := db.GetTestScore(fishermanAddress, servicerAddress, appPublicKeyHex, 0, "0'01", 1)
+	require.NoError(t, err)
+	require.Equal(t, geoZone, gotGeoZone)
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"encoding/hex"
+
+	"github.com/pokt-network/pocket/persistence/types"
+)
+
+func (p PostgresContext) InsertTestScore(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain, geoZone string, samplesRoot []byte, numNullSamples uint64) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, types.InsertTestScoreQuery(hex.EncodeToString(fishermanAddress), hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, geoZone, hex.EncodeToString(samplesRoot), numNullSamples, p.Height))
+	return err
+}
+
+func (p PostgresContext) SetTestScoreProvenHeight(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, types.SetTestScoreProvenHeightQuery(hex.EncodeToString(fishermanAddress), hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, provenHeight))
+	return err
+}
+
+func (p PostgresContext) SetTestScoreSettledHeight(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, settledHeight int64) error {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, types.SetTestScoreSettledHeightQuery(hex.EncodeToString(fishermanAddress), hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, settledHeight))
+	return err
+}
+
+func (p PostgresContext) GetTestScore(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (geoZone string, samplesRoot []byte, numNullSamples uint64, scoreHeight, provenHeight, settledHeight int64, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return
+	}
+
+	var samplesRootHex string
+	if err = tx.QueryRow(ctx, types.GetTestScoreQuery(hex.EncodeToString(fishermanAddress), hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, height)).Scan(&geoZone, &samplesRootHex, &numNullSamples, &scoreHeight, &provenHeight, &settledHeight); err != nil {
+		return
+	}
+
+	samplesRoot, err = hex.DecodeString(samplesRootHex)
+	return
+}
+
+func (p PostgresContext) GetTestScoreExists(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (exists bool, err error) {
+	ctx, tx, err := p.getCtxAndTx()
+	if err != nil {
+		return
+	}
+
+	err = tx.QueryRow(ctx, types.TestScoreExistsQuery(hex.EncodeToString(fishermanAddress), hex.EncodeToString(servicerAddress), appPublicKey, sessionHeight, relayChain, height)).Scan(&exists)
+	return
+}
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 var _ ProtocolAccountSchema = &AccountSchema{}
 
 type AccountSchema struct {
@@ -9,12 +11,23 @@ type AccountSchema struct {
 const (
 	AccountTableName        = "account"
 	AccountHeightConstraint = "account_create_height"
+	SequenceCol             = "sequence"
 )
 
 var Account ProtocolAccountSchema = &AccountSchema{
 	baseProtocolAccountSchema{
 		tableName:              AccountTableName,
 		accountSpecificColName: AddressCol,
+		sequenceColName:        SequenceCol,
 		heightConstraintName:   AccountHeightConstraint,
 	},
 }
+
+// The sequence column is added to the account table by a migration, and defaults to 0 for the existing accounts
+func AddAccountSequenceColumnQuery() string {
+	return fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s BIGINT NOT NULL DEFAULT 0`, AccountTableName, SequenceCol)
+}
+
+func GetAccountSequenceQuery(address string, height int64) string {
+	return Select(SequenceCol, address, height, AccountTableName)
+}
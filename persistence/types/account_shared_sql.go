@@ -14,13 +14,13 @@ func protocolAccountTableSchema(accountSpecificColName, constraintName string) s
 		)`, accountSpecificColName, BalanceCol, HeightCol, constraintName, accountSpecificColName, HeightCol)
 }
 
-func SelectAccounts(height int64, colName, tableName string) string {
+func SelectAccounts(height int64, colName, sequenceSelector, tableName string) string {
 	return fmt.Sprintf(`
-			SELECT DISTINCT ON (%s) %s, balance, height
+			SELECT %s, balance, %s, height
 			FROM %s
-			WHERE height<=%d
-			ORDER BY %s, height DESC
-       `, colName, colName, tableName, height, colName)
+			WHERE %s
+			ORDER BY %s
+       `, colName, sequenceSelector, tableName, latestAtHeight(colName, height, tableName), colName)
 }
 
 func SelectBalance(accountSpecificParam, accountSpecificParamValue string, height int64, tableName string) string {
@@ -28,11 +28,32 @@ func SelectBalance(accountSpecificParam, accountSpecificParamValue string, heigh
 		tableName, accountSpecificParam, accountSpecificParamValue, height)
 }
 
-func InsertAccount(accountSpecificParam, accountSpecificParamValue, amount string, height int64, tableName, constraintName string) string {
+func InsertAccount(accountSpecificParam, accountSpecificParamValue, amount string, height int64, tableName string) string {
 	return fmt.Sprintf(`
 		INSERT INTO %s (%s, balance, height)
 			VALUES ('%s','%s',%d)
-			ON CONFLICT ON CONSTRAINT %s
+			ON CONFLICT (%s, height)
 			DO UPDATE SET balance=EXCLUDED.balance, height=EXCLUDED.height
-		`, tableName, accountSpecificParam, accountSpecificParamValue, amount, height, constraintName)
+		`, tableName, accountSpecificParam, accountSpecificParamValue, amount, height, accountSpecificParam)
+}
+
+// Inserts the balance of an account at a height, carrying its sequence over from its latest version
+func InsertAccountWithSequence(accountSpecificParam, accountSpecificParamValue, amount string, height int64, tableName string) string {
+	return fmt.Sprintf(`
+		INSERT INTO %s (%s, balance, sequence, height)
+			VALUES ('%s','%s',COALESCE((%s),0),%d)
+			ON CONFLICT (%s, height)
+			DO UPDATE SET balance=EXCLUDED.balance, height=EXCLUDED.height
+		`, tableName, accountSpecificParam, accountSpecificParamValue, amount,
+		Select(SequenceCol, accountSpecificParamValue, height, tableName), height, accountSpecificParam)
+}
+
+// Inserts the sequence of an account at a height, carrying its balance over from its latest version
+func InsertAccountSequence(address string, sequence uint64, height int64) string {
+	return fmt.Sprintf(`
+		INSERT INTO %s (address, balance, sequence, height)
+			VALUES ('%s',COALESCE((%s),'0'),%d,%d)
+			ON CONFLICT (address, height)
+			DO UPDATE SET sequence=EXCLUDED.sequence, height=EXCLUDED.height
+		`, AccountTableName, address, Select(BalanceCol, address, height, AccountTableName), sequence, height)
 }
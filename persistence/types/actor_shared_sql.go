@@ -85,11 +85,11 @@ func Select(selector, address string, height int64, tableName string) string {
 
 func SelectActors(actorSpecificParam string, height int64, tableName string) string {
 	return fmt.Sprintf(`
-			SELECT DISTINCT ON (address) address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height
+			SELECT address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height
 			FROM %s
-			WHERE height<=%d
-			ORDER BY address, height DESC
-       `, actorSpecificParam, tableName, height)
+			WHERE %s
+			ORDER BY address
+       `, actorSpecificParam, tableName, latestAtHeight(AddressCol, height, tableName))
 }
 
 func selectChains(selector, address string, height int64, actorTableName, chainsTableName string) string {
@@ -115,34 +115,32 @@ func readyToUnstake(unstakingHeight int64, tableName string) string {
 		tableName, unstakingHeight, tableName)
 }
 
+// `latestAtHeight` returns a condition selecting the latest version of each row (i.e. identified by `keyCol`)
+// at `height`, which is how `SELECT DISTINCT ON` is expressed in the SQL dialect shared by all the SQL drivers.
+func latestAtHeight(keyCol string, height int64, tableName string) string {
+	return fmt.Sprintf(`(height, %s) IN (SELECT MAX(height), %s FROM %s WHERE height<=%d GROUP BY %s)`,
+		keyCol, keyCol, tableName, height, keyCol)
+}
+
 func Insert(
 	actor *coreTypes.Actor,
 	actorSpecificParam, actorSpecificParamValue,
-	constraintName, chainsConstraintName,
-	tableName, chainsTableName string,
+	tableName string,
 	height int64) string {
-	insertStatement := fmt.Sprintf(
+	return fmt.Sprintf(
 		`INSERT INTO %s (address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
 				VALUES('%s', '%s', '%s', '%s', '%s', %d, %d, %d)
-				ON CONFLICT ON CONSTRAINT %s
+				ON CONFLICT (address, height)
 				DO UPDATE SET staked_tokens=EXCLUDED.staked_tokens, %s=EXCLUDED.%s,
 							  paused_height=EXCLUDED.paused_height, unstaking_height=EXCLUDED.unstaking_height,
 							  height=EXCLUDED.height`,
 		tableName, actorSpecificParam,
 		actor.Address, actor.PublicKey, actor.StakedAmount, actorSpecificParamValue,
 		actor.Output, actor.PausedHeight, actor.UnstakingHeight, height,
-		constraintName,
 		actorSpecificParam, actorSpecificParam)
-
-	if actor.Chains == nil {
-		return insertStatement
-	}
-
-	return fmt.Sprintf("WITH baseTableInsert AS (%s)\n%s",
-		insertStatement, insertChains(actor.Address, actor.Chains, height, chainsTableName, chainsConstraintName))
 }
 
-func insertChains(address string, chains []string, height int64, tableName, constraintName string) string {
+func insertChains(address string, chains []string, height int64, tableName string) string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString(fmt.Sprintf("INSERT INTO %s (address, chain_id, height) VALUES", tableName))
@@ -155,86 +153,72 @@ func insertChains(address string, chains []string, height int64, tableName, cons
 		}
 	}
 
-	buffer.WriteString(fmt.Sprintf("\nON CONFLICT ON CONSTRAINT %s DO NOTHING", constraintName))
+	buffer.WriteString("\nON CONFLICT (address, chain_id, height) DO NOTHING")
 
 	return buffer.String()
 }
 
-func Update(address, stakedTokens, actorSpecificParam, actorSpecificParamValue string, height int64, tableName, constraintName string) string {
+func Update(address, stakedTokens, actorSpecificParam, actorSpecificParamValue string, height int64, tableName string) string {
 	return fmt.Sprintf(
 		`INSERT INTO %s(address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
-			(
-				SELECT address, public_key, '%s', '%s', output_address, paused_height, unstaking_height, %d
-				FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
-			)
-		    ON CONFLICT ON CONSTRAINT %s
+			SELECT address, public_key, '%s', '%s', output_address, paused_height, unstaking_height, %d
+			FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
+			ON CONFLICT (address, height)
 			DO UPDATE SET staked_tokens=EXCLUDED.staked_tokens, %s=EXCLUDED.%s, height=EXCLUDED.height`,
 		tableName, actorSpecificParam,
 		stakedTokens, actorSpecificParamValue, height,
 		tableName, address, height,
-		constraintName,
 		actorSpecificParam, actorSpecificParam)
 }
 
-func updateUnstakingHeight(address, actorSpecificParam string, unstakingHeight, height int64, tableName, constraintName string) string {
+func updateUnstakingHeight(address, actorSpecificParam string, unstakingHeight, height int64, tableName string) string {
 	return fmt.Sprintf(`
 		INSERT INTO %s(address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
-		(
-			SELECT address, public_key, staked_tokens, %s, output_address, paused_height, %d, %d
-			FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
-		)
-		ON CONFLICT ON CONSTRAINT %s
+		SELECT address, public_key, staked_tokens, %s, output_address, paused_height, %d, %d
+		FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
+		ON CONFLICT (address, height)
 			DO UPDATE SET unstaking_height=EXCLUDED.unstaking_height, height=EXCLUDED.height`,
 		tableName, actorSpecificParam,
 		actorSpecificParam, unstakingHeight, height,
-		tableName, address, height,
-		constraintName)
+		tableName, address, height)
 }
 
-func updateStakeAmount(address, actorSpecificParam, stakeAmount string, height int64, tableName, constraintName string) string {
+func updateStakeAmount(address, actorSpecificParam, stakeAmount string, height int64, tableName string) string {
 	return fmt.Sprintf(`
 		INSERT INTO %s(address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
-		(
-			SELECT address, public_key, '%s', %s, output_address, paused_height, unstaking_height, %d
-			FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
-		)
-		ON CONFLICT ON CONSTRAINT %s
+		SELECT address, public_key, '%s', %s, output_address, paused_height, unstaking_height, %d
+		FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
+		ON CONFLICT (address, height)
 			DO UPDATE SET staked_tokens=EXCLUDED.staked_tokens, height=EXCLUDED.height`,
 		tableName, actorSpecificParam,
 		stakeAmount, actorSpecificParam, height,
-		tableName, address, height,
-		constraintName)
+		tableName, address, height)
 }
 
-func updatePausedHeight(address, actorSpecificParam string, pausedHeight, height int64, tableName, constraintName string) string {
+func updatePausedHeight(address, actorSpecificParam string, pausedHeight, height int64, tableName string) string {
 	return fmt.Sprintf(`
 		INSERT INTO %s(address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
-		(
-			SELECT address, public_key, staked_tokens, %s, output_address, %d, unstaking_height, %d
-			FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
-		)
-		ON CONFLICT ON CONSTRAINT %s
+		SELECT address, public_key, staked_tokens, %s, output_address, %d, unstaking_height, %d
+		FROM %s WHERE address='%s' AND height<=%d ORDER BY height DESC LIMIT 1
+		ON CONFLICT (address, height)
 			DO UPDATE SET paused_height=EXCLUDED.paused_height, height=EXCLUDED.height`,
 		tableName, actorSpecificParam, actorSpecificParam,
 		pausedHeight, height,
-		tableName, address, height, constraintName)
+		tableName, address, height)
 }
 
-func updateUnstakedHeightIfPausedBefore(actorSpecificParam string, unstakingHeight, pausedBeforeHeight, height int64, tableName, constraintName string) string {
+func updateUnstakedHeightIfPausedBefore(actorSpecificParam string, unstakingHeight, pausedBeforeHeight, height int64, tableName string) string {
 	return fmt.Sprintf(`
 		INSERT INTO %s (address, public_key, staked_tokens, %s, output_address, paused_height, unstaking_height, height)
-		(
-			SELECT address, public_key, staked_tokens, %s, output_address, paused_height, %d, %d
-			FROM %s WHERE paused_height<%d
-				AND (height,address) IN (SELECT MAX(height),address from %s GROUP BY address)
-        )
-		ON CONFLICT ON CONSTRAINT %s
+		SELECT address, public_key, staked_tokens, %s, output_address, paused_height, %d, %d
+		FROM %s WHERE paused_height<%d
+			AND (height,address) IN (SELECT MAX(height),address from %s GROUP BY address)
+		ON CONFLICT (address, height)
 			DO UPDATE SET unstaking_height=EXCLUDED.unstaking_height`,
 		tableName, actorSpecificParam,
 		actorSpecificParam, unstakingHeight, height,
 		tableName, pausedBeforeHeight,
-		tableName,
-		constraintName)
+		tableName)
 }
 
 func NullifyChains(address string, height int64, tableName string) string {
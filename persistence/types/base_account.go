@@ -14,6 +14,7 @@ type baseProtocolAccountSchema struct {
 
 	// SQL Columns
 	accountSpecificColName string
+	sequenceColName        string // empty if the accounts have no sequence (i.e. the pools)
 
 	// SQL Constraints
 	heightConstraintName string
@@ -36,17 +37,28 @@ func (account baseProtocolAccountSchema) GetAccountAmountQuery(identifier string
 }
 
 func (account baseProtocolAccountSchema) GetAccountsUpdatedAtHeightQuery(height int64) string {
-	return SelectAtHeight(fmt.Sprintf("%s,%s", account.accountSpecificColName, BalanceCol), height, account.tableName)
+	return SelectAtHeight(fmt.Sprintf("%s,%s,%s", account.accountSpecificColName, BalanceCol, account.sequenceSelector()), height, account.tableName)
 }
 
 func (account baseProtocolAccountSchema) GetAllQuery(height int64) string {
-	return SelectAccounts(height, account.accountSpecificColName, account.tableName)
+	return SelectAccounts(height, account.accountSpecificColName, account.sequenceSelector(), account.tableName)
 }
 
 func (account baseProtocolAccountSchema) InsertAccountQuery(identifier, amount string, height int64) string {
-	return InsertAccount(account.accountSpecificColName, identifier, amount, height, account.tableName, account.heightConstraintName)
+	if account.sequenceColName == "" {
+		return InsertAccount(account.accountSpecificColName, identifier, amount, height, account.tableName)
+	}
+	return InsertAccountWithSequence(account.accountSpecificColName, identifier, amount, height, account.tableName)
 }
 
 func (account baseProtocolAccountSchema) ClearAllAccounts() string {
 	return fmt.Sprintf(`DELETE FROM %s`, account.tableName)
 }
+
+// The accounts without a sequence are selected with a sequence of 0, so the accounts and the pools are read alike
+func (account baseProtocolAccountSchema) sequenceSelector() string {
+	if account.sequenceColName == "" {
+		return "0"
+	}
+	return account.sequenceColName
+}
@@ -91,7 +91,7 @@ func (actor *BaseProtocolActorSchema) GetChainsQuery(address string, height int6
 	return selectChains(AllColsSelector, address, height, actor.tableName, actor.chainsTableName)
 }
 
-func (actor *BaseProtocolActorSchema) InsertQuery(address, publicKey, stakedTokens, generic, outputAddress string, pausedHeight, unstakingHeight int64, chains []string, height int64) string {
+func (actor *BaseProtocolActorSchema) InsertQuery(address, publicKey, stakedTokens, generic, outputAddress string, pausedHeight, unstakingHeight int64, height int64) string {
 	return Insert(&coreTypes.Actor{
 		Address:         address,
 		PublicKey:       publicKey,
@@ -99,36 +99,34 @@ func (actor *BaseProtocolActorSchema) InsertQuery(address, publicKey, stakedToke
 		Output:          outputAddress,
 		PausedHeight:    pausedHeight,
 		UnstakingHeight: unstakingHeight,
-		Chains:          chains,
 	},
 		actor.actorSpecificColName, generic,
-		actor.heightConstraintName, actor.chainsHeightConstraintName,
-		actor.tableName, actor.chainsTableName,
+		actor.tableName,
 		height)
 }
 
-func (actor *BaseProtocolActorSchema) UpdateQuery(address, stakedTokens, generic string, height int64) string {
-	return Update(address, stakedTokens, actor.actorSpecificColName, generic, height, actor.tableName, actor.heightConstraintName)
+func (actor *BaseProtocolActorSchema) InsertChainsQuery(address string, chains []string, height int64) string {
+	return insertChains(address, chains, height, actor.chainsTableName)
 }
 
-func (actor *BaseProtocolActorSchema) UpdateChainsQuery(address string, chains []string, height int64) string {
-	return insertChains(address, chains, height, actor.chainsTableName, actor.chainsHeightConstraintName)
+func (actor *BaseProtocolActorSchema) UpdateQuery(address, stakedTokens, generic string, height int64) string {
+	return Update(address, stakedTokens, actor.actorSpecificColName, generic, height, actor.tableName)
 }
 
 func (actor *BaseProtocolActorSchema) UpdateUnstakingHeightQuery(address string, unstakingHeight, height int64) string {
-	return updateUnstakingHeight(address, actor.actorSpecificColName, unstakingHeight, height, actor.tableName, actor.heightConstraintName)
+	return updateUnstakingHeight(address, actor.actorSpecificColName, unstakingHeight, height, actor.tableName)
 }
 
 func (actor *BaseProtocolActorSchema) UpdatePausedHeightQuery(address string, pausedHeight, height int64) string {
-	return updatePausedHeight(address, actor.actorSpecificColName, pausedHeight, height, actor.tableName, actor.heightConstraintName)
+	return updatePausedHeight(address, actor.actorSpecificColName, pausedHeight, height, actor.tableName)
 }
 
 func (actor *BaseProtocolActorSchema) UpdateUnstakedHeightIfPausedBeforeQuery(pauseBeforeHeight, unstakingHeight, height int64) string {
-	return updateUnstakedHeightIfPausedBefore(actor.actorSpecificColName, unstakingHeight, pauseBeforeHeight, height, actor.tableName, actor.heightConstraintName)
+	return updateUnstakedHeightIfPausedBefore(actor.actorSpecificColName, unstakingHeight, pauseBeforeHeight, height, actor.tableName)
 }
 
 func (actor *BaseProtocolActorSchema) SetStakeAmountQuery(address string, stakedTokens string, height int64) string {
-	return updateStakeAmount(address, actor.actorSpecificColName, stakedTokens, height, actor.tableName, actor.heightConstraintName)
+	return updateStakeAmount(address, actor.actorSpecificColName, stakedTokens, height, actor.tableName)
 }
 
 func (actor *BaseProtocolActorSchema) ClearAllQuery() string {
@@ -25,10 +25,19 @@ func GetBlockHashQuery(height int64) string {
 	return fmt.Sprintf(`SELECT hash FROM %s WHERE height=%d`, BlockTableName, height)
 }
 
+func BlockExistsQuery(height int64) string {
+	return fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE height=%d)`, BlockTableName, height)
+}
+
 func GetLatestBlockHeightQuery() string {
 	return fmt.Sprintf(`SELECT MAX(height) FROM %s`, BlockTableName)
 }
 
+// The earliest block retained in the database, or 0 if there is none
+func GetEarliestBlockHeightQuery() string {
+	return fmt.Sprintf(`SELECT COALESCE(MIN(height), 0) FROM %s`, BlockTableName)
+}
+
 func ClearAllBlocksQuery() string {
 	return fmt.Sprintf(`DELETE FROM %s`, BlockTableName)
 }
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TODO: Claims are not versioned by height nor part of the state hash yet, but will need to be if historical
+// queries or state sync of pending claims are required.
+//
+// Proven claims are kept, with their `proven_height` set, as the record that their session was settled: a session
+// cannot be claimed again once its claim exists, whether it is proven or not.
+const (
+	ClaimTableName   = "claim"
+	ClaimTableSchema = `(
+			servicer_address       TEXT NOT NULL,
+			application_public_key TEXT NOT NULL,
+			session_height         BIGINT NOT NULL,
+			relay_chain            CHAR(4) NOT NULL,
+			geo_zone               TEXT NOT NULL,
+			merkle_root            TEXT NOT NULL,
+			height                 BIGINT NOT NULL,
+			PRIMARY KEY (servicer_address, application_public_key, session_height, relay_chain)
+		)`
+)
+
+func InsertClaimQuery(servicerAddress, appPublicKey string, sessionHeight int64, relayChain, geoZone, merkleRoot string, height int64) string {
+	return fmt.Sprintf(
+		`INSERT INTO %s(servicer_address, application_public_key, session_height, relay_chain, geo_zone, merkle_root, height)
+			VALUES(%s, %s, %d, %s, %s, %s, %d)`,
+		ClaimTableName,
+		sqlString(servicerAddress), sqlString(appPublicKey), sessionHeight, sqlString(relayChain), sqlString(geoZone), sqlString(merkleRoot), height)
+}
+
+// The proven height of the claims is added by a migration since the table predates it
+func AddClaimProvenHeightColumnQuery() string {
+	return fmt.Sprintf(`ALTER TABLE %s ADD COLUMN proven_height BIGINT NOT NULL DEFAULT %d`, ClaimTableName, DefaultBigInt)
+}
+
+func GetClaimQuery(servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, height int64) string {
+	return fmt.Sprintf(
+		`SELECT geo_zone, merkle_root, height, proven_height FROM %s WHERE %s AND height<=%d`,
+		ClaimTableName, claimKeyClause(servicerAddress, appPublicKey, sessionHeight, relayChain), height)
+}
+
+func ClaimExistsQuery(servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, height int64) string {
+	return fmt.Sprintf(
+		`SELECT EXISTS(SELECT 1 FROM %s WHERE %s AND height<=%d)`,
+		ClaimTableName, claimKeyClause(servicerAddress, appPublicKey, sessionHeight, relayChain), height)
+}
+
+func SetClaimProvenHeightQuery(servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) string {
+	return fmt.Sprintf(`UPDATE %s SET proven_height=%d WHERE %s`,
+		ClaimTableName, provenHeight, claimKeyClause(servicerAddress, appPublicKey, sessionHeight, relayChain))
+}
+
+func ClearAllClaimsQuery() string {
+	return fmt.Sprintf(`DELETE FROM %s`, ClaimTableName)
+}
+
+func claimKeyClause(servicerAddress, appPublicKey string, sessionHeight int64, relayChain string) string {
+	return fmt.Sprintf(`servicer_address=%s AND application_public_key=%s AND session_height=%d AND relay_chain=%s`,
+		sqlString(servicerAddress), sqlString(appPublicKey), sessionHeight, sqlString(relayChain))
+}
+
+// sqlString quotes `s` as a SQL string literal. The claims and test scores are built from the fields of
+// transactions, so their strings must be escaped rather than interpolated as is.
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
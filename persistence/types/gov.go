@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/pokt-network/pocket/runtime/genesis"
@@ -94,8 +95,7 @@ func InsertParams(params *genesis.Params, height int64) string {
 		}
 	}
 
-	constraint := fmt.Sprintf("%s_pkey", ParamsTableName)
-	fmt.Fprintf(&sb, " ON CONFLICT ON CONSTRAINT %s DO UPDATE SET value=EXCLUDED.value, type=EXCLUDED.type", constraint)
+	sb.WriteString(" ON CONFLICT (name, height) DO UPDATE SET value=EXCLUDED.value, type=EXCLUDED.type")
 
 	return sb.String()
 }
@@ -144,11 +144,40 @@ func InsertParamOrFlag[T SupportedParamTypes](tableName, name string, height int
 
 	sb.WriteString(")")
 
-	constraint := fmt.Sprintf("%s_pkey", tableName)
-	sb.WriteString(fmt.Sprintf("ON CONFLICT ON CONSTRAINT %s DO UPDATE SET %s", constraint, upsertFields))
+	sb.WriteString(fmt.Sprintf("ON CONFLICT (name, height) DO UPDATE SET %s", upsertFields))
 	return sb.String()
 }
 
+// SetGenesisParam sets the field of `params` named `paramName` to `value`, as it is stored in the database.
+// It is the inverse of `InsertParams`.
+// WARNING: reflections in prod
+func SetGenesisParam(params *genesis.Params, paramName, value string) error {
+	metadata, ok := govParamMetadataMap[paramName]
+	if !ok {
+		return fmt.Errorf("unknown param: %s", paramName)
+	}
+	field := reflect.ValueOf(params).Elem().FieldByName(metadata.PropertyName)
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value for param %s: %v", paramName, err)
+		}
+		field.SetInt(intValue)
+	case reflect.Slice:
+		bytesValue, err := hex.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for param %s: %v", paramName, err)
+		}
+		field.SetBytes(bytesValue)
+	default:
+		return fmt.Errorf("unhandled type for param %s: %s", paramName, field.Kind())
+	}
+	return nil
+}
+
 func ClearAllGovParamsQuery() string {
 	return fmt.Sprintf(`DELETE FROM %s`, ParamsTableName)
 }
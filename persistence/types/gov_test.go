@@ -77,6 +77,11 @@ func TestInsertParams(t *testing.T) {
 				"('message_pause_service_node_fee', -1, 'STRING', '10000')," +
 				"('message_unpause_service_node_fee', -1, 'STRING', '10000')," +
 				"('message_change_parameter_fee', -1, 'STRING', '10000')," +
+				"('message_claim_fee', -1, 'STRING', '10000')," +
+				"('message_proof_fee', -1, 'STRING', '10000')," +
+				"('relays_to_tokens_multiplier', -1, 'STRING', '1000')," +
+				"('claim_expiration_blocks', -1, 'BIGINT', 24)," +
+				"('fishermen_per_session', -1, 'SMALLINT', 1)," +
 				"('acl_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
 				"('blocks_per_session_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
 				"('app_minimum_stake_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
@@ -131,8 +136,13 @@ func TestInsertParams(t *testing.T) {
 				"('message_unstake_service_node_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
 				"('message_pause_service_node_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
 				"('message_unpause_service_node_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
-				"('message_change_parameter_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45') " +
-				"ON CONFLICT ON CONSTRAINT params_pkey DO UPDATE SET value=EXCLUDED.value, type=EXCLUDED.type",
+				"('message_change_parameter_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
+				"('message_claim_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
+				"('message_proof_fee_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
+				"('relays_to_tokens_multiplier_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
+				"('claim_expiration_blocks_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45')," +
+				"('fishermen_per_session_owner', -1, 'STRING', 'da034209758b78eaea06dd99c07909ab54c99b45') " +
+				"ON CONFLICT (name, height) DO UPDATE SET value=EXCLUDED.value, type=EXCLUDED.type",
 		},
 	}
 	for _, tt := range tests {
@@ -144,3 +154,50 @@ func TestInsertParams(t *testing.T) {
 	}
 
 }
+
+func TestSetGenesisParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		paramName string
+		value     string
+		wantErr   bool
+		check     func(params *genesis.Params) bool
+	}{
+		{
+			name:      "should set an int param",
+			paramName: "blocks_per_session",
+			value:     "4",
+			check:     func(params *genesis.Params) bool { return params.BlocksPerSession == 4 },
+		},
+		{
+			name:      "should set a string param",
+			paramName: "app_minimum_stake",
+			value:     "15000000000",
+			check:     func(params *genesis.Params) bool { return params.AppMinimumStake == "15000000000" },
+		},
+		{
+			name:      "should fail on an invalid int value",
+			paramName: "blocks_per_session",
+			value:     "four",
+			wantErr:   true,
+		},
+		{
+			name:      "should fail on an unknown param",
+			paramName: "unknown_param",
+			value:     "4",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := new(genesis.Params)
+			err := SetGenesisParam(params, tt.paramName, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetGenesisParam() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(params) {
+				t.Errorf("SetGenesisParam() did not set %s to %s", tt.paramName, tt.value)
+			}
+		})
+	}
+}
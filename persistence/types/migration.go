@@ -0,0 +1,20 @@
+package types
+
+import "fmt"
+
+const (
+	SchemaVersionTableName   = "schema_version"
+	SchemaVersionTableSchema = `(
+			version     INT PRIMARY KEY,
+			description TEXT NOT NULL
+		)`
+)
+
+func InsertSchemaVersionQuery(version uint32, description string) string {
+	return fmt.Sprintf(`INSERT INTO %s(version, description) VALUES(%d, '%s')`, SchemaVersionTableName, version, description)
+}
+
+// The version of the latest migration applied to the database, or 0 if there is none
+func GetSchemaVersionQuery() string {
+	return fmt.Sprintf(`SELECT COALESCE(MAX(version), 0) FROM %s`, SchemaVersionTableName)
+}
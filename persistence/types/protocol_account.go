@@ -14,16 +14,16 @@ type ProtocolAccountSchema interface {
 
 	/*** Read/Get Queries ***/
 
-	// Returns a query to get all accounts
+	// Returns a query to get all accounts, selecting their identifier, balance, sequence and height
 	GetAllQuery(height int64) string
 	// Returns a query to get the balance of an account at a specified height
 	GetAccountAmountQuery(identifier string, height int64) string // Identifier can either be address (cryptographic ID) (Account) or semantic name (Pool)
-	// Returns a query to select all accounts updated at a specified height
+	// Returns a query to select all accounts updated at a specified height, selecting their identifier, balance and sequence
 	GetAccountsUpdatedAtHeightQuery(height int64) string
 
 	/*** Create/Insert Queries ***/
 
-	// Returns a query to insert an account amount at a specified height
+	// Returns a query to insert an account amount at a specified height, keeping the sequence of the account
 	InsertAccountQuery(identifier, amount string, height int64) string // Identifier can either be address (cryptographic ID) (Account) or semantic name (Pool)
 
 	/*** Debug Queries Only ***/
@@ -46,14 +46,14 @@ type ProtocolActorSchema interface {
 
 	/*** Create/Insert Queries ***/
 
-	// Returns a query to create a new Actor with all of the necessary data.
-	InsertQuery(address, publicKey, stakedTokens, maxRelays, outputAddress string, pausedHeight, unstakingHeight int64, chains []string, height int64) string
+	// Returns a query to create a new Actor with all of the necessary data but its chains.
+	InsertQuery(address, publicKey, stakedTokens, maxRelays, outputAddress string, pausedHeight, unstakingHeight int64, height int64) string
+	// Returns a query to insert the chains an Actor is staked for at a height, when it is created or updated.
+	InsertChainsQuery(address string, chains []string, height int64) string
 
 	/*** Update Queries ***/
 	// Returns a query to update an Actor's stake and/or max relays.
 	UpdateQuery(address, stakedTokens, maxRelays string, height int64) string
-	// Returns a query to update the chains an Actor is staked for.
-	UpdateChainsQuery(address string, chains []string, height int64) string
 	// Returns a query to update the height at which an Actor is unstaking.
 	UpdateUnstakingHeightQuery(address string, unstakingHeight, height int64) string
 	// Returns a query to update the height at which an Actor is paused.
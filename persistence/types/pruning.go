@@ -0,0 +1,35 @@
+package types
+
+import "fmt"
+
+// `PruneVersionsQuery` deletes the versions of the rows of a height-versioned table (i.e. identified by `keyCol`)
+// which are superseded at `pruneHeight`. The version of each key at `pruneHeight` (i.e. the latest one at or
+// below it) is kept, so the table can still be queried at any height from `pruneHeight` onwards.
+func PruneVersionsQuery(keyCol string, pruneHeight int64, tableName string) string {
+	return fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE height<%d
+			AND EXISTS (SELECT 1 FROM %s AS newer
+				WHERE newer.%s=%s.%s AND newer.height>%s.height AND newer.height<=%d)`,
+		tableName,
+		pruneHeight,
+		tableName,
+		keyCol, tableName, keyCol, tableName, pruneHeight)
+}
+
+// `PruneChainsQuery` deletes the chains below `pruneHeight` which no longer belong to a version of their actor
+func PruneChainsQuery(pruneHeight int64, actorTableName, chainsTableName string) string {
+	return fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE height<%d
+			AND NOT EXISTS (SELECT 1 FROM %s AS actor
+				WHERE actor.address=%s.address AND actor.height=%s.height)`,
+		chainsTableName,
+		pruneHeight,
+		actorTableName,
+		chainsTableName, chainsTableName)
+}
+
+func PruneBlocksQuery(pruneHeight int64) string {
+	return fmt.Sprintf(`DELETE FROM %s WHERE height<%d`, BlockTableName, pruneHeight)
+}
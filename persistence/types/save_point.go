@@ -0,0 +1,22 @@
+package types
+
+import "fmt"
+
+// Save points are identified by their position in the stack of save points of a context rather than by
+// the hash of the transaction they precede, since the hex encoded hash can exceed the identifier length
+// limit of Postgres and identifiers cannot start with a digit.
+func SavePointName(position int) string {
+	return fmt.Sprintf("save_point_%d", position)
+}
+
+func SavePointQuery(name string) string {
+	return fmt.Sprintf(`SAVEPOINT %s`, name)
+}
+
+func RollbackToSavePointQuery(name string) string {
+	return fmt.Sprintf(`ROLLBACK TO SAVEPOINT %s`, name)
+}
+
+func ReleaseSavePointQuery(name string) string {
+	return fmt.Sprintf(`RELEASE SAVEPOINT %s`, name)
+}
@@ -0,0 +1,23 @@
+package types
+
+import "fmt"
+
+// `SelectGovRows` returns the latest version of every param or flag at `height`
+func SelectGovRows(tableName string, height int64) string {
+	fields := "name,height,type,value"
+	if tableName == FlagsTableName {
+		fields += ",enabled"
+	}
+	return fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY name ASC`,
+		fields, tableName, latestAtHeight(NameCol, height, tableName))
+}
+
+// `InsertGovRow` inserts a param or flag as it was read from the database, regardless of the type of its value
+func InsertGovRow(tableName, name string, height int64, valType, value string, enabled bool) string {
+	if tableName == FlagsTableName {
+		return fmt.Sprintf(`INSERT INTO %s(name,height,type,value,enabled) VALUES ('%s', %d, '%s', '%s', %t)`,
+			tableName, name, height, valType, value, enabled)
+	}
+	return fmt.Sprintf(`INSERT INTO %s(name,height,type,value) VALUES ('%s', %d, '%s', '%s')`,
+		tableName, name, height, valType, value)
+}
@@ -0,0 +1,67 @@
+package types
+
+import "fmt"
+
+// TODO: Like claims, test scores are not versioned by height nor part of the state hash yet.
+//
+// The test scores that paused their servicer are kept, with their `settled_height` set, so they cannot pause it again.
+const (
+	TestScoreTableName   = "test_score"
+	TestScoreTableSchema = `(
+			fisherman_address      TEXT NOT NULL,
+			servicer_address       TEXT NOT NULL,
+			application_public_key TEXT NOT NULL,
+			session_height         BIGINT NOT NULL,
+			relay_chain            CHAR(4) NOT NULL,
+			geo_zone               TEXT NOT NULL,
+			samples_root           TEXT NOT NULL,
+			num_null_samples       BIGINT NOT NULL,
+			height                 BIGINT NOT NULL,
+			proven_height          BIGINT NOT NULL DEFAULT -1,
+			PRIMARY KEY (fisherman_address, servicer_address, application_public_key, session_height, relay_chain)
+		)`
+)
+
+func InsertTestScoreQuery(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain, geoZone, samplesRoot string, numNullSamples uint64, height int64) string {
+	return fmt.Sprintf(
+		`INSERT INTO %s(fisherman_address, servicer_address, application_public_key, session_height, relay_chain, geo_zone, samples_root, num_null_samples, height)
+			VALUES(%s, %s, %s, %d, %s, %s, %s, %d, %d)`,
+		TestScoreTableName,
+		sqlString(fishermanAddress), sqlString(servicerAddress), sqlString(appPublicKey), sessionHeight, sqlString(relayChain), sqlString(geoZone), sqlString(samplesRoot), numNullSamples, height)
+}
+
+// The settled height of the test scores is added by a migration since the table predates it
+func AddTestScoreSettledHeightColumnQuery() string {
+	return fmt.Sprintf(`ALTER TABLE %s ADD COLUMN settled_height BIGINT NOT NULL DEFAULT %d`, TestScoreTableName, DefaultBigInt)
+}
+
+func GetTestScoreQuery(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, height int64) string {
+	return fmt.Sprintf(
+		`SELECT geo_zone, samples_root, num_null_samples, height, proven_height, settled_height FROM %s WHERE %s AND height<=%d`,
+		TestScoreTableName, testScoreKeyClause(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain), height)
+}
+
+func TestScoreExistsQuery(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, height int64) string {
+	return fmt.Sprintf(
+		`SELECT EXISTS(SELECT 1 FROM %s WHERE %s AND height<=%d)`,
+		TestScoreTableName, testScoreKeyClause(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain), height)
+}
+
+func SetTestScoreProvenHeightQuery(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) string {
+	return fmt.Sprintf(`UPDATE %s SET proven_height=%d WHERE %s`,
+		TestScoreTableName, provenHeight, testScoreKeyClause(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain))
+}
+
+func SetTestScoreSettledHeightQuery(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain string, settledHeight int64) string {
+	return fmt.Sprintf(`UPDATE %s SET settled_height=%d WHERE %s`,
+		TestScoreTableName, settledHeight, testScoreKeyClause(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain))
+}
+
+func ClearAllTestScoresQuery() string {
+	return fmt.Sprintf(`DELETE FROM %s`, TestScoreTableName)
+}
+
+func testScoreKeyClause(fishermanAddress, servicerAddress, appPublicKey string, sessionHeight int64, relayChain string) string {
+	return fmt.Sprintf(`fisherman_address=%s AND %s`,
+		sqlString(fishermanAddress), claimKeyClause(servicerAddress, appPublicKey, sessionHeight, relayChain))
+}
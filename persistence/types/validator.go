@@ -29,22 +29,7 @@ var ValidatorActor ProtocolActorSchema = &ValidatorSchema{
 	},
 }
 
-func (actor *ValidatorSchema) InsertQuery(address, publicKey, stakedTokens, maxRelays, outputAddress string, pausedHeight, unstakingHeight int64, _ []string, height int64) string {
-	return Insert(&coreTypes.Actor{
-		Address:         address,
-		PublicKey:       publicKey,
-		StakedAmount:    stakedTokens,
-		Output:          outputAddress,
-		PausedHeight:    pausedHeight,
-		UnstakingHeight: unstakingHeight,
-	},
-		actor.actorSpecificColName, maxRelays,
-		actor.heightConstraintName, NullString,
-		actor.tableName, NullString,
-		height)
-}
-
-func (actor *ValidatorSchema) UpdateChainsQuery(_ string, _ []string, _ int64) string {
+func (actor *ValidatorSchema) InsertChainsQuery(_ string, _ []string, _ int64) string {
 	panic(ValidatorPanicMsg)
 }
 func (actor *ValidatorSchema) GetChainsTableSchema() string            { panic(ValidatorPanicMsg) }
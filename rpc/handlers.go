@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pokt-network/pocket/app"
 	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
 
@@ -50,6 +51,151 @@ func (s *rpcServer) GetV1ConsensusState(ctx echo.Context) error {
 	})
 }
 
+func (s *rpcServer) PostV1QueryStateProof(ctx echo.Context) error {
+	query := new(QueryStateProof)
+	if err := ctx.Bind(query); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+
+	key, err := hex.DecodeString(query.Key)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode key")
+	}
+
+	proof, err := s.GetBus().GetPersistenceModule().GetStateProof(query.Tree, key, query.Height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, StateProof{
+		Height:                proof.Height,
+		Tree:                  query.Tree,
+		TreeIndex:             int64(proof.TreeIndex),
+		Key:                   hex.EncodeToString(proof.Key),
+		Value:                 hex.EncodeToString(proof.Value),
+		SideNodes:             encodeHexSlice(proof.SideNodes),
+		NonMembershipLeafData: hex.EncodeToString(proof.NonMembershipLeafData),
+		TreeRoots:             encodeHexSlice(proof.TreeRoots),
+	})
+}
+
+func (s *rpcServer) PostV1QueryEvents(ctx echo.Context) error {
+	query := new(QueryEvents)
+	if err := ctx.Bind(query); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+	if (query.Height == nil) == (query.Address == nil) {
+		return ctx.String(http.StatusBadRequest, "either a height or an address is required")
+	}
+
+	persistenceModule := s.GetBus().GetPersistenceModule()
+	var events []*coreTypes.StateChangeEvent
+	var err error
+	if query.Height != nil {
+		events, err = persistenceModule.GetEventsByHeight(*query.Height)
+	} else {
+		address, decodeErr := hex.DecodeString(*query.Address)
+		if decodeErr != nil {
+			return ctx.String(http.StatusBadRequest, "cannot decode address")
+		}
+		events, err = persistenceModule.GetEventsByAddress(address)
+	}
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	response := make([]StateChangeEvent, len(events))
+	for i, event := range events {
+		response[i] = StateChangeEvent{
+			Height:       event.Height,
+			Index:        int64(event.Index),
+			Type:         event.Type.String(),
+			Phase:        event.Phase.String(),
+			TxHash:       event.TxHash,
+			ActorType:    event.ActorType.String(),
+			Address:      event.Address,
+			Counterparty: event.Counterparty,
+			Pool:         event.Pool,
+			Amount:       event.Amount,
+		}
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (s *rpcServer) PostV1QueryFeeEstimate(ctx echo.Context) error {
+	query := new(QueryFeeEstimate)
+	if err := ctx.Bind(query); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+
+	txBz, err := hex.DecodeString(query.RawHexBytes)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode tx bytes")
+	}
+
+	minimumFee, fee, err := s.GetBus().GetUtilityModule().EstimateFee(txBz)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, FeeEstimate{
+		MinimumFee: minimumFee,
+		Fee:        fee,
+	})
+}
+
+func (s *rpcServer) PostV1QueryAccount(ctx echo.Context) error {
+	query := new(QueryAccount)
+	if err := ctx.Bind(query); err != nil {
+		return ctx.String(http.StatusBadRequest, "bad request")
+	}
+
+	address, err := hex.DecodeString(query.Address)
+	if err != nil {
+		return ctx.String(http.StatusBadRequest, "cannot decode address")
+	}
+
+	readCtx, err := s.GetBus().GetPersistenceModule().NewReadContext(-1)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	defer readCtx.Close()
+
+	var height int64
+	if query.Height != nil {
+		height = *query.Height
+	} else {
+		latestHeight, err := readCtx.GetLatestBlockHeight()
+		if err != nil {
+			return ctx.String(http.StatusInternalServerError, err.Error())
+		}
+		height = int64(latestHeight)
+	}
+
+	amount, err := readCtx.GetAccountAmount(address, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+	sequence, err := readCtx.GetAccountSequence(address, height)
+	if err != nil {
+		return ctx.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, Account{
+		Address:  query.Address,
+		Amount:   amount,
+		Sequence: int64(sequence),
+	})
+}
+
+func encodeHexSlice(bzs [][]byte) []string {
+	hexes := make([]string, len(bzs))
+	for i, bz := range bzs {
+		hexes[i] = hex.EncodeToString(bz)
+	}
+	return hexes
+}
+
 // Broadcast to the entire validator set
 func (s *rpcServer) broadcastMessage(msgBz []byte) error {
 	utilMsg := &typesUtil.TransactionGossipMessage{
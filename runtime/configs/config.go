@@ -9,6 +9,7 @@ type Config struct {
 
 	Consensus   *ConsensusConfig   `json:"consensus"`
 	Utility     *UtilityConfig     `json:"utility"`
+	Servicer    *ServicerConfig    `json:"servicer"`
 	Persistence *PersistenceConfig `json:"persistence"`
 	P2P         *P2PConfig         `json:"p2p"`
 	Telemetry   *TelemetryConfig   `json:"telemetry"`
@@ -30,6 +31,11 @@ func NewDefaultConfig(options ...func(*Config)) *Config {
 		Utility: &UtilityConfig{
 			MaxMempoolTransactionBytes: defaults.DefaultUtilityMaxMempoolTransactionBytes,
 			MaxMempoolTransactions:     defaults.DefaultUtilityMaxMempoolTransactions,
+			MempoolType:                defaults.DefaultUtilityMempoolType,
+			MempoolTransactionTtlMsec:  defaults.DefaultUtilityMempoolTransactionTTLMsec,
+		},
+		Servicer: &ServicerConfig{
+			TimeoutMsec: defaults.DefaultServicerTimeoutMsec,
 		},
 		Persistence: &PersistenceConfig{
 			PostgresUrl:    defaults.DefaultPersistencePostgresUrl,
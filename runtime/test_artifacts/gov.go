@@ -71,6 +71,11 @@ func DefaultParams() *genesis.Params {
 		MessagePauseServiceNodeFee:               types.BigIntToString(big.NewInt(10000)),
 		MessageUnpauseServiceNodeFee:             types.BigIntToString(big.NewInt(10000)),
 		MessageChangeParameterFee:                types.BigIntToString(big.NewInt(10000)),
+		MessageClaimFee:                          types.BigIntToString(big.NewInt(10000)),
+		MessageProofFee:                          types.BigIntToString(big.NewInt(10000)),
+		RelaysToTokensMultiplier:                 types.BigIntToString(big.NewInt(1000)),
+		ClaimExpirationBlocks:                    24,
+		FishermenPerSession:                      1,
 		AclOwner:                                 DefaultParamsOwner.Address().String(),
 		BlocksPerSessionOwner:                    DefaultParamsOwner.Address().String(),
 		AppMinimumStakeOwner:                     DefaultParamsOwner.Address().String(),
@@ -126,5 +131,10 @@ func DefaultParams() *genesis.Params {
 		MessagePauseServiceNodeFeeOwner:          DefaultParamsOwner.Address().String(),
 		MessageUnpauseServiceNodeFeeOwner:        DefaultParamsOwner.Address().String(),
 		MessageChangeParameterFeeOwner:           DefaultParamsOwner.Address().String(),
+		MessageClaimFeeOwner:                     DefaultParamsOwner.Address().String(),
+		MessageProofFeeOwner:                     DefaultParamsOwner.Address().String(),
+		RelaysToTokensMultiplierOwner:            DefaultParamsOwner.Address().String(),
+		ClaimExpirationBlocksOwner:               DefaultParamsOwner.Address().String(),
+		FishermenPerSessionOwner:                 DefaultParamsOwner.Address().String(),
 	}
 }
@@ -6,12 +6,14 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"testing"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
+	"github.com/pokt-network/pocket/runtime/configs"
 	"github.com/pokt-network/pocket/utility"
 )
 
@@ -22,8 +24,42 @@ const (
 	sqlSchema        = "test_schema"
 	dialect          = "postgres"
 	connStringFormat = "postgres://%s:%s@%s/%s?sslmode=disable"
+
+	// The SQL driver the tests run against, e.g. `TEST_SQL_DRIVER=sqlite make test_persistence` runs them without Docker
+	testSQLDriverEnvVar = "TEST_SQL_DRIVER"
+	sqliteDialect       = "sqlite"
 )
 
+// `SetupTestDatabase` sets up the SQL database backing the persistence module of the tests: an embedded SQLite
+// database if the `TEST_SQL_DRIVER` env var is `sqlite`, or a Postgres docker container otherwise. It returns the
+// persistence config fields pointing to it and the function to call once the tests are done to clean it up.
+func SetupTestDatabase() (databaseCfg *configs.PersistenceConfig, cleanup func()) {
+	if os.Getenv(testSQLDriverEnvVar) != sqliteDialect {
+		pool, resource, databaseUrl := SetupPostgresDocker()
+		return &configs.PersistenceConfig{
+			SqlDriver:   dialect,
+			PostgresUrl: databaseUrl,
+		}, func() {
+			CleanupPostgresDocker(nil, pool, resource)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "pocket_test_db")
+	if err != nil {
+		log.Fatalf("could not create the sqlite database directory: %s", err)
+	}
+	databasePath := filepath.Join(dir, "test.db")
+	log.Println("Using the sqlite database at: ", databasePath)
+	return &configs.PersistenceConfig{
+		SqlDriver:  sqliteDialect,
+		SqlitePath: databasePath,
+	}, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Fatalf("could not remove the sqlite database: %s", err)
+		}
+	}
+}
+
 // DISCUSS(team) both the persistence module and the utility module share this code which is less than ideal
 //
 //	(see call to action in generator.go to try to remove the cross module testing code)
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/celestiaorg/smt"
+)
+
+const (
+	// The number of state trees whose roots make up the state hash; see `numMerkleTrees` in `persistence/state.go`
+	NumStateTrees = 9
+	// The length of the root of a state tree, i.e. of a SHA256 digest
+	StateTreeRootLen = sha256.Size
+)
+
+// `GetStateHash` returns the state hash committed to by the roots of the state trees, in the order they make up the state hash
+func GetStateHash(treeRoots [][]byte) string {
+	stateHash := sha256.Sum256(bytes.Join(treeRoots, []byte{}))
+	return hex.EncodeToString(stateHash[:])
+}
+
+// `IsInclusion` returns whether the proof attests to the inclusion of its key rather than its exclusion
+func (proof *StateProof) IsInclusion() bool {
+	return len(proof.Value) > 0
+}
+
+// `VerifyStateProof` verifies a state proof using only the header of the block it was generated at: the roots of the
+// state trees must hash to the state hash of the block, and the proof must be valid for the root of its tree.
+func VerifyStateProof(proof *StateProof, blockHeader *BlockHeader) error {
+	if proof == nil || blockHeader == nil {
+		return fmt.Errorf("a state proof and a block header are required")
+	}
+	if proof.Height < 0 || uint64(proof.Height) != blockHeader.Height {
+		return fmt.Errorf("the state proof is at height %d but the block header is at height %d", proof.Height, blockHeader.Height)
+	}
+	// The roots are concatenated to hash them, so their number and lengths must be fixed for the root of a tree
+	// not to be passed off as a part of another one
+	if len(proof.TreeRoots) != NumStateTrees {
+		return fmt.Errorf("the state proof has %d tree roots, expected %d", len(proof.TreeRoots), NumStateTrees)
+	}
+	for i, root := range proof.TreeRoots {
+		if len(root) != StateTreeRootLen {
+			return fmt.Errorf("the root of tree %d of the state proof is %d bytes long, expected %d", i, len(root), StateTreeRootLen)
+		}
+	}
+	if int(proof.TreeIndex) >= len(proof.TreeRoots) {
+		return fmt.Errorf("the state proof has no root for tree %d", proof.TreeIndex)
+	}
+	if stateHash := GetStateHash(proof.TreeRoots); stateHash != blockHeader.StateHash {
+		return fmt.Errorf("the tree roots of the state proof hash to %s rather than the state hash of the block: %s", stateHash, blockHeader.StateHash)
+	}
+
+	smtProof := smt.SparseMerkleProof{
+		SideNodes:             proof.SideNodes,
+		NonMembershipLeafData: proof.NonMembershipLeafData,
+	}
+	if !smt.VerifyProof(smtProof, proof.TreeRoots[proof.TreeIndex], proof.Key, proof.Value, sha256.New()) {
+		return fmt.Errorf("the state proof of key %x is invalid for the root of tree %d", proof.Key, proof.TreeIndex)
+	}
+	return nil
+}
@@ -6,14 +6,18 @@ import (
 )
 
 const (
-	InvalidAddressLenError        = "the address length is not valid"
-	InvalidHashLenError           = "the hash length is not valid"
-	CreateAddressError            = "an error occurred creating the address"
-	InvalidPrivateKeyLenError     = "the private key length is not valid"
-	InvalidPrivateKeySeedLenError = "the seed is too short to create a private key"
-	CreatePrivateKeyError         = "an error occurred creating the private key"
-	InvalidPublicKeyLenError      = "the public key length is not valid"
-	CreatePublicKeyError          = "an error occurred creating the public key"
+	InvalidAddressLenError           = "the address length is not valid"
+	InvalidHashLenError              = "the hash length is not valid"
+	CreateAddressError               = "an error occurred creating the address"
+	InvalidPrivateKeyLenError        = "the private key length is not valid"
+	InvalidPrivateKeySeedLenError    = "the seed is too short to create a private key"
+	CreatePrivateKeyError            = "an error occurred creating the private key"
+	InvalidPublicKeyLenError         = "the public key length is not valid"
+	CreatePublicKeyError             = "an error occurred creating the public key"
+	InvalidMultisigThresholdError    = "the threshold of the multisig public key is not valid"
+	DuplicateMultisigPublicKeyError  = "the member public keys of the multisig public key are not unique"
+	InvalidMultisigPublicKeyLenError = "the multisig public key length is not valid"
+	UnsortedMultisigPublicKeysError  = "the member public keys of the multisig public key are not sorted"
 )
 
 func ErrInvalidAddressLen(len int) error {
@@ -47,3 +51,19 @@ func ErrInvalidPublicKeyLen(len int) error {
 func ErrCreatePublicKey(err error) error {
 	return fmt.Errorf("%s; %s", CreatePublicKeyError, err.Error())
 }
+
+func ErrInvalidMultisigThreshold(threshold, numPublicKeys int) error {
+	return fmt.Errorf("%s, expected between 1 and %d, actual %d", InvalidMultisigThresholdError, numPublicKeys, threshold)
+}
+
+func ErrDuplicateMultisigPublicKey(publicKey string) error {
+	return fmt.Errorf("%s: %s", DuplicateMultisigPublicKeyError, publicKey)
+}
+
+func ErrInvalidMultisigPublicKeyLen(len int) error {
+	return fmt.Errorf("%s, expected the length of a threshold and of at least one public key, actual length: %d", InvalidMultisigPublicKeyLenError, len)
+}
+
+func ErrUnsortedMultisigPublicKeys() error {
+	return fmt.Errorf("%s", UnsortedMultisigPublicKeysError)
+}
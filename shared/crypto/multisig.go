@@ -0,0 +1,136 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+)
+
+// The threshold is encoded on 4 bytes ahead of the member public keys, so the encoding of a multisig public key is never
+// the size of an Ed25519 public key and the address of a multisig account cannot collide with the one of a single key
+const multisigThresholdLen = 4
+
+// `MultisigPublicKey` is the public key of an M-of-N multisig account, whose transactions must be signed by at least
+// `threshold` of its member public keys. The members are sorted by bytes, so the multisig public key, and therefore the
+// address of the account, derive from the set of members and the threshold regardless of the order they are listed in.
+type MultisigPublicKey struct {
+	threshold  int
+	publicKeys []PublicKey
+}
+
+func NewMultisigPublicKey(threshold int, publicKeys []PublicKey) (*MultisigPublicKey, error) {
+	if threshold <= 0 || threshold > len(publicKeys) {
+		return nil, ErrInvalidMultisigThreshold(threshold, len(publicKeys))
+	}
+	sortedPublicKeys := make([]PublicKey, len(publicKeys))
+	copy(sortedPublicKeys, publicKeys)
+	sort.Slice(sortedPublicKeys, func(i, j int) bool {
+		return bytes.Compare(sortedPublicKeys[i].Bytes(), sortedPublicKeys[j].Bytes()) < 0
+	})
+	for i := 1; i < len(sortedPublicKeys); i++ {
+		if bytes.Equal(sortedPublicKeys[i-1].Bytes(), sortedPublicKeys[i].Bytes()) {
+			return nil, ErrDuplicateMultisigPublicKey(sortedPublicKeys[i].String())
+		}
+	}
+	return &MultisigPublicKey{
+		threshold:  threshold,
+		publicKeys: sortedPublicKeys,
+	}, nil
+}
+
+func NewMultisigPublicKeyFromString(hexString string) (*MultisigPublicKey, error) {
+	bz, err := hex.DecodeString(hexString)
+	if err != nil {
+		return nil, ErrCreatePublicKey(err)
+	}
+	return NewMultisigPublicKeyFromBytes(bz)
+}
+
+func NewMultisigPublicKeyFromBytes(bz []byte) (*MultisigPublicKey, error) {
+	bzLen := len(bz)
+	if bzLen <= multisigThresholdLen || (bzLen-multisigThresholdLen)%PublicKeyLen != 0 {
+		return nil, ErrInvalidMultisigPublicKeyLen(bzLen)
+	}
+	threshold := int(binary.BigEndian.Uint32(bz[:multisigThresholdLen]))
+	publicKeys := make([]PublicKey, 0, (bzLen-multisigThresholdLen)/PublicKeyLen)
+	for i := multisigThresholdLen; i < bzLen; i += PublicKeyLen {
+		publicKey, err := NewPublicKeyFromBytes(bz[i : i+PublicKeyLen])
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	multisigPublicKey, err := NewMultisigPublicKey(threshold, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	// The members must already be sorted for the encoding of a multisig public key to be unique
+	if !bytes.Equal(multisigPublicKey.Bytes(), bz) {
+		return nil, ErrUnsortedMultisigPublicKeys()
+	}
+	return multisigPublicKey, nil
+}
+
+func (m *MultisigPublicKey) Bytes() []byte {
+	bz := make([]byte, multisigThresholdLen, multisigThresholdLen+len(m.publicKeys)*PublicKeyLen)
+	binary.BigEndian.PutUint32(bz, uint32(m.threshold))
+	for _, publicKey := range m.publicKeys {
+		bz = append(bz, publicKey.Bytes()...)
+	}
+	return bz
+}
+
+func (m *MultisigPublicKey) String() string {
+	return hex.EncodeToString(m.Bytes())
+}
+
+func (m *MultisigPublicKey) Address() Address {
+	hash := sha256.Sum256(m.Bytes())
+	return hash[:AddressLen]
+}
+
+func (m *MultisigPublicKey) Equals(other *MultisigPublicKey) bool {
+	return bytes.Equal(m.Bytes(), other.Bytes())
+}
+
+func (m *MultisigPublicKey) Threshold() int {
+	return m.threshold
+}
+
+func (m *MultisigPublicKey) PublicKeys() []PublicKey {
+	return m.publicKeys
+}
+
+// `IsMember` returns whether `publicKey` is one of the member public keys
+func (m *MultisigPublicKey) IsMember(publicKey []byte) bool {
+	for _, member := range m.publicKeys {
+		if bytes.Equal(member.Bytes(), publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// `Verify` returns whether `signatures[i]` is a valid signature of `msg` by the member `publicKeys[i]` for every i, and
+// the signatures are of at least `threshold` distinct members
+func (m *MultisigPublicKey) Verify(msg []byte, publicKeys, signatures [][]byte) bool {
+	if len(publicKeys) != len(signatures) || len(publicKeys) < m.threshold {
+		return false
+	}
+	signers := make(map[string]struct{}, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		if !m.IsMember(publicKey) {
+			return false
+		}
+		if _, ok := signers[string(publicKey)]; ok {
+			return false
+		}
+		signers[string(publicKey)] = struct{}{}
+		if !Ed25519PublicKey(publicKey).Verify(msg, signatures[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigPublicKey_Address(t *testing.T) {
+	privateKeys := newTestingPrivateKeys(t, 3)
+	publicKeys := []PublicKey{privateKeys[0].PublicKey(), privateKeys[1].PublicKey(), privateKeys[2].PublicKey()}
+	multisigPublicKey, err := NewMultisigPublicKey(2, publicKeys)
+	require.NoError(t, err)
+	require.Len(t, multisigPublicKey.Address(), AddressLen)
+
+	// The address derives from the set of members regardless of their order
+	reversedPublicKeys := []PublicKey{publicKeys[2], publicKeys[1], publicKeys[0]}
+	reversedMultisigPublicKey, err := NewMultisigPublicKey(2, reversedPublicKeys)
+	require.NoError(t, err)
+	require.Equal(t, multisigPublicKey.Address(), reversedMultisigPublicKey.Address())
+
+	// but not from the threshold
+	otherThresholdMultisigPublicKey, err := NewMultisigPublicKey(3, publicKeys)
+	require.NoError(t, err)
+	require.NotEqual(t, multisigPublicKey.Address(), otherThresholdMultisigPublicKey.Address())
+
+	decodedMultisigPublicKey, err := NewMultisigPublicKeyFromBytes(multisigPublicKey.Bytes())
+	require.NoError(t, err)
+	require.True(t, multisigPublicKey.Equals(decodedMultisigPublicKey))
+	require.Equal(t, 2, decodedMultisigPublicKey.Threshold())
+}
+
+func TestMultisigPublicKey_Invalid(t *testing.T) {
+	privateKeys := newTestingPrivateKeys(t, 2)
+	publicKeys := []PublicKey{privateKeys[0].PublicKey(), privateKeys[1].PublicKey()}
+
+	_, err := NewMultisigPublicKey(0, publicKeys)
+	require.Error(t, err)
+	_, err = NewMultisigPublicKey(3, publicKeys)
+	require.Error(t, err)
+	_, err = NewMultisigPublicKey(1, []PublicKey{publicKeys[0], publicKeys[0]})
+	require.Error(t, err)
+
+	multisigPublicKey, err := NewMultisigPublicKey(1, publicKeys)
+	require.NoError(t, err)
+	bz := multisigPublicKey.Bytes()
+	_, err = NewMultisigPublicKeyFromBytes(bz[:len(bz)-1])
+	require.Error(t, err)
+	unsortedBz := append(append(bz[:multisigThresholdLen:multisigThresholdLen], bz[multisigThresholdLen+PublicKeyLen:]...), bz[multisigThresholdLen:multisigThresholdLen+PublicKeyLen]...)
+	_, err = NewMultisigPublicKeyFromBytes(unsortedBz)
+	require.Error(t, err)
+}
+
+func TestMultisigPublicKey_Verify(t *testing.T) {
+	privateKeys := newTestingPrivateKeys(t, 3)
+	publicKeys := []PublicKey{privateKeys[0].PublicKey(), privateKeys[1].PublicKey(), privateKeys[2].PublicKey()}
+	multisigPublicKey, err := NewMultisigPublicKey(2, publicKeys)
+	require.NoError(t, err)
+
+	msg := []byte("message")
+	signatures := make([][]byte, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		signatures[i], err = privateKey.Sign(msg)
+		require.NoError(t, err)
+	}
+
+	require.True(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes(), publicKeys[2].Bytes()}, [][]byte{signatures[0], signatures[2]}))
+	require.True(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes(), publicKeys[1].Bytes(), publicKeys[2].Bytes()}, signatures))
+	// Below the threshold
+	require.False(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes()}, [][]byte{signatures[0]}))
+	// The same member twice
+	require.False(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes(), publicKeys[0].Bytes()}, [][]byte{signatures[0], signatures[0]}))
+	// An invalid signature
+	require.False(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes(), publicKeys[1].Bytes()}, [][]byte{signatures[0], signatures[2]}))
+	// A signature of a non member
+	outsider := newTestingPrivateKeys(t, 1)[0]
+	outsiderSignature, err := outsider.Sign(msg)
+	require.NoError(t, err)
+	require.False(t, multisigPublicKey.Verify(msg, [][]byte{publicKeys[0].Bytes(), outsider.PublicKey().Bytes()}, [][]byte{signatures[0], outsiderSignature}))
+}
+
+func newTestingPrivateKeys(t *testing.T, numKeys int) []PrivateKey {
+	privateKeys := make([]PrivateKey, numKeys)
+	for i := range privateKeys {
+		privateKey, err := GeneratePrivateKey()
+		require.NoError(t, err)
+		privateKeys[i] = privateKey
+	}
+	return privateKeys
+}
@@ -21,10 +21,30 @@ type PersistenceModule interface {
 
 	// BlockStore operations
 	GetBlockStore() kvstore.KVStore
+	GetBlock(height int64) (*coreTypes.Block, error)
 	NewWriteContext() PersistenceRWContext
 
+	// RelayStore operations
+	GetRelayStore() kvstore.RelayStore
+
 	// Indexer Queries
 	TransactionExists(transactionHash string) (bool, error)
+	// Rebuilds the indexer from the block store, resuming from the checkpoint of an interrupted rebuild unless `restart` is set
+	RebuildTxIndexer(restart bool, progress func(height, toHeight int64, numTxs int)) error
+
+	// Event Queries
+	GetEventsByHeight(height int64) ([]*coreTypes.StateChangeEvent, error)
+	GetEventsByAddress(address []byte) ([]*coreTypes.StateChangeEvent, error) // The events where `address` is the address or the counterparty
+
+	// Snapshot operations; see `persistence/snapshot.go` for the format of a snapshot
+	ExportSnapshot(height int64, dir string) error
+	ImportSnapshot(dir, stateHash string) error // Replaces the state of the node with a snapshot at the trusted `stateHash`
+
+	// Exports the state at `height` as a genesis state, e.g. to start a forked network from it
+	ExportGenesis(height int64) (*genesis.GenesisState, error)
+
+	// State proofs; see `VerifyStateProof` in `shared/core/types` to verify them against a block header
+	GetStateProof(treeName string, key []byte, height int64) (*coreTypes.StateProof, error)
 
 	// Debugging / development only
 	HandleDebugMessage(*messaging.DebugMessage) error
@@ -63,8 +83,9 @@ type PersistenceWriteContext interface {
 	// Indexer Operations
 
 	// Block Operations
-	ComputeStateHash() (string, error)        // Update the merkle trees, computes the new state hash, and returns it
-	IndexTransaction(txResult TxResult) error // TODO(#361): Look into an approach to remove `TxResult` from shared interfaces
+	ComputeStateHash() (string, error)                 // Update the merkle trees, computes the new state hash, and returns it
+	IndexTransaction(txResult TxResult) error          // TODO(#361): Look into an approach to remove `TxResult` from shared interfaces
+	EmitEvent(event *coreTypes.StateChangeEvent) error // Stores a state change of the block on commit; its height and index are set by the context
 
 	// Pool Operations
 	AddPoolAmount(name string, amount string) error
@@ -76,6 +97,7 @@ type PersistenceWriteContext interface {
 	AddAccountAmount(address []byte, amount string) error
 	SubtractAccountAmount(address []byte, amount string) error
 	SetAccountAmount(address []byte, amount string) error // NOTE: same as (insert)
+	SetAccountSequence(address []byte, sequence uint64) error
 
 	// App Operations
 	InsertApp(address []byte, publicKey []byte, output []byte, paused bool, status int32, maxRelays string, stakedTokens string, chains []string, pausedHeight int64, unstakingHeight int64) error
@@ -111,6 +133,15 @@ type PersistenceWriteContext interface {
 	SetValidatorPauseHeightAndMissedBlocks(address []byte, pauseHeight int64, missedBlocks int) error
 	SetValidatorMissedBlocks(address []byte, missedBlocks int) error
 
+	// Claim Operations
+	InsertClaim(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain, geoZone string, merkleRoot []byte) error
+	SetClaimProvenHeight(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) error
+
+	// Test Score Operations
+	InsertTestScore(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain, geoZone string, samplesRoot []byte, numNullSamples uint64) error
+	SetTestScoreProvenHeight(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, provenHeight int64) error
+	SetTestScoreSettledHeight(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, settledHeight int64) error
+
 	// Param Operations
 	InitGenesisParams(params *genesis.Params) error
 	SetParam(paramName string, value any) error
@@ -140,10 +171,13 @@ type PersistenceReadContext interface {
 
 	// Returns "0" if the account does not exist
 	GetAccountAmount(address []byte, height int64) (string, error)
+	// Returns 0 if the account does not exist
+	GetAccountSequence(address []byte, height int64) (uint64, error)
 	GetAllAccounts(height int64) ([]*coreTypes.Account, error)
 
 	// App Queries
 	GetAllApps(height int64) ([]*coreTypes.Actor, error)
+	GetApp(address []byte, height int64) (operator, publicKey, stakedTokens, maxRelays, outputAddress string, pauseHeight, unstakingHeight int64, chains []string, err error)
 	GetAppExists(address []byte, height int64) (exists bool, err error)
 	GetAppStakeAmount(height int64, address []byte) (string, error)
 	GetAppsReadyToUnstake(height int64, status int32) (apps []IUnstakingActor, err error)
@@ -183,6 +217,14 @@ type PersistenceReadContext interface {
 	// Actors Queries
 	GetAllStakedActors(height int64) ([]*coreTypes.Actor, error)
 
+	// Claim Queries
+	GetClaim(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (geoZone string, merkleRoot []byte, claimHeight, provenHeight int64, err error)
+	GetClaimExists(servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (exists bool, err error)
+
+	// Test Score Queries
+	GetTestScore(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (geoZone string, samplesRoot []byte, numNullSamples uint64, scoreHeight, provenHeight, settledHeight int64, err error)
+	GetTestScoreExists(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string, height int64) (exists bool, err error)
+
 	// Params
 	GetIntParam(paramName string, height int64) (int, error)
 	GetStringParam(paramName string, height int64) (string, error)
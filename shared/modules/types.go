@@ -17,8 +17,10 @@ type TxResult interface {
 	GetResultCode() int32                 // 0 is no error, otherwise corresponds to error object code; // IMPROVE: Add a specific type fot he result code
 	GetError() string                     // can be empty; IMPROVE: Add a specific type fot he error code
 	GetSignerAddr() string                // get the address of who signed (i.e. sent) the transaction
-	GetRecipientAddr() string             // get the address of who received the transaction; may be empty
-	GetMessageType() string               // corresponds to type of message (validator-stake, app-unjail, node-stake, etc) // IMPROVE: Add an enum for message types
+	GetRecipientAddr() string             // get the address of who received the first message of the transaction; may be empty
+	GetMessageType() string               // corresponds to type of the first message (validator-stake, app-unjail, node-stake, etc) // IMPROVE: Add an enum for message types
+	GetRecipientAddrs() []string          // get the addresses of who received every message of the transaction; may contain empty addresses
+	GetMessageTypes() []string            // get the types of every message of the transaction
 	Hash() ([]byte, error)                // the hash of the tx bytes
 	HashFromBytes([]byte) ([]byte, error) // same operation as `Hash`, but avoid re-serializing the tx
 	Bytes() ([]byte, error)               // returns the serialized transaction bytes
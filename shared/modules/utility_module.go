@@ -18,6 +18,10 @@ type UtilityModule interface {
 
 	// Basic Transaction validation. SIDE EFFECT: Adds the transaction to the mempool if valid.
 	CheckTransaction(tx []byte) error
+
+	// Returns the minimum fee of the message of the transaction, and the fee it should pay to be included in the
+	// next blocks given the transactions in the mempool and in the recent blocks
+	EstimateFee(tx []byte) (minimumFee, fee string, err error)
 }
 
 // Interface defining the context within which the node can operate with the utility layer.
@@ -108,3 +108,24 @@ func (u *UtilityContext) SubtractAccountAmount(address []byte, amountToSubtract
 	}
 	return nil
 }
+
+// The sequence of an account is the sequence of the next transaction it signs
+func (u *UtilityContext) GetAccountSequence(address []byte) (uint64, types.Error) {
+	store, height, er := u.GetStoreAndHeight()
+	if er != nil {
+		return 0, er
+	}
+	sequence, err := store.GetAccountSequence(address, height)
+	if err != nil {
+		return 0, types.ErrGetAccountSequence(err)
+	}
+	return sequence, nil
+}
+
+func (u *UtilityContext) SetAccountSequence(address []byte, sequence uint64) types.Error {
+	store := u.Store()
+	if err := store.SetAccountSequence(address, sequence); err != nil {
+		return types.ErrSetAccountSequence(err)
+	}
+	return nil
+}
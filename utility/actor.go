@@ -1,6 +1,7 @@
 package utility
 
 import (
+	"encoding/hex"
 	"math"
 	"math/big"
 
@@ -67,7 +68,11 @@ func (u *UtilityContext) SetActorUnstaking(actorType coreTypes.ActorType, unstak
 		return typesUtil.ErrSetUnstakingHeightAndStatus(er)
 	}
 
-	return nil
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:      coreTypes.EventType_EVENT_TYPE_UNSTAKE_BEGIN,
+		ActorType: actorType,
+		Address:   hex.EncodeToString(address),
+	})
 }
 
 func (u *UtilityContext) SetActorPauseHeight(actorType coreTypes.ActorType, address []byte, height int64) typesUtil.Error {
@@ -91,7 +96,15 @@ func (u *UtilityContext) SetActorPauseHeight(actorType coreTypes.ActorType, addr
 		return typesUtil.ErrSetPauseHeight(err)
 	}
 
-	return nil
+	eventType := coreTypes.EventType_EVENT_TYPE_PAUSE
+	if height == typesUtil.HeightNotUsed {
+		eventType = coreTypes.EventType_EVENT_TYPE_UNPAUSE
+	}
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:      eventType,
+		ActorType: actorType,
+		Address:   hex.EncodeToString(address),
+	})
 }
 
 // getters
@@ -425,13 +438,23 @@ func (u *UtilityContext) BurnActor(actorType coreTypes.ActorType, percentage int
 	}
 	newTokensAfterBurn := big.NewInt(0).Sub(tokens, truncatedTokens)
 	// remove from pool
-	if err := u.SubPoolAmount(coreTypes.Pools_POOLS_VALIDATOR_STAKE.FriendlyName(), typesUtil.BigIntToString(truncatedTokens)); err != nil {
+	poolName := coreTypes.Pools_POOLS_VALIDATOR_STAKE.FriendlyName()
+	if err := u.SubPoolAmount(poolName, typesUtil.BigIntToString(truncatedTokens)); err != nil {
 		return err
 	}
 	// remove from actor
 	if err := u.SetActorStakedTokens(actorType, newTokensAfterBurn, address); err != nil {
 		return err
 	}
+	if err := u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:      coreTypes.EventType_EVENT_TYPE_BURN,
+		ActorType: actorType,
+		Address:   hex.EncodeToString(address),
+		Pool:      poolName,
+		Amount:    typesUtil.BigIntToString(truncatedTokens),
+	}); err != nil {
+		return err
+	}
 	// check to see if they fell below minimum stake
 	minStake, err := u.GetValidatorMinimumStake()
 	if err != nil {
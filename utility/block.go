@@ -1,10 +1,12 @@
 package utility
 
 import (
+	"encoding/hex"
 	"log"
 	"math/big"
 
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/modules"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
@@ -17,11 +19,10 @@ import (
 	Pocket Network adopt a Tendermint-like lifecycle of BeginBlock -> DeliverTx -> EndBlock in that
 	order. Like the name suggests, BeginBlock is an autonomous state operation that executes at the
 	beginning of every block DeliverTx individually applies each transaction against the state and
-	rolls it back (not yet implemented) if fails. Like BeginBlock, EndBlock is an autonomous state
+	rolls it back to the save point created before applying it if it is invalid. Like BeginBlock, EndBlock is an autonomous state
 	operation that executes at the end of every block.
 */
 
-// TODO: Make sure to call `utility.CheckTransaction`, which calls `persistence.TransactionExists`
 func (u *UtilityContext) CreateAndApplyProposalBlock(proposer []byte, maxTransactionBytes int) (string, [][]byte, error) {
 	lastBlockByzantineVals, err := u.GetLastBlockByzantineValidators()
 	if err != nil {
@@ -54,9 +55,19 @@ func (u *UtilityContext) CreateAndApplyProposalBlock(proposer []byte, maxTransac
 			totalTxsSizeInBytes -= txTxsSizeInBytes
 			break // we've reached our max
 		}
+		// Each transaction is applied on top of its own save point so a transaction failing the ante handler only
+		// reverts its own changes rather than the work of the entire block. A transaction whose messages fail is
+		// still part of the block: its messages are reverted but its fee and sequence are charged.
+		if err := u.NewSavePoint(crypto.SHA3Hash(txBytes)); err != nil {
+			if err.Code() == typesUtil.CodeDuplicateSavePointError {
+				// The same transaction was already included in this block
+				totalTxsSizeInBytes -= txTxsSizeInBytes
+				continue
+			}
+			return "", nil, err
+		}
 		txResult, err := u.ApplyTransaction(txIndex, transaction)
 		if err != nil {
-			// TODO(#327): Properly implement 'unhappy path' for save points
 			if err := u.RevertLastSavePoint(); err != nil {
 				return "", nil, err
 			}
@@ -84,7 +95,6 @@ func (u *UtilityContext) CreateAndApplyProposalBlock(proposer []byte, maxTransac
 	return stateHash, transactions, err
 }
 
-// TODO: Make sure to call `utility.CheckTransaction`, which calls `persistence.TransactionExists`
 // CLEANUP: code re-use ApplyBlock() for CreateAndApplyBlock()
 func (u *UtilityContext) ApplyBlock() (string, error) {
 	lastByzantineValidators, err := u.GetLastBlockByzantineValidators()
@@ -119,12 +129,6 @@ func (u *UtilityContext) ApplyBlock() (string, error) {
 		if err := u.Context.IndexTransaction(txResult); err != nil {
 			log.Fatalf("TODO(#327): We can apply the transaction but not index it. Crash the process for now: %v\n", err)
 		}
-
-		// TODO: if found, remove transaction from mempool.
-		// DISCUSS: What if the context is rolled back or cancelled. Do we add it back to the mempool?
-		// if err := u.Mempool.RemoveTransaction(transaction); err != nil {
-		// 	return nil, err
-		// }
 	}
 
 	// end block lifecycle phase
@@ -144,6 +148,7 @@ func (u *UtilityContext) ApplyBlock() (string, error) {
 }
 
 func (u *UtilityContext) BeginBlock(previousBlockByzantineValidators [][]byte) typesUtil.Error {
+	u.blockPhase, u.txHash = coreTypes.BlockPhase_BLOCK_PHASE_BEGIN_BLOCK, ""
 	if err := u.HandleByzantineValidators(previousBlockByzantineValidators); err != nil {
 		return err
 	}
@@ -151,6 +156,7 @@ func (u *UtilityContext) BeginBlock(previousBlockByzantineValidators [][]byte) t
 }
 
 func (u *UtilityContext) EndBlock(proposer []byte) typesUtil.Error {
+	u.blockPhase, u.txHash = coreTypes.BlockPhase_BLOCK_PHASE_END_BLOCK, ""
 	// reward the block proposer
 	if err := u.HandleProposalRewards(proposer); err != nil {
 		return err
@@ -241,6 +247,16 @@ func (u *UtilityContext) UnstakeActorsThatAreReady() (err typesUtil.Error) {
 			if err = u.AddAccountAmountString(actor.GetOutputAddress(), actor.GetStakeAmount()); err != nil {
 				return err
 			}
+			if err = u.emitEvent(&coreTypes.StateChangeEvent{
+				Type:         coreTypes.EventType_EVENT_TYPE_UNSTAKE,
+				ActorType:    actorType,
+				Address:      hex.EncodeToString(actor.GetAddress()),
+				Counterparty: hex.EncodeToString(actor.GetOutputAddress()),
+				Pool:         poolName,
+				Amount:       actor.GetStakeAmount(),
+			}); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -312,18 +328,31 @@ func (u *UtilityContext) HandleProposalRewards(proposer []byte) typesUtil.Error
 	if daoCutPercentage < 0 || daoCutPercentage > 100 {
 		return typesUtil.ErrInvalidProposerCutPercentage()
 	}
-	amountToProposerFloat := new(big.Float).SetInt(feesAndRewardsCollected)
-	amountToProposerFloat.Mul(amountToProposerFloat, big.NewFloat(float64(proposerCutPercentage)))
-	amountToProposerFloat.Quo(amountToProposerFloat, big.NewFloat(100))
-	amountToProposer, _ := amountToProposerFloat.Int(nil)
-	amountToDAO := feesAndRewardsCollected.Sub(feesAndRewardsCollected, amountToProposer)
+	// The share of the proposer is rounded down with integer arithmetic so that no fee is lost to the precision of
+	// a float, however high the fees paid by the transactions
+	amountToProposer := new(big.Int).Mul(feesAndRewardsCollected, big.NewInt(int64(proposerCutPercentage)))
+	amountToProposer.Quo(amountToProposer, big.NewInt(100))
+	amountToDAO := new(big.Int).Sub(feesAndRewardsCollected, amountToProposer)
 	if err = u.AddAccountAmount(proposer, amountToProposer); err != nil {
 		return err
 	}
-	if err = u.AddPoolAmount(coreTypes.Pools_POOLS_DAO.FriendlyName(), amountToDAO); err != nil {
+	daoPoolName := coreTypes.Pools_POOLS_DAO.FriendlyName()
+	if err = u.AddPoolAmount(daoPoolName, amountToDAO); err != nil {
 		return err
 	}
-	return nil
+	if err = u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:    coreTypes.EventType_EVENT_TYPE_PROPOSER_REWARD,
+		Address: hex.EncodeToString(proposer),
+		Pool:    feePoolName,
+		Amount:  typesUtil.BigIntToString(amountToProposer),
+	}); err != nil {
+		return err
+	}
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:   coreTypes.EventType_EVENT_TYPE_DAO_REWARD,
+		Pool:   daoPoolName,
+		Amount: typesUtil.BigIntToString(amountToDAO),
+	})
 }
 
 // GetValidatorMissedBlocks gets the total blocks that a validator has not signed a certain window of time denominated by blocks
@@ -344,7 +373,11 @@ func (u *UtilityContext) PauseValidatorAndSetMissedBlocks(address []byte, pauseH
 	if err := store.SetValidatorPauseHeightAndMissedBlocks(address, pauseHeight, missedBlocks); err != nil {
 		return typesUtil.ErrSetPauseHeight(err)
 	}
-	return nil
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:      coreTypes.EventType_EVENT_TYPE_PAUSE,
+		ActorType: coreTypes.ActorType_ACTOR_TYPE_VAL,
+		Address:   hex.EncodeToString(address),
+	})
 }
 
 func (u *UtilityContext) SetValidatorMissedBlocks(address []byte, missedBlocks int) typesUtil.Error {
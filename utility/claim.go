@@ -0,0 +1,307 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/pokt-network/pocket/shared/converters"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+	"golang.org/x/exp/slices"
+)
+
+/*
+`claim.go` contains the on-chain side of the claim & proof volume reporting lifecycle:
+
+ 1. Once a session is over, a servicer submits a `MessageClaim` with the root of a Merkle sum tree built
+    over the relays it serviced for an application; the sum of the root is the number of relays claimed.
+ 2. The hash of the block the claim was committed in pseudo-randomly selects a leaf of that tree, which the
+    servicer must answer with a `MessageProof` in a later block.
+ 3. A valid proof rewards the servicer proportionally to the number of relays claimed.
+
+A session can only be claimed within `claim_expiration_blocks` of its end, and its claim proven within
+`claim_expiration_blocks` of the claim. Proven claims are kept as the record that their session was settled, so a
+session cannot be claimed, and therefore rewarded, more than once.
+*/
+
+func (u *UtilityContext) HandleMessageClaim(message *typesUtil.MessageClaim) typesUtil.Error {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return err
+	}
+	// ensure the session is over so the servicer can no longer add relays to the tree, and not expired
+	if err := u.validateSessionOver(message.SessionHeight, height, typesUtil.ErrInvalidClaim); err != nil {
+		return err
+	}
+	// ensure the session has not already been claimed, whether its claim was proven or not
+	exists, er := store.GetClaimExists(message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height)
+	if er != nil {
+		return typesUtil.ErrGetExists(er)
+	}
+	if exists {
+		return typesUtil.ErrClaimExists()
+	}
+	// ensure the servicer was part of the session and is not claiming more than it could have serviced
+	session, _, err := u.getServicerSession(message, message.GeoZone, typesUtil.ErrInvalidClaim)
+	if err != nil {
+		return err
+	}
+	maxRelaysPerServicer, err := getMaxRelaysPerServicer(session)
+	if err != nil {
+		return err
+	}
+	if numRelays := typesUtil.RelayTreeRootSum(message.MerkleRoot); numRelays > maxRelaysPerServicer {
+		return typesUtil.ErrOverServiced(int64(maxRelaysPerServicer))
+	}
+	if er := store.InsertClaim(message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, message.GeoZone, message.MerkleRoot); er != nil {
+		return typesUtil.ErrInsert(er)
+	}
+	return nil
+}
+
+func (u *UtilityContext) HandleMessageProof(message *typesUtil.MessageProof) typesUtil.Error {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return err
+	}
+	exists, er := store.GetClaimExists(message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height)
+	if er != nil {
+		return typesUtil.ErrGetExists(er)
+	}
+	if !exists {
+		return typesUtil.ErrClaimNotFound()
+	}
+	geoZone, merkleRoot, claimHeight, provenHeight, er := store.GetClaim(message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height)
+	if er != nil {
+		return typesUtil.ErrClaimNotFound()
+	}
+	if provenHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrInvalidProof("the claim is already proven")
+	}
+	// the leaf to prove is selected using the hash of the block the claim was committed in
+	if height <= claimHeight {
+		return typesUtil.ErrInvalidProof("the proof must be submitted after the claim is committed")
+	}
+	claimExpirationBlocks, err := u.GetClaimExpirationBlocks()
+	if err != nil {
+		return err
+	}
+	if height > claimHeight+claimExpirationBlocks {
+		return typesUtil.ErrInvalidProof("the claim has expired")
+	}
+	index, err := u.getRelayProofIndex(claimHeight, merkleRoot)
+	if err != nil {
+		return err
+	}
+	if message.LeafIndex != index {
+		return typesUtil.ErrInvalidProof(fmt.Sprintf("expected a proof of leaf %d, got %d", index, message.LeafIndex))
+	}
+	// ensure the relay proven was serviced by the servicer during the claimed session
+	if err := u.validateSessionRelay(message.Relay, message, geoZone, typesUtil.ErrInvalidProof); err != nil {
+		return err
+	}
+	relayBz, err := message.Relay.Bytes()
+	if err != nil {
+		return err
+	}
+	if !typesUtil.VerifyRelayProof(merkleRoot, message.LeafIndex, relayBz, message.SideNodes) {
+		return typesUtil.ErrInvalidProof("the merkle proof does not match the claimed root")
+	}
+	if er := store.SetClaimProvenHeight(message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height); er != nil {
+		return typesUtil.ErrSetClaimProvenHeight(er)
+	}
+	return u.HandleRelayRewards(message.ServicerAddress, typesUtil.RelayTreeRootSum(merkleRoot))
+}
+
+// HandleRelayRewards mints the rewards of a proven claim out of the DAO pool to the servicer's output address
+func (u *UtilityContext) HandleRelayRewards(servicerAddress []byte, numRelays uint64) typesUtil.Error {
+	relaysToTokensMultiplier, err := u.GetRelaysToTokensMultiplier()
+	if err != nil {
+		return err
+	}
+	reward := new(big.Int).Mul(new(big.Int).SetUint64(numRelays), relaysToTokensMultiplier)
+	daoPoolName := coreTypes.Pools_POOLS_DAO.FriendlyName()
+	daoPoolAmount, err := u.GetPoolAmount(daoPoolName)
+	if err != nil {
+		return err
+	}
+	if daoPoolAmount.Cmp(reward) < 0 {
+		return typesUtil.ErrInsufficientAmount(daoPoolName)
+	}
+	output, err := u.GetActorOutputAddress(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, servicerAddress)
+	if err != nil {
+		return err
+	}
+	if err := u.SubPoolAmount(daoPoolName, typesUtil.BigIntToString(reward)); err != nil {
+		return err
+	}
+	if err := u.AddAccountAmount(output, reward); err != nil {
+		return err
+	}
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:         coreTypes.EventType_EVENT_TYPE_RELAY_REWARD,
+		ActorType:    coreTypes.ActorType_ACTOR_TYPE_SERVICENODE,
+		Address:      hex.EncodeToString(servicerAddress),
+		Counterparty: hex.EncodeToString(output),
+		Pool:         daoPoolName,
+		Amount:       typesUtil.BigIntToString(reward),
+	})
+}
+
+func (u *UtilityContext) GetMessageClaimSignerCandidates(msg *typesUtil.MessageClaim) ([][]byte, typesUtil.Error) {
+	return u.getOperatorSignerCandidates(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, msg.ServicerAddress)
+}
+
+func (u *UtilityContext) GetMessageProofSignerCandidates(msg *typesUtil.MessageProof) ([][]byte, typesUtil.Error) {
+	return u.getOperatorSignerCandidates(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, msg.ServicerAddress)
+}
+
+// getOperatorSignerCandidates returns the output & operator addresses of the actor
+func (u *UtilityContext) getOperatorSignerCandidates(actorType coreTypes.ActorType, operator []byte) ([][]byte, typesUtil.Error) {
+	output, err := u.GetActorOutputAddress(actorType, operator)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([][]byte, 0)
+	candidates = append(candidates, output)
+	candidates = append(candidates, operator)
+	return candidates, nil
+}
+
+// validateSessionOver ensures `sessionHeight` is the first height of a session that ended before `height`, at
+// most `claim_expiration_blocks` before it
+func (u *UtilityContext) validateSessionOver(sessionHeight, height int64, invalid func(string) typesUtil.Error) typesUtil.Error {
+	blocksPerSession, err := u.getSessionBlocksPerSession(sessionHeight)
+	if err != nil {
+		return err
+	}
+	if GetSessionHeight(sessionHeight, blocksPerSession) != sessionHeight {
+		return invalid(fmt.Sprintf("%d is not a session height", sessionHeight))
+	}
+	sessionEndHeight := sessionHeight + blocksPerSession
+	if height < sessionEndHeight {
+		return invalid("the session is not over")
+	}
+	claimExpirationBlocks, err := u.GetClaimExpirationBlocks()
+	if err != nil {
+		return err
+	}
+	if height > sessionEndHeight+claimExpirationBlocks {
+		return invalid("the session has expired")
+	}
+	return nil
+}
+
+// getRelayProofIndex selects the leaf of the relay tree to prove using the hash of the block the tree's root
+// was committed in
+func (u *UtilityContext) getRelayProofIndex(commitHeight int64, root []byte) (uint64, typesUtil.Error) {
+	blockHash, er := u.Store().GetBlockHash(commitHeight)
+	if er != nil {
+		return 0, typesUtil.ErrGetBlockHash(er)
+	}
+	seed, er := hex.DecodeString(blockHash)
+	if er != nil {
+		return 0, typesUtil.ErrHexDecodeFromString(er)
+	}
+	return typesUtil.RelayProofIndex(seed, root), nil
+}
+
+// getServicerSession regenerates the session of a claim (or test score), ensures the servicer was dispatched
+// in it and returns it along with the session's application. `invalid` builds the error of the message type.
+func (u *UtilityContext) getServicerSession(msg typesUtil.MessageSessionRelays, geoZone string, invalid func(string) typesUtil.Error) (Session, *coreTypes.Actor, typesUtil.Error) {
+	store := u.Store()
+	appPublicKey, er := crypto.NewPublicKey(msg.GetApplicationPublicKey())
+	if er != nil {
+		return nil, nil, typesUtil.ErrNewPublicKeyFromBytes(er)
+	}
+	_, publicKey, stakedTokens, maxRelays, outputAddress, pauseHeight, unstakingHeight, chains, er := store.GetApp(appPublicKey.Address(), msg.GetSessionHeight())
+	if er != nil {
+		return nil, nil, typesUtil.ErrNotExists()
+	}
+	if !slices.Contains(chains, msg.GetRelayChain()) {
+		return nil, nil, invalid("the application is not staked for the relay chain")
+	}
+	application := &coreTypes.Actor{
+		ActorType:       coreTypes.ActorType_ACTOR_TYPE_APP,
+		Address:         appPublicKey.Address().String(),
+		PublicKey:       publicKey,
+		Chains:          chains,
+		GenericParam:    maxRelays,
+		StakedAmount:    stakedTokens,
+		PausedHeight:    pauseHeight,
+		UnstakingHeight: unstakingHeight,
+		Output:          outputAddress,
+	}
+	session, err := NewSession(store, msg.GetSessionHeight(), identifier(geoZone), identifier(msg.GetRelayChain()), application)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !containsActorAddress(session.GetServiceNodes(), msg.GetServicerAddress()) {
+		return nil, nil, typesUtil.ErrServicerNotInSession()
+	}
+	return session, application, nil
+}
+
+// getMaxRelaysPerServicer returns the maximum number of relays a servicer of the session could have serviced
+func getMaxRelaysPerServicer(session Session) (uint64, typesUtil.Error) {
+	appMaxRelays, er := converters.StringToBigInt(session.GetApplication().GetGenericParam())
+	if er != nil {
+		return 0, typesUtil.ErrStringToBigInt()
+	}
+	return new(big.Int).Div(appMaxRelays, big.NewInt(int64(len(session.GetServiceNodes())))).Uint64(), nil
+}
+
+func containsActorAddress(actors []*coreTypes.Actor, address []byte) bool {
+	addressHex := hex.EncodeToString(address)
+	return slices.IndexFunc(actors, func(actor *coreTypes.Actor) bool {
+		return actor.GetAddress() == addressHex
+	}) != -1
+}
+
+// validateSessionRelay ensures the relay of a proof (or proven test score sample) was signed by a client of the
+// application and addressed to the servicer during the session of the message
+func (u *UtilityContext) validateSessionRelay(relay *typesUtil.Relay, msg typesUtil.MessageSessionRelays, geoZone string, invalid func(string) typesUtil.Error) typesUtil.Error {
+	meta := relay.GetMeta()
+	if meta == nil {
+		return invalid("missing relay metadata")
+	}
+	if meta.GetToken().GetApplicationPublicKey() != msg.GetApplicationPublicKey() {
+		return invalid("the relay is for a different application")
+	}
+	if meta.GetRelayChain() != msg.GetRelayChain() || meta.GetGeoZone() != geoZone {
+		return invalid("the relay is for a different relay chain or geo zone")
+	}
+	blocksPerSession, err := u.getSessionBlocksPerSession(msg.GetSessionHeight())
+	if err != nil {
+		return err
+	}
+	if GetSessionHeight(meta.GetBlockHeight(), blocksPerSession) != msg.GetSessionHeight() {
+		return invalid("the relay is for a different session")
+	}
+	servicerPublicKey, er := crypto.NewPublicKey(meta.GetServicerPublicKey())
+	if er != nil {
+		return typesUtil.ErrNewPublicKeyFromBytes(er)
+	}
+	if !bytes.Equal(servicerPublicKey.Address(), msg.GetServicerAddress()) {
+		return invalid("the relay is addressed to a different servicer")
+	}
+	return relay.ValidateSignatures()
+}
+
+func (u *UtilityContext) getSessionBlocksPerSession(sessionHeight int64) (int64, typesUtil.Error) {
+	blocksPerSession, er := u.Store().GetIntParam(typesUtil.BlocksPerSessionParamName, sessionHeight)
+	if er != nil {
+		return 0, typesUtil.ErrGetParam(typesUtil.BlocksPerSessionParamName, er)
+	}
+	return int64(blocksPerSession), nil
+}
+
+// identifier is a string based `Identifier` for the relay chains & geo zones of claimed (or tested) sessions
+type identifier string
+
+func (i identifier) Name() string  { return string(i) }
+func (i identifier) ID() string    { return string(i) }
+func (i identifier) Bytes() []byte { return []byte(i) }
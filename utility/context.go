@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/modules"
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 )
@@ -20,6 +21,11 @@ type UtilityContext struct {
 	proposalProposerAddr []byte
 	proposalStateHash    string
 	proposalBlockTxs     [][]byte
+
+	// The lifecycle phase of the block being applied and the hash of the transaction being delivered, if any,
+	// which the events emitted are tagged with
+	blockPhase coreTypes.BlockPhase
+	txHash     string
 }
 
 // IMPROVE: Consider renaming to `persistenceContext` or `storeContext`?
@@ -67,6 +73,12 @@ func (u *UtilityContext) Commit(quorumCert []byte) error {
 		return err
 	}
 	u.Context = nil
+	// The transactions committed in the block, proposed by this node or not, can no longer be reaped
+	for _, txBz := range u.proposalBlockTxs {
+		if err := u.Mempool.RemoveTransaction(txBz); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -117,13 +129,13 @@ func (u *UtilityContext) RevertLastSavePoint() typesUtil.Error {
 }
 
 func (u *UtilityContext) NewSavePoint(transactionHash []byte) typesUtil.Error {
-	if err := u.Context.PersistenceRWContext.NewSavePoint(transactionHash); err != nil {
-		return typesUtil.ErrNewSavePoint(err)
-	}
 	txHash := hex.EncodeToString(transactionHash)
 	if _, exists := u.Context.SavePointsM[txHash]; exists {
 		return typesUtil.ErrDuplicateSavePoint()
 	}
+	if err := u.Context.PersistenceRWContext.NewSavePoint(transactionHash); err != nil {
+		return typesUtil.ErrNewSavePoint(err)
+	}
 	u.Context.SavePoints = append(u.Context.SavePoints, transactionHash)
 	u.Context.SavePointsM[txHash] = struct{}{}
 	return nil
@@ -0,0 +1,23 @@
+package utility
+
+import (
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+// 'Events' record the changes of the state made while applying a block (e.g. the amounts moved between the accounts
+//  and the pools, the actors staked, paused or unstaked) so the changes of a balance can be explained without
+//  replaying the block. They are stored by the persistence module once the block is committed, and are rolled back
+//  along with the transaction that emitted them.
+
+// `emitEvent` records a state change, tagged with the lifecycle phase of the block and the transaction being applied
+func (u *UtilityContext) emitEvent(event *coreTypes.StateChangeEvent) typesUtil.Error {
+	event.Phase = u.blockPhase
+	if u.blockPhase == coreTypes.BlockPhase_BLOCK_PHASE_DELIVER_TX {
+		event.TxHash = u.txHash
+	}
+	if err := u.Store().EmitEvent(event); err != nil {
+		return typesUtil.ErrEmitEvent(err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package utility
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/pokt-network/pocket/shared/modules"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+/*
+	Every transaction pays at least the minimum fee of its messages, set by the `message_*_fee` governance params, and
+	its signer can pay a higher fee (i.e. the `fee` of the transaction) for the priority mempool to reap it before the
+	transactions paying a lower fee per byte.
+
+	The fee estimation suggests a fee per byte high enough both to outbid the transactions in the mempool which would
+	not fit in the next block, and to match the median fee per byte of the transactions of the recent blocks.
+*/
+
+// The number of recent blocks whose transactions the fee estimation is based on
+const feeEstimationBlocks = 10
+
+type txFeePerByte struct {
+	feePerByte *big.Rat
+	size       int
+}
+
+// `EstimateFee` returns the minimum fee of the messages of `txBz`, and the fee it should pay given the transactions in
+// the mempool and in the recent blocks, which is at least the minimum fee
+func (u *utilityModule) EstimateFee(txBz []byte) (minimumFee, fee string, err error) {
+	tx, er := typesUtil.TransactionFromBytes(txBz)
+	if er != nil {
+		return "", "", er
+	}
+
+	readCtx, err := u.GetBus().GetPersistenceModule().NewReadContext(-1)
+	if err != nil {
+		return "", "", typesUtil.ErrGetHeight(err)
+	}
+	defer readCtx.Close()
+	latestHeight, err := readCtx.GetLatestBlockHeight()
+	if err != nil {
+		return "", "", typesUtil.ErrGetHeight(err)
+	}
+	minimumFees := newMinimumFeeCache(readCtx, int64(latestHeight))
+
+	minimumFeeAmount, er := minimumFees.get(tx)
+	if er != nil {
+		return "", "", er
+	}
+
+	blockTxs := make([][]byte, 0)
+	for height := int64(latestHeight); height > int64(latestHeight)-feeEstimationBlocks && height >= 0; height-- {
+		block, err := u.GetBus().GetPersistenceModule().GetBlock(height)
+		if err != nil {
+			break // The previous blocks may have been pruned
+		}
+		blockTxs = append(blockTxs, block.GetTransactions()...)
+	}
+
+	feePerByte := estimateFeePerByte(minimumFees.feesPerByte(blockTxs), minimumFees.feesPerByte(u.Mempool.Transactions()), u.maxBlockBytes)
+
+	feeAmount := new(big.Int).Set(minimumFeeAmount)
+	if feePerByte.Sign() > 0 {
+		// The fee per byte is outbid, since the mempool reaps the transactions with the same fee per byte by arrival
+		estimatedFee := new(big.Int).Mul(feePerByte.Num(), big.NewInt(int64(len(txBz))))
+		estimatedFee.Quo(estimatedFee, feePerByte.Denom())
+		estimatedFee.Add(estimatedFee, big.NewInt(1))
+		if estimatedFee.Cmp(feeAmount) > 0 {
+			feeAmount = estimatedFee
+		}
+	}
+	return typesUtil.BigIntToString(minimumFeeAmount), typesUtil.BigIntToString(feeAmount), nil
+}
+
+// `estimateFeePerByte` returns the highest of the fee per byte of the first transaction of the mempool which would not
+// fit in a block of `maxBlockBytes`, if any, and of the median fee per byte of the transactions of the recent blocks
+func estimateFeePerByte(blockTxs, mempoolTxs []txFeePerByte, maxBlockBytes uint64) *big.Rat {
+	feePerByte := new(big.Rat)
+	if len(blockTxs) > 0 {
+		sort.Slice(blockTxs, func(i, j int) bool {
+			return blockTxs[i].feePerByte.Cmp(blockTxs[j].feePerByte) < 0
+		})
+		feePerByte.Set(blockTxs[len(blockTxs)/2].feePerByte)
+	}
+
+	sort.Slice(mempoolTxs, func(i, j int) bool {
+		return mempoolTxs[i].feePerByte.Cmp(mempoolTxs[j].feePerByte) > 0
+	})
+	var blockBytes uint64
+	for _, tx := range mempoolTxs {
+		blockBytes += uint64(tx.size)
+		if blockBytes > maxBlockBytes {
+			if tx.feePerByte.Cmp(feePerByte) > 0 {
+				feePerByte.Set(tx.feePerByte)
+			}
+			break
+		}
+	}
+	return feePerByte
+}
+
+// `getTransactionFee` returns the fee paid by `tx`, given the minimum fee of its messages at the latest height, which
+// the priority of `tx` in the priority mempool is derived from
+func (u *utilityModule) getTransactionFee(tx *typesUtil.Transaction) (*big.Int, typesUtil.Error) {
+	readCtx, er := u.GetBus().GetPersistenceModule().NewReadContext(-1)
+	if er != nil {
+		return nil, typesUtil.ErrGetHeight(er)
+	}
+	defer readCtx.Close()
+	latestHeight, er := readCtx.GetLatestBlockHeight()
+	if er != nil {
+		return nil, typesUtil.ErrGetHeight(er)
+	}
+
+	minimumFee, err := newMinimumFeeCache(readCtx, int64(latestHeight)).get(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.FeeAmount(minimumFee)
+}
+
+// `minimumFeeCache` reads the minimum fees of the messages at a height, once per fee param
+type minimumFeeCache struct {
+	readCtx modules.PersistenceReadContext
+	height  int64
+	fees    map[string]*big.Int
+}
+
+func newMinimumFeeCache(readCtx modules.PersistenceReadContext, height int64) *minimumFeeCache {
+	return &minimumFeeCache{
+		readCtx: readCtx,
+		height:  height,
+		fees:    make(map[string]*big.Int),
+	}
+}
+
+// `get` returns the minimum fee of `tx`, which is the sum of the minimum fees of its messages
+func (c *minimumFeeCache) get(tx *typesUtil.Transaction) (*big.Int, typesUtil.Error) {
+	msgs, err := tx.Messages()
+	if err != nil {
+		return nil, err
+	}
+	minimumFee := big.NewInt(0)
+	for _, msg := range msgs {
+		fee, err := c.getMessageFee(msg)
+		if err != nil {
+			return nil, err
+		}
+		minimumFee.Add(minimumFee, fee)
+	}
+	return minimumFee, nil
+}
+
+func (c *minimumFeeCache) getMessageFee(msg typesUtil.Message) (*big.Int, typesUtil.Error) {
+	paramName, err := typesUtil.GetMessageFeeParamName(msg, msg.GetActorType())
+	if err != nil {
+		return nil, err
+	}
+	if fee, ok := c.fees[paramName]; ok {
+		return fee, nil
+	}
+	value, er := c.readCtx.GetStringParam(paramName, c.height)
+	if er != nil {
+		return nil, typesUtil.ErrGetParam(paramName, er)
+	}
+	fee, err := typesUtil.StringToBigInt(value)
+	if err != nil {
+		return nil, err
+	}
+	c.fees[paramName] = fee
+	return fee, nil
+}
+
+// `feesPerByte` returns the fees per byte of `txs`, skipping the transactions whose fee cannot be determined (e.g.
+// those of the recent blocks which paid less than the current minimum fee of their messages)
+func (c *minimumFeeCache) feesPerByte(txs [][]byte) []txFeePerByte {
+	feesPerByte := make([]txFeePerByte, 0, len(txs))
+	for _, txBz := range txs {
+		tx, err := typesUtil.TransactionFromBytes(txBz)
+		if err != nil {
+			continue
+		}
+		minimumFee, err := c.get(tx)
+		if err != nil {
+			continue
+		}
+		fee, err := tx.FeeAmount(minimumFee)
+		if err != nil {
+			continue
+		}
+		feesPerByte = append(feesPerByte, txFeePerByte{
+			feePerByte: new(big.Rat).SetFrac(fee, big.NewInt(int64(len(txBz)))),
+			size:       len(txBz),
+		})
+	}
+	return feesPerByte
+}
@@ -0,0 +1,58 @@
+package utility
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateFeePerByte(t *testing.T) {
+	tests := []struct {
+		name               string
+		blockTxs           []txFeePerByte
+		mempoolTxs         []txFeePerByte
+		maxBlockBytes      uint64
+		expectedFeePerByte *big.Rat
+	}{
+		{
+			name:               "no transactions",
+			maxBlockBytes:      1000,
+			expectedFeePerByte: big.NewRat(0, 1),
+		},
+		{
+			name:               "median fee per byte of the recent blocks",
+			blockTxs:           []txFeePerByte{newTestTxFeePerByte(30, 100), newTestTxFeePerByte(10, 100), newTestTxFeePerByte(20, 100)},
+			mempoolTxs:         []txFeePerByte{newTestTxFeePerByte(50, 100)},
+			maxBlockBytes:      1000,
+			expectedFeePerByte: big.NewRat(20, 100),
+		},
+		{
+			name:               "fee per byte of the first transaction of the mempool left out of the next block",
+			blockTxs:           []txFeePerByte{newTestTxFeePerByte(10, 100)},
+			mempoolTxs:         []txFeePerByte{newTestTxFeePerByte(30, 100), newTestTxFeePerByte(90, 100), newTestTxFeePerByte(50, 100)},
+			maxBlockBytes:      250,
+			expectedFeePerByte: big.NewRat(30, 100),
+		},
+		{
+			name:               "median fee per byte of the recent blocks above the mempool",
+			blockTxs:           []txFeePerByte{newTestTxFeePerByte(80, 100)},
+			mempoolTxs:         []txFeePerByte{newTestTxFeePerByte(30, 100), newTestTxFeePerByte(90, 100)},
+			maxBlockBytes:      150,
+			expectedFeePerByte: big.NewRat(80, 100),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feePerByte := estimateFeePerByte(tt.blockTxs, tt.mempoolTxs, tt.maxBlockBytes)
+			require.Zero(t, tt.expectedFeePerByte.Cmp(feePerByte), "expected %s, got %s", tt.expectedFeePerByte, feePerByte)
+		})
+	}
+}
+
+func newTestTxFeePerByte(fee int64, size int) txFeePerByte {
+	return txFeePerByte{
+		feePerByte: big.NewRat(fee, int64(size)),
+		size:       size,
+	}
+}
@@ -246,6 +246,22 @@ func (u *UtilityContext) GetMessageChangeParameterFee() (*big.Int, typesUtil.Err
 	return u.getBigIntParam(typesUtil.MessageChangeParameterFee)
 }
 
+func (u *UtilityContext) GetMessageClaimFee() (*big.Int, typesUtil.Error) {
+	return u.getBigIntParam(typesUtil.MessageClaimFee)
+}
+
+func (u *UtilityContext) GetMessageProofFee() (*big.Int, typesUtil.Error) {
+	return u.getBigIntParam(typesUtil.MessageProofFee)
+}
+
+func (u *UtilityContext) GetRelaysToTokensMultiplier() (*big.Int, typesUtil.Error) {
+	return u.getBigIntParam(typesUtil.RelaysToTokensMultiplierParamName)
+}
+
+func (u *UtilityContext) GetClaimExpirationBlocks() (int64, typesUtil.Error) {
+	return u.getInt64Param(typesUtil.ClaimExpirationBlocksParamName)
+}
+
 func (u *UtilityContext) GetDoubleSignFeeOwner() (owner []byte, err typesUtil.Error) {
 	return u.getByteArrayParam(typesUtil.MessageDoubleSignFeeOwner)
 }
@@ -369,6 +385,16 @@ func (u *UtilityContext) GetParamOwner(paramName string) ([]byte, error) {
 		return store.GetBytesParam(typesUtil.MessageUnpauseServiceNodeFeeOwner, height)
 	case typesUtil.MessageChangeParameterFee:
 		return store.GetBytesParam(typesUtil.MessageChangeParameterFeeOwner, height)
+	case typesUtil.MessageClaimFee:
+		return store.GetBytesParam(typesUtil.MessageClaimFeeOwner, height)
+	case typesUtil.MessageProofFee:
+		return store.GetBytesParam(typesUtil.MessageProofFeeOwner, height)
+	case typesUtil.RelaysToTokensMultiplierParamName:
+		return store.GetBytesParam(typesUtil.RelaysToTokensMultiplierOwner, height)
+	case typesUtil.ClaimExpirationBlocksParamName:
+		return store.GetBytesParam(typesUtil.ClaimExpirationBlocksOwner, height)
+	case typesUtil.FishermenPerSessionParamName:
+		return store.GetBytesParam(typesUtil.FishermenPerSessionOwner, height)
 	case typesUtil.BlocksPerSessionOwner:
 		return store.GetBytesParam(typesUtil.AclOwner, height)
 	case typesUtil.AppMaxChainsOwner:
@@ -475,75 +501,27 @@ func (u *UtilityContext) GetParamOwner(paramName string) ([]byte, error) {
 		return store.GetBytesParam(typesUtil.AclOwner, height)
 	case typesUtil.MessageChangeParameterFeeOwner:
 		return store.GetBytesParam(typesUtil.AclOwner, height)
+	case typesUtil.MessageClaimFeeOwner:
+		return store.GetBytesParam(typesUtil.AclOwner, height)
+	case typesUtil.MessageProofFeeOwner:
+		return store.GetBytesParam(typesUtil.AclOwner, height)
+	case typesUtil.RelaysToTokensMultiplierOwner:
+		return store.GetBytesParam(typesUtil.AclOwner, height)
+	case typesUtil.ClaimExpirationBlocksOwner:
+		return store.GetBytesParam(typesUtil.AclOwner, height)
+	case typesUtil.FishermenPerSessionOwner:
+		return store.GetBytesParam(typesUtil.AclOwner, height)
 	default:
 		return nil, typesUtil.ErrUnknownParam(paramName)
 	}
 }
 
 func (u *UtilityContext) GetFee(msg typesUtil.Message, actorType coreTypes.ActorType) (amount *big.Int, err typesUtil.Error) {
-	switch x := msg.(type) {
-	case *typesUtil.MessageDoubleSign:
-		return u.GetMessageDoubleSignFee()
-	case *typesUtil.MessageSend:
-		return u.GetMessageSendFee()
-	case *typesUtil.MessageStake:
-		switch actorType {
-		case coreTypes.ActorType_ACTOR_TYPE_APP:
-			return u.GetMessageStakeAppFee()
-		case coreTypes.ActorType_ACTOR_TYPE_FISH:
-			return u.GetMessageStakeFishermanFee()
-		case coreTypes.ActorType_ACTOR_TYPE_SERVICENODE:
-			return u.GetMessageStakeServiceNodeFee()
-		case coreTypes.ActorType_ACTOR_TYPE_VAL:
-			return u.GetMessageStakeValidatorFee()
-		default:
-			return nil, typesUtil.ErrUnknownActorType(actorType.String())
-		}
-	case *typesUtil.MessageEditStake:
-		switch actorType {
-		case coreTypes.ActorType_ACTOR_TYPE_APP:
-			return u.GetMessageEditStakeAppFee()
-		case coreTypes.ActorType_ACTOR_TYPE_FISH:
-			return u.GetMessageEditStakeFishermanFee()
-		case coreTypes.ActorType_ACTOR_TYPE_SERVICENODE:
-			return u.GetMessageEditStakeServiceNodeFee()
-		case coreTypes.ActorType_ACTOR_TYPE_VAL:
-			return u.GetMessageEditStakeValidatorFee()
-		default:
-			return nil, typesUtil.ErrUnknownActorType(actorType.String())
-		}
-	case *typesUtil.MessageUnstake:
-		switch actorType {
-		case coreTypes.ActorType_ACTOR_TYPE_APP:
-			return u.GetMessageUnstakeAppFee()
-		case coreTypes.ActorType_ACTOR_TYPE_FISH:
-			return u.GetMessageUnstakeFishermanFee()
-		case coreTypes.ActorType_ACTOR_TYPE_SERVICENODE:
-			return u.GetMessageUnstakeServiceNodeFee()
-		case coreTypes.ActorType_ACTOR_TYPE_VAL:
-			return u.GetMessageUnstakeValidatorFee()
-		default:
-			return nil, typesUtil.ErrUnknownActorType(actorType.String())
-		}
-	case *typesUtil.MessageUnpause:
-		switch actorType {
-		case coreTypes.ActorType_ACTOR_TYPE_APP:
-			return u.GetMessageUnpauseAppFee()
-		case coreTypes.ActorType_ACTOR_TYPE_FISH:
-			return u.GetMessageUnpauseFishermanFee()
-		case coreTypes.ActorType_ACTOR_TYPE_SERVICENODE:
-			return u.GetMessageUnpauseServiceNodeFee()
-		case coreTypes.ActorType_ACTOR_TYPE_VAL:
-			return u.GetMessageUnpauseValidatorFee()
-		default:
-			return nil, typesUtil.ErrUnknownActorType(actorType.String())
-		}
-	case *typesUtil.MessageChangeParameter:
-		return u.GetMessageChangeParameterFee()
-	default:
-		return nil, typesUtil.ErrUnknownMessage(x)
+	paramName, err := typesUtil.GetMessageFeeParamName(msg, actorType)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+	return u.getBigIntParam(paramName)
 }
 
 func (u *UtilityContext) GetMessageChangeParameterSignerCandidates(msg *typesUtil.MessageChangeParameter) ([][]byte, typesUtil.Error) {
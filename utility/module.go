@@ -3,6 +3,7 @@ package utility
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/pokt-network/pocket/runtime/configs"
 	"github.com/pokt-network/pocket/shared/codec"
@@ -15,8 +16,9 @@ var _ modules.UtilityModule = &utilityModule{}
 var _ modules.Module = &utilityModule{}
 
 type utilityModule struct {
-	bus    modules.Bus
-	config *configs.UtilityConfig
+	bus           modules.Bus
+	config        *configs.UtilityConfig
+	maxBlockBytes uint64 // the size of a block assumed by the fee estimation
 
 	Mempool types.Mempool
 }
@@ -39,7 +41,16 @@ func (*utilityModule) Create(bus modules.Bus) (modules.Module, error) {
 	utilityCfg := cfg.Utility
 
 	m.config = utilityCfg
-	m.Mempool = types.NewMempool(utilityCfg.MaxMempoolTransactionBytes, utilityCfg.MaxMempoolTransactions)
+	m.maxBlockBytes = runtimeMgr.GetGenesis().GetMaxBlockBytes()
+	switch utilityCfg.MempoolType {
+	case types.PriorityMempoolType:
+		ttl := time.Duration(utilityCfg.MempoolTransactionTtlMsec) * time.Millisecond
+		m.Mempool = types.NewPriorityMempool(utilityCfg.MaxMempoolTransactionBytes, utilityCfg.MaxMempoolTransactions, ttl, runtimeMgr.GetClock(), m.getTransactionFee)
+	case types.FIFOMempoolType, "":
+		m.Mempool = types.NewMempool(utilityCfg.MaxMempoolTransactionBytes, utilityCfg.MaxMempoolTransactions)
+	default:
+		return nil, fmt.Errorf("unknown mempool type: %s", utilityCfg.MempoolType)
+	}
 
 	return m, nil
 }
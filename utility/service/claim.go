@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/hex"
+
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/utility/types"
+)
+
+// NewClaim builds the relay tree over the relays the servicer stored for an application's session and returns
+// the claim committing to it. The relays are kept in the store until the claim is settled, see
+// `DeleteSettledClaimRelays`.
+func NewClaim(bus modules.Bus, sessionHeight int64, appPublicKey, relayChain, geoZone string) (*types.MessageClaim, types.Error) {
+	servicerAddress, err := servicerAddress(bus)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := buildRelayTree(bus.GetPersistenceModule().GetRelayStore(), sessionHeight, appPublicKey, relayChain)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MessageClaim{
+		ServicerAddress:      servicerAddress,
+		ApplicationPublicKey: appPublicKey,
+		SessionHeight:        sessionHeight,
+		RelayChain:           relayChain,
+		GeoZone:              geoZone,
+		MerkleRoot:           tree.Root(),
+	}, nil
+}
+
+// NewProof rebuilds the relay tree of a committed claim and proves the leaf selected by the hash of the block
+// the claim was committed in
+func NewProof(bus modules.Bus, claim *types.MessageClaim, claimBlockHash string) (*types.MessageProof, types.Error) {
+	tree, err := buildRelayTree(bus.GetPersistenceModule().GetRelayStore(), claim.SessionHeight, claim.ApplicationPublicKey, claim.RelayChain)
+	if err != nil {
+		return nil, err
+	}
+	seed, er := hex.DecodeString(claimBlockHash)
+	if er != nil {
+		return nil, types.ErrHexDecodeFromString(er)
+	}
+	index := types.RelayProofIndex(seed, tree.Root())
+	relayBz, sideNodes, err := tree.Prove(index)
+	if err != nil {
+		return nil, err
+	}
+	relay, err := types.RelayFromBytes(relayBz)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MessageProof{
+		ServicerAddress:      claim.ServicerAddress,
+		ApplicationPublicKey: claim.ApplicationPublicKey,
+		SessionHeight:        claim.SessionHeight,
+		RelayChain:           claim.RelayChain,
+		Relay:                relay,
+		LeafIndex:            index,
+		SideNodes:            sideNodes,
+	}, nil
+}
+
+// DeleteSettledClaimRelays deletes the relays of a claim from the relay store once the claim is settled as of the
+// latest committed height, i.e. once it is proven or can no longer be. It returns whether the relays were deleted, and
+// leaves them in the store for the pending proof of the claim otherwise.
+func DeleteSettledClaimRelays(bus modules.Bus, claim *types.MessageClaim) (bool, types.Error) {
+	readCtx, er := bus.GetPersistenceModule().NewReadContext(-1)
+	if er != nil {
+		return false, types.ErrGetHeight(er)
+	}
+	defer readCtx.Close()
+
+	latestHeight, er := readCtx.GetLatestBlockHeight()
+	if er != nil {
+		return false, types.ErrGetHeight(er)
+	}
+	height := int64(latestHeight)
+	_, _, claimHeight, provenHeight, er := readCtx.GetClaim(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, height)
+	if er != nil {
+		return false, types.ErrClaimNotFound()
+	}
+	if provenHeight == types.HeightNotUsed {
+		claimExpirationBlocks, er := readCtx.GetIntParam(types.ClaimExpirationBlocksParamName, height)
+		if er != nil {
+			return false, types.ErrGetParam(types.ClaimExpirationBlocksParamName, er)
+		}
+		if height <= claimHeight+int64(claimExpirationBlocks) {
+			return false, nil
+		}
+	}
+
+	if er := bus.GetPersistenceModule().GetRelayStore().DeleteSessionRelays(claim.SessionHeight, claim.ApplicationPublicKey, claim.RelayChain); er != nil {
+		return false, types.ErrRelayStore(er)
+	}
+	return true, nil
+}
+
+// buildRelayTree adds the session relays to a new relay tree
+func buildRelayTree(store kvstore.RelayStore, sessionHeight int64, appPublicKey, relayChain string) (*types.RelayTree, types.Error) {
+	relays, er := store.GetSessionRelays(sessionHeight, appPublicKey, relayChain)
+	if er != nil {
+		return nil, types.ErrRelayStore(er)
+	}
+	if len(relays) == 0 {
+		return nil, types.ErrInvalidClaim("no relays were serviced for the session")
+	}
+	tree := types.NewRelayTree()
+	for _, relayBz := range relays {
+		if err := tree.AddRelay(relayBz); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+func servicerAddress(bus modules.Bus) (crypto.Address, types.Error) {
+	privateKey, err := crypto.NewPrivateKey(bus.GetRuntimeMgr().GetConfig().PrivateKey)
+	if err != nil {
+		return nil, types.ErrNewPrivateKey(err)
+	}
+	return privateKey.Address(), nil
+}
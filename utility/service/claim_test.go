@@ -0,0 +1,311 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/utility"
+	"github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNumRelays           = 10
+	testClaimHeight         = testSessionHeight + testBlocksPerSession + 1
+	testClaimExpiration     = 8
+	testRelaysToTokens      = 1000
+	testDAOPoolAmount       = "1000000000"
+	testServicerOutputBytes = 20
+)
+
+func TestClaimAndProof(t *testing.T) {
+	ts := newTestServicer(t)
+	ts.storeTestRelays(t, testNumRelays)
+	bus := ts.newBusMock(t, "http://localhost")
+	appPublicKey := ts.appKey.PublicKey().String()
+
+	claim, err := NewClaim(bus, testSessionHeight, appPublicKey, testRelayChain, testGeoZone)
+	require.NoError(t, err)
+	require.NoError(t, claim.ValidateBasic())
+	require.Equal(t, uint64(testNumRelays), types.RelayTreeRootSum(claim.MerkleRoot))
+
+	rwCtx := ts.newClaimRWContextMock(t, claim, false)
+	expectProven(rwCtx, claim)
+
+	candidates, err := ts.newUtilityContext(rwCtx, testClaimHeight).GetMessageClaimSignerCandidates(claim)
+	require.NoError(t, err)
+	require.Contains(t, candidates, []byte(claim.ServicerAddress))
+
+	// a claim cannot be submitted before the session is over
+	err = ts.newUtilityContext(rwCtx, testSessionHeight+1).HandleMessageClaim(claim)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidClaimError, err.Code())
+
+	require.NoError(t, ts.newUtilityContext(rwCtx, testClaimHeight).HandleMessageClaim(claim))
+
+	// a session can only be claimed once
+	err = ts.newUtilityContext(rwCtx, testClaimHeight).HandleMessageClaim(claim)
+	require.Error(t, err)
+	require.Equal(t, types.CodeClaimExistsError, err.Code())
+
+	proof, err := NewProof(bus, claim, testBlockHash(testClaimHeight))
+	require.NoError(t, err)
+	require.NoError(t, proof.ValidateBasic())
+
+	// a proof cannot be submitted in the block of the claim
+	err = ts.newUtilityContext(rwCtx, testClaimHeight).HandleMessageProof(proof)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidProofError, err.Code())
+
+	require.NoError(t, ts.newUtilityContext(rwCtx, testClaimHeight+1).HandleMessageProof(proof))
+
+	// a proven claim settles its session so it can neither be proven nor claimed again
+	err = ts.newUtilityContext(rwCtx, testClaimHeight+2).HandleMessageProof(proof)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidProofError, err.Code())
+
+	err = ts.newUtilityContext(rwCtx, testClaimHeight+2).HandleMessageClaim(claim)
+	require.Error(t, err)
+	require.Equal(t, types.CodeClaimExistsError, err.Code())
+}
+
+func TestClaimAndProof_Expiration(t *testing.T) {
+	ts := newTestServicer(t)
+	ts.storeTestRelays(t, testNumRelays)
+	bus := ts.newBusMock(t, "http://localhost")
+
+	claim, err := NewClaim(bus, testSessionHeight, ts.appKey.PublicKey().String(), testRelayChain, testGeoZone)
+	require.NoError(t, err)
+
+	// a session cannot be claimed once the claim window after its end is over
+	rwCtx := ts.newClaimRWContextMock(t, claim, false)
+	err = ts.newUtilityContext(rwCtx, testSessionHeight+testBlocksPerSession+testClaimExpiration+1).HandleMessageClaim(claim)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidClaimError, err.Code())
+
+	// a claim cannot be proven once the proof window after the claim is over
+	rwCtx = ts.newClaimRWContextMock(t, claim, true)
+	proof, err := NewProof(bus, claim, testBlockHash(testClaimHeight))
+	require.NoError(t, err)
+	err = ts.newUtilityContext(rwCtx, testClaimHeight+testClaimExpiration+1).HandleMessageProof(proof)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidProofError, err.Code())
+}
+
+func TestClaimAndProof_InvalidProofs(t *testing.T) {
+	ts := newTestServicer(t)
+	ts.storeTestRelays(t, testNumRelays)
+	bus := ts.newBusMock(t, "http://localhost")
+
+	claim, err := NewClaim(bus, testSessionHeight, ts.appKey.PublicKey().String(), testRelayChain, testGeoZone)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(proof *types.MessageProof)
+	}{
+		{"wrong leaf", func(proof *types.MessageProof) { proof.LeafIndex = (proof.LeafIndex + 1) % testNumRelays }},
+		{"missing side node", func(proof *types.MessageProof) { proof.SideNodes = proof.SideNodes[1:] }},
+		{"tampered relay", func(proof *types.MessageProof) { proof.Relay.Payload.Data = "tampered" }},
+		{"tampered and re-signed relay", func(proof *types.MessageProof) {
+			proof.Relay.Payload.Data = "tampered"
+			ts.signRelay(proof.Relay)
+		}},
+		{"different relay chain", func(proof *types.MessageProof) { proof.Relay.Meta.RelayChain = "0002" }},
+		{"different session", func(proof *types.MessageProof) { proof.Relay.Meta.BlockHeight += testBlocksPerSession }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rwCtx := ts.newClaimRWContextMock(t, claim, true)
+
+			proof, err := NewProof(bus, claim, testBlockHash(testClaimHeight))
+			require.NoError(t, err)
+			test.modify(proof)
+
+			err = ts.newUtilityContext(rwCtx, testClaimHeight+1).HandleMessageProof(proof)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDeleteSettledClaimRelays(t *testing.T) {
+	tests := []struct {
+		name          string
+		latestHeight  int64
+		provenHeight  int64
+		expectDeleted bool
+	}{
+		{"pending proof", testClaimHeight + 1, types.HeightNotUsed, false},
+		{"last block of the proof window", testClaimHeight + testClaimExpiration, types.HeightNotUsed, false},
+		{"proven", testClaimHeight + 2, testClaimHeight + 1, true},
+		{"expired", testClaimHeight + testClaimExpiration + 1, types.HeightNotUsed, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := newTestServicer(t)
+			ts.storeTestRelays(t, testNumRelays)
+			appPublicKey := ts.appKey.PublicKey().String()
+			// the relays of the other relay chains of the session are settled by their own claim
+			otherRelayBz := []byte("relay of another relay chain")
+			require.NoError(t, ts.store.StoreRelay(testSessionHeight, appPublicKey, "0002", crypto.SHA3Hash(otherRelayBz), otherRelayBz))
+
+			claim, err := NewClaim(ts.newBusMock(t, "http://localhost"), testSessionHeight, appPublicKey, testRelayChain, testGeoZone)
+			require.NoError(t, err)
+
+			deleted, err := DeleteSettledClaimRelays(ts.newSettlementBusMock(t, claim, test.latestHeight, test.provenHeight), claim)
+			require.NoError(t, err)
+			require.Equal(t, test.expectDeleted, deleted)
+
+			relays, er := ts.store.GetSessionRelays(testSessionHeight, appPublicKey, testRelayChain)
+			require.NoError(t, er)
+			if test.expectDeleted {
+				require.Empty(t, relays)
+			} else {
+				require.Len(t, relays, testNumRelays)
+			}
+			relays, er = ts.store.GetSessionRelays(testSessionHeight, appPublicKey, "0002")
+			require.NoError(t, er)
+			require.Len(t, relays, 1)
+		})
+	}
+}
+
+func (ts *testServicer) storeTestRelays(t *testing.T, numRelays int) {
+	for i := 0; i < numRelays; i++ {
+		relay := ts.newSignedRelay(t, testSessionHeight+int64(i%testBlocksPerSession))
+		relay.Payload.Data = fmt.Sprintf(`{"method":"eth_blockNumber","id":%d}`, i)
+		ts.signRelay(relay)
+
+		relayBz, err := relay.Bytes()
+		require.NoError(t, err)
+		require.NoError(t, ts.store.StoreRelay(testSessionHeight, ts.appKey.PublicKey().String(), testRelayChain, crypto.SHA3Hash(relayBz), relayBz))
+	}
+}
+
+func (ts *testServicer) newUtilityContext(rwCtx *mockModules.MockPersistenceRWContext, height int64) *utility.UtilityContext {
+	ts.height = height
+	return &utility.UtilityContext{
+		Height:  height,
+		Context: &utility.Context{PersistenceRWContext: rwCtx},
+	}
+}
+
+// newSessionRWContextMock mocks the persistence context needed to regenerate the test session, which the
+// fishermen provided are candidates of
+func (ts *testServicer) newSessionRWContextMock(t *testing.T, fishermen ...*coreTypes.Actor) *mockModules.MockPersistenceRWContext {
+	ctrl := gomock.NewController(t)
+	rwCtx := mockModules.NewMockPersistenceRWContext(ctrl)
+
+	rwCtx.EXPECT().GetHeight().DoAndReturn(func() (int64, error) { return ts.height, nil }).AnyTimes()
+	rwCtx.EXPECT().GetBlockHash(gomock.Any()).DoAndReturn(func(height int64) (string, error) { return testBlockHash(height), nil }).AnyTimes()
+	rwCtx.EXPECT().GetIntParam(types.BlocksPerSessionParamName, gomock.Any()).Return(testBlocksPerSession, nil).AnyTimes()
+	rwCtx.EXPECT().GetIntParam(types.ServiceNodesPerSessionParamName, gomock.Any()).Return(1, nil).AnyTimes()
+	rwCtx.EXPECT().GetIntParam(types.FishermenPerSessionParamName, gomock.Any()).Return(1, nil).AnyTimes()
+	rwCtx.EXPECT().GetIntParam(types.ClaimExpirationBlocksParamName, gomock.Any()).Return(testClaimExpiration, nil).AnyTimes()
+	rwCtx.EXPECT().GetApp(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ []byte, _ int64) (string, string, string, string, string, int64, int64, []string, error) {
+			appPublicKey := ts.appKey.PublicKey()
+			return appPublicKey.Address().String(), appPublicKey.String(), "1000000", ts.appMaxRelays, appPublicKey.Address().String(),
+				types.HeightNotUsed, types.HeightNotUsed, []string{testRelayChain}, nil
+		}).AnyTimes()
+	rwCtx.EXPECT().GetAllServiceNodes(gomock.Any()).DoAndReturn(func(_ int64) ([]*coreTypes.Actor, error) {
+		serviceNodeKey := ts.servicerKey.PublicKey()
+		return []*coreTypes.Actor{{
+			ActorType:       coreTypes.ActorType_ACTOR_TYPE_SERVICENODE,
+			Address:         serviceNodeKey.Address().String(),
+			PublicKey:       serviceNodeKey.String(),
+			Chains:          []string{testRelayChain},
+			PausedHeight:    types.HeightNotUsed,
+			UnstakingHeight: types.HeightNotUsed,
+		}}, nil
+	}).AnyTimes()
+	rwCtx.EXPECT().GetAllFishermen(gomock.Any()).Return(fishermen, nil).AnyTimes()
+	return rwCtx
+}
+
+// newClaimRWContextMock mocks the persistence context of the claim & proof handlers. Unless `claimed`, the claim
+// only exists once inserted. Paying the rewards of the claim is unexpected unless `expectProven` is used.
+func (ts *testServicer) newClaimRWContextMock(t *testing.T, claim *types.MessageClaim, claimed bool) *mockModules.MockPersistenceRWContext {
+	rwCtx := ts.newSessionRWContextMock(t)
+
+	claimHeight, provenHeight := types.HeightNotUsed, types.HeightNotUsed
+	if claimed {
+		claimHeight = testClaimHeight
+	}
+	servicerOutput := make([]byte, testServicerOutputBytes)
+
+	rwCtx.EXPECT().GetStringParam(types.RelaysToTokensMultiplierParamName, gomock.Any()).Return(fmt.Sprint(testRelaysToTokens), nil).AnyTimes()
+	rwCtx.EXPECT().GetServiceNodeOutputAddress(claim.ServicerAddress, gomock.Any()).Return(servicerOutput, nil).AnyTimes()
+
+	rwCtx.EXPECT().GetClaimExists(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, gomock.Any()).
+		DoAndReturn(func(_ []byte, _ string, _ int64, _ string, _ int64) (bool, error) {
+			return claimHeight != types.HeightNotUsed, nil
+		}).AnyTimes()
+	rwCtx.EXPECT().InsertClaim(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, claim.GeoZone, claim.MerkleRoot).
+		DoAndReturn(func(_ []byte, _ string, _ int64, _, _ string, _ []byte) error {
+			claimHeight = ts.height
+			return nil
+		}).MaxTimes(1)
+	rwCtx.EXPECT().GetClaim(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, gomock.Any()).
+		DoAndReturn(func(_ []byte, _ string, _ int64, _ string, _ int64) (string, []byte, int64, int64, error) {
+			return claim.GeoZone, claim.MerkleRoot, claimHeight, provenHeight, nil
+		}).AnyTimes()
+	rwCtx.EXPECT().SetClaimProvenHeight(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, gomock.Any()).
+		DoAndReturn(func(_ []byte, _ string, _ int64, _ string, height int64) error {
+			provenHeight = height
+			return nil
+		}).MaxTimes(1)
+
+	rwCtx.EXPECT().GetPoolAmount(coreTypes.Pools_POOLS_DAO.FriendlyName(), gomock.Any()).Return(testDAOPoolAmount, nil).AnyTimes()
+	return rwCtx
+}
+
+// newSettlementBusMock mocks the latest committed state of a claim committed at `testClaimHeight`
+func (ts *testServicer) newSettlementBusMock(t *testing.T, claim *types.MessageClaim, latestHeight, provenHeight int64) *mockModules.MockBus {
+	ctrl := gomock.NewController(t)
+
+	readCtx := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtx.EXPECT().Close().Return(nil).AnyTimes()
+	readCtx.EXPECT().GetLatestBlockHeight().Return(uint64(latestHeight), nil).AnyTimes()
+	readCtx.EXPECT().GetIntParam(types.ClaimExpirationBlocksParamName, latestHeight).Return(testClaimExpiration, nil).AnyTimes()
+	readCtx.EXPECT().GetClaim(claim.ServicerAddress, claim.ApplicationPublicKey, claim.SessionHeight, claim.RelayChain, latestHeight).
+		Return(claim.GeoZone, claim.MerkleRoot, int64(testClaimHeight), provenHeight, nil).Times(1)
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(gomock.Any()).Return(readCtx, nil).AnyTimes()
+	persistenceMock.EXPECT().GetRelayStore().Return(ts.store).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	return busMock
+}
+
+// expectProven expects the rewards of the claim to be paid exactly once
+func expectProven(rwCtx *mockModules.MockPersistenceRWContext, claim *types.MessageClaim) {
+	reward := types.BigIntToString(big.NewInt(testNumRelays * testRelaysToTokens))
+	rwCtx.EXPECT().SubtractPoolAmount(coreTypes.Pools_POOLS_DAO.FriendlyName(), reward).Return(nil).Times(1)
+	rwCtx.EXPECT().AddAccountAmount(make([]byte, testServicerOutputBytes), reward).Return(nil).Times(1)
+	rwCtx.EXPECT().EmitEvent(eventOfType(coreTypes.EventType_EVENT_TYPE_RELAY_REWARD)).Return(nil).Times(1)
+}
+
+// eventOfType matches the state change events of type `eventType`
+type eventOfType coreTypes.EventType
+
+func (m eventOfType) Matches(x interface{}) bool {
+	event, ok := x.(*coreTypes.StateChangeEvent)
+	return ok && event.Type == coreTypes.EventType(m)
+}
+
+func (m eventOfType) String() string {
+	return fmt.Sprintf("is a state change event of type %s", coreTypes.EventType(m))
+}
+
+func testBlockHash(height int64) string {
+	return hex.EncodeToString(crypto.SHA3Hash([]byte(fmt.Sprintf("block_%d", height))))
+}
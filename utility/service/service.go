@@ -1,10 +1,30 @@
 package service
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/converters"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/modules"
+	"github.com/pokt-network/pocket/utility"
 	"github.com/pokt-network/pocket/utility/types"
+	"golang.org/x/exp/slices"
 )
 
+// AATVersion is the only supported version of the Application Authentication Token
+const AATVersion = types.AATVersion
+
 type Relay interface {
 	RelayPayload
 	RelayMeta
@@ -27,8 +47,8 @@ type RelayMeta interface {
 }
 
 type RelayResponse interface {
-	Payload() string
-	ServicerSignature() string
+	GetPayload() string
+	GetServicerSignature() string
 }
 
 type RelayChain Identifiable
@@ -46,81 +66,229 @@ type Identifiable interface {
 	ID() string
 }
 
-var _ Relay = &relay{}
+// RelayServicer drives the servicer side of the relay lifecycle
+type RelayServicer interface {
+	Relay
+	Validate() types.Error
+	Store() types.Error
+	Execute() (RelayResponse, types.Error)
+	ReapStoreForHashCollision(sessionBlockHeight int64, hashEndWith string) ([]Relay, types.Error)
+	ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []Relay) types.Error
+}
 
-type relay struct{}
+var _ RelayServicer = &relay{}
+
+type relay struct {
+	bus   modules.Bus
+	relay *types.Relay
+}
+
+func NewRelay(bus modules.Bus, r *types.Relay) RelayServicer {
+	return &relay{
+		bus:   bus,
+		relay: r,
+	}
+}
 
 // Validate a submitted relay by a client before servicing
 func (r *relay) Validate() types.Error {
-
 	// validate payload
+	payload := r.relay.GetPayload()
+	if payload == nil {
+		return types.ErrInvalidRelay("missing payload")
+	}
+	if payload.GetMethod() == "" {
+		return types.ErrInvalidRelay("missing http method")
+	}
 
 	// validate the metadata
+	meta := r.relay.GetMeta()
+	if meta == nil {
+		return types.ErrInvalidRelay("missing metadata")
+	}
+	if meta.GetRelayChain() == "" {
+		return types.ErrEmptyRelayChain()
+	}
+	servicerPrivateKey, err := r.servicerPrivateKey()
+	if err != nil {
+		return err
+	}
+	if meta.GetServicerPublicKey() != servicerPrivateKey.PublicKey().String() {
+		return types.ErrInvalidRelay("relay is addressed to a different servicer")
+	}
+	if err := r.relay.ValidateSignatures(); err != nil {
+		return err
+	}
 
 	// ensure the RelayChain is supported locally
+	if _, ok := r.servicerConfig().GetRelayChainUrls()[meta.GetRelayChain()]; !ok {
+		return types.ErrUnsupportedRelayChain(meta.GetRelayChain())
+	}
 
 	// ensure session block height is current
-
-	// get the session context
+	readCtx, er := r.bus.GetPersistenceModule().NewReadContext(-1)
+	if er != nil {
+		return types.ErrGetHeight(er)
+	}
+	defer readCtx.Close()
+
+	latestHeight, er := readCtx.GetLatestBlockHeight()
+	if er != nil {
+		return types.ErrGetHeight(er)
+	}
+	currentHeight := int64(latestHeight)
+	blocksPerSession, er := readCtx.GetIntParam(types.BlocksPerSessionParamName, currentHeight)
+	if er != nil {
+		return types.ErrGetParam(types.BlocksPerSessionParamName, er)
+	}
+	if meta.GetBlockHeight() > currentHeight ||
+		utility.GetSessionHeight(meta.GetBlockHeight(), int64(blocksPerSession)) != utility.GetSessionHeight(currentHeight, int64(blocksPerSession)) {
+		return types.ErrInvalidSessionHeight(meta.GetBlockHeight(), currentHeight)
+	}
 
 	// get the application object from the r.AAT()
+	application, err := r.getApplication(readCtx, currentHeight)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(application.GetChains(), meta.GetRelayChain()) {
+		return types.ErrInvalidRelay("the application is not staked for the relay chain")
+	}
 
-	// get session node count from that session height
+	// generate the session from seed data
+	session, err := utility.NewSession(readCtx, meta.GetBlockHeight(), identifier(meta.GetGeoZone()), identifier(meta.GetRelayChain()), application)
+	if err != nil {
+		return err
+	}
+
+	// validate self against the session
+	serviceNodes := session.GetServiceNodes()
+	if slices.IndexFunc(serviceNodes, func(sn *coreTypes.Actor) bool {
+		return sn.GetPublicKey() == meta.GetServicerPublicKey()
+	}) == -1 {
+		return types.ErrServicerNotInSession()
+	}
 
 	// get maximum possible relays for the application
+	appMaxRelays, er := converters.StringToBigInt(application.GetGenericParam())
+	if er != nil {
+		return types.ErrStringToBigInt()
+	}
+	maxRelaysPerServicer := new(big.Int).Div(appMaxRelays, big.NewInt(int64(len(serviceNodes)))).Int64()
 
 	// ensure not over serviced
-
-	// generate the session from seed data
-
-	// validate self against the session
+	relayCount, er := r.relayStore().GetRelayCount(session.GetSessionHeight(), application.GetPublicKey())
+	if er != nil {
+		return types.ErrRelayStore(er)
+	}
+	if int64(relayCount) >= maxRelaysPerServicer {
+		return types.ErrOverServiced(maxRelaysPerServicer)
+	}
 
 	return nil
 }
 
 // Store a submitted relay by a client for volume tracking
 func (r *relay) Store() types.Error {
-
 	// marshal relay object into protoBytes
+	relayBz, err := r.relay.Bytes()
+	if err != nil {
+		return err
+	}
 
 	// calculate the hashOf(protoBytes) <needed for volume tracking>
+	relayHash := crypto.SHA3Hash(relayBz)
 
 	// persist relay object, indexing under session
-
+	readCtx, er := r.bus.GetPersistenceModule().NewReadContext(-1)
+	if er != nil {
+		return types.ErrGetHeight(er)
+	}
+	defer readCtx.Close()
+
+	meta := r.relay.GetMeta()
+	blocksPerSession, er := readCtx.GetIntParam(types.BlocksPerSessionParamName, meta.GetBlockHeight())
+	if er != nil {
+		return types.ErrGetParam(types.BlocksPerSessionParamName, er)
+	}
+	sessionHeight := utility.GetSessionHeight(meta.GetBlockHeight(), int64(blocksPerSession))
+	if er := r.relayStore().StoreRelay(sessionHeight, meta.GetToken().GetApplicationPublicKey(), meta.GetRelayChain(), relayHash, relayBz); er != nil {
+		return types.ErrRelayStore(er)
+	}
 	return nil
 }
 
 // Execute a submitted relay by a client after validation
 func (r *relay) Execute() (RelayResponse, types.Error) {
-
 	// retrieve the RelayChain url from the servicer's local configuration file
+	config := r.servicerConfig()
+	relayChainURL, ok := config.GetRelayChainUrls()[r.relay.GetMeta().GetRelayChain()]
+	if !ok {
+		return nil, types.ErrUnsupportedRelayChain(r.relay.GetMeta().GetRelayChain())
+	}
 
 	// execute http request with the relay payload
+	payload := r.relay.GetPayload()
+	req, er := http.NewRequest(payload.GetMethod(), relayChainURL+payload.GetHttpPath(), strings.NewReader(payload.GetData()))
+	if er != nil {
+		return nil, types.ErrRelayExecution(er)
+	}
+	for key, value := range payload.GetHeaders() {
+		req.Header.Set(key, value)
+	}
+	client := &http.Client{Timeout: time.Duration(config.GetTimeoutMsec()) * time.Millisecond}
+	resp, er := client.Do(req)
+	if er != nil {
+		return nil, types.ErrRelayExecution(er)
+	}
+	defer resp.Body.Close()
+	body, er := io.ReadAll(resp.Body)
+	if er != nil {
+		return nil, types.ErrRelayExecution(er)
+	}
 
 	// format and digitally sign the response
-
-	return nil, nil
+	response := &types.RelayResponse{Payload: string(body)}
+	signBytes, err := response.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	servicerPrivateKey, err := r.servicerPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, er := servicerPrivateKey.Sign(signBytes)
+	if er != nil {
+		return nil, types.ErrRelayResponseSign(er)
+	}
+	response.ServicerSignature = hex.EncodeToString(signature)
+	return response, nil
 }
 
 // Get volume metric applicable relays from store
 func (r *relay) ReapStoreForHashCollision(sessionBlockHeight int64, hashEndWith string) ([]Relay, types.Error) {
-
 	// Pull all relays whose hash collides with the revealed secret key
 	// It's important to note, the secret key isn't revealed by the network until the session is over
 	// to prevent volume based bias. The secret key is usually a pseudorandom selection using the block hash as a seed.
 	// (See the session protocol)
-	//
-	// Demonstrable pseudocode below:
-	//   `SELECT * from RELAY where HashOf(relay) ends with hashEndWith AND sessionBlockHeight=sessionBlockHeight`
-
-	// This function also signifies deleting the non-volume-applicable Relays
-
-	return nil, nil
+	relaysBz, er := r.relayStore().GetRelaysByHashSuffix(sessionBlockHeight, hashEndWith)
+	if er != nil {
+		return nil, types.ErrRelayStore(er)
+	}
+	volumeRelays := make([]Relay, 0, len(relaysBz))
+	for _, relayBz := range relaysBz {
+		volumeRelay, err := types.RelayFromBytes(relayBz)
+		if err != nil {
+			return nil, err
+		}
+		volumeRelays = append(volumeRelays, NewRelay(r.bus, volumeRelay))
+	}
+	// The relays are kept until the claim of their session is settled, see `DeleteSettledClaimRelays`
+	return volumeRelays, nil
 }
 
 // Report volume metric applicable relays to Fisherman
 func (r *relay) ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []Relay) types.Error {
-
 	// Send all volume applicable relays to the assigned trusted Fisherman for
 	// a proper verification of the volume completed. Send volumeRelays to fishermanServiceURL
 	// through http.
@@ -132,17 +300,93 @@ func (r *relay) ReportVolumeMetrics(fishermanServiceURL string, volumeRelays []R
 	// Pros: Can report volume metrics directly to the chain in a trustless fashion
 	// Cons: Large chain bloat, non-trivial compute requirement for creation of claim/proof transactions and trees,
 	//       non-trivial compute requirement to process claim / proofs during ApplyBlock()
-
+	report := &types.RelayVolumeReport{}
+	for _, volumeRelay := range volumeRelays {
+		vr, ok := volumeRelay.(*relay)
+		if !ok {
+			return types.ErrInvalidRelay("unexpected relay implementation")
+		}
+		report.Relays = append(report.Relays, vr.relay)
+	}
+	reportBz, er := codec.GetCodec().Marshal(report)
+	if er != nil {
+		return types.ErrProtoMarshal(er)
+	}
+
+	client := &http.Client{Timeout: time.Duration(r.servicerConfig().GetTimeoutMsec()) * time.Millisecond}
+	resp, er := client.Post(fishermanServiceURL, "application/x-protobuf", bytes.NewReader(reportBz))
+	if er != nil {
+		return types.ErrReportVolumeMetrics(er)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return types.ErrReportVolumeMetrics(fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
 	return nil
 }
 
-func (r *relay) GetData() string                        { return "" }
-func (r *relay) GetMethod() string                      { return "" }
-func (r *relay) GetHTTPPath() string                    { return "" }
-func (r *relay) GetHeaders() map[string]string          { return nil }
-func (r *relay) GetBlockHeight() int64                  { return 0 }
-func (r *relay) GetServicerPublicKey() crypto.PublicKey { return nil }
-func (r *relay) GetRelayChain() RelayChain              { return nil }
-func (r *relay) GetGeoZone() GeoZone                    { return nil }
-func (r *relay) GetToken() AAT                          { return nil }
-func (r *relay) GetSignature() string                   { return "" }
+func (r *relay) getApplication(readCtx modules.PersistenceReadContext, height int64) (*coreTypes.Actor, types.Error) {
+	appPublicKey, err := crypto.NewPublicKey(r.relay.GetMeta().GetToken().GetApplicationPublicKey())
+	if err != nil {
+		return nil, types.ErrNewPublicKeyFromBytes(err)
+	}
+	_, publicKey, stakedTokens, maxRelays, outputAddress, pauseHeight, unstakingHeight, chains, err := readCtx.GetApp(appPublicKey.Address(), height)
+	if err != nil {
+		return nil, types.ErrNotExists()
+	}
+	if pauseHeight != types.HeightNotUsed || unstakingHeight != types.HeightNotUsed {
+		return nil, types.ErrInvalidAAT("the application is not actively staked")
+	}
+	return &coreTypes.Actor{
+		ActorType:       coreTypes.ActorType_ACTOR_TYPE_APP,
+		Address:         appPublicKey.Address().String(),
+		PublicKey:       publicKey,
+		Chains:          chains,
+		GenericParam:    maxRelays,
+		StakedAmount:    stakedTokens,
+		PausedHeight:    pauseHeight,
+		UnstakingHeight: unstakingHeight,
+		Output:          outputAddress,
+	}, nil
+}
+
+func (r *relay) relayStore() kvstore.RelayStore {
+	return r.bus.GetPersistenceModule().GetRelayStore()
+}
+
+func (r *relay) servicerConfig() *configs.ServicerConfig {
+	return r.bus.GetRuntimeMgr().GetConfig().Servicer
+}
+
+func (r *relay) servicerPrivateKey() (crypto.PrivateKey, types.Error) {
+	privateKey, err := crypto.NewPrivateKey(r.bus.GetRuntimeMgr().GetConfig().PrivateKey)
+	if err != nil {
+		return nil, types.ErrNewPrivateKey(err)
+	}
+	return privateKey, nil
+}
+
+// identifier satisfies both the `Identifiable` interface of this package and the `utility.Identifier`
+// interface used for session generation.
+type identifier string
+
+func (i identifier) Name() string  { return string(i) }
+func (i identifier) ID() string    { return string(i) }
+func (i identifier) Bytes() []byte { return []byte(i) }
+
+func (r *relay) GetData() string               { return r.relay.GetPayload().GetData() }
+func (r *relay) GetMethod() string             { return r.relay.GetPayload().GetMethod() }
+func (r *relay) GetHTTPPath() string           { return r.relay.GetPayload().GetHttpPath() }
+func (r *relay) GetHeaders() map[string]string { return r.relay.GetPayload().GetHeaders() }
+func (r *relay) GetBlockHeight() int64         { return r.relay.GetMeta().GetBlockHeight() }
+func (r *relay) GetServicerPublicKey() crypto.PublicKey {
+	publicKey, err := crypto.NewPublicKey(r.relay.GetMeta().GetServicerPublicKey())
+	if err != nil {
+		return nil
+	}
+	return publicKey
+}
+func (r *relay) GetRelayChain() RelayChain { return identifier(r.relay.GetMeta().GetRelayChain()) }
+func (r *relay) GetGeoZone() GeoZone       { return identifier(r.relay.GetMeta().GetGeoZone()) }
+func (r *relay) GetToken() AAT             { return r.relay.GetMeta().GetToken() }
+func (r *relay) GetSignature() string      { return r.relay.GetMeta().GetSignature() }
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pokt-network/pocket/persistence/kvstore"
+	"github.com/pokt-network/pocket/runtime/configs"
+	"github.com/pokt-network/pocket/shared/codec"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRelayChain       = "0001"
+	testGeoZone          = "0000"
+	testCurrentHeight    = int64(5)
+	testBlocksPerSession = 4
+	testAppMaxRelays     = "1000"
+	testSessionHeight    = testCurrentHeight - testCurrentHeight%testBlocksPerSession
+)
+
+type testServicer struct {
+	servicerKey crypto.PrivateKey
+	appKey      crypto.PrivateKey
+	clientKey   crypto.PrivateKey
+	store       kvstore.RelayStore
+
+	servicerInSession bool
+	appMaxRelays      string
+	height            int64 // the height of the utility context used by the claim & proof tests
+}
+
+func newTestServicer(t *testing.T) *testServicer {
+	servicerKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	appKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	clientKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	store := kvstore.NewMemRelayStore()
+	t.Cleanup(func() { require.NoError(t, store.Stop()) })
+	return &testServicer{
+		servicerKey:       servicerKey,
+		appKey:            appKey,
+		clientKey:         clientKey,
+		store:             store,
+		servicerInSession: true,
+		appMaxRelays:      testAppMaxRelays,
+	}
+}
+
+func TestRelay_ValidateStoreExecute(t *testing.T) {
+	chainNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Path, body)
+	}))
+	defer chainNode.Close()
+
+	ts := newTestServicer(t)
+	r := NewRelay(ts.newBusMock(t, chainNode.URL), ts.newSignedRelay(t, testCurrentHeight))
+
+	require.NoError(t, r.Validate())
+	require.NoError(t, r.Store())
+	require.Equal(t, 1, ts.relayCount(t))
+
+	response, err := r.Execute()
+	require.NoError(t, err)
+	require.Equal(t, `POST /v1/query {"method":"eth_blockNumber"}`, response.GetPayload())
+
+	signBytes, err := (&types.RelayResponse{Payload: response.GetPayload()}).SignBytes()
+	require.NoError(t, err)
+	signature, er := hex.DecodeString(response.GetServicerSignature())
+	require.NoError(t, er)
+	require.True(t, ts.servicerKey.PublicKey().Verify(signBytes, signature))
+}
+
+func TestRelay_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(ts *testServicer, relay *types.Relay)
+		expectedCode types.Code
+	}{
+		{
+			name: "invalid client signature",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				relay.Payload.Data = "tampered"
+			},
+			expectedCode: types.CodeSignatureVerificationFailedError,
+		},
+		{
+			name: "invalid application signature",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				relay.Meta.Token.ClientPublicKey = ts.appKey.PublicKey().String()
+				ts.signRelay(relay)
+			},
+			expectedCode: types.CodeInvalidAATError,
+		},
+		{
+			name: "unsupported relay chain",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				relay.Meta.RelayChain = "0002"
+				ts.signRelay(relay)
+			},
+			expectedCode: types.CodeUnsupportedRelayChainError,
+		},
+		{
+			name: "relay for a previous session",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				relay.Meta.BlockHeight = testCurrentHeight - testBlocksPerSession
+				ts.signRelay(relay)
+			},
+			expectedCode: types.CodeInvalidSessionHeightError,
+		},
+		{
+			name: "servicer not in session",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				ts.servicerInSession = false
+			},
+			expectedCode: types.CodeServicerNotInSessionError,
+		},
+		{
+			name: "application over serviced",
+			modify: func(ts *testServicer, relay *types.Relay) {
+				ts.appMaxRelays = "1"
+				require.NoError(t, ts.store.StoreRelay(testSessionHeight, ts.appKey.PublicKey().String(), testRelayChain, []byte("hash"), []byte("relay")))
+			},
+			expectedCode: types.CodeOverServicedError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := newTestServicer(t)
+			relay := ts.newSignedRelay(t, testCurrentHeight)
+			test.modify(ts, relay)
+
+			err := NewRelay(ts.newBusMock(t, "http://localhost"), relay).Validate()
+			require.Error(t, err)
+			require.Equal(t, test.expectedCode, err.Code(), err.Error())
+		})
+	}
+}
+
+func TestRelay_ReapStoreForHashCollision(t *testing.T) {
+	ts := newTestServicer(t)
+	bus := ts.newBusMock(t, "http://localhost")
+
+	var hashes []string
+	for i := 0; i < 3; i++ {
+		relay := ts.newSignedRelay(t, testCurrentHeight)
+		relay.Payload.Data = fmt.Sprintf("relay %d", i)
+		ts.signRelay(relay)
+		require.NoError(t, NewRelay(bus, relay).Store())
+
+		hash, err := relay.Hash()
+		require.NoError(t, err)
+		hashes = append(hashes, hex.EncodeToString(hash))
+	}
+
+	volumeRelays, err := NewRelay(bus, nil).ReapStoreForHashCollision(testSessionHeight, hashes[1])
+	require.NoError(t, err)
+	require.Len(t, volumeRelays, 1)
+	require.Equal(t, "relay 1", volumeRelays[0].GetData())
+	require.Equal(t, 3, ts.relayCount(t), "the session relays should be kept until their claim is settled")
+}
+
+func TestRelay_ReportVolumeMetrics(t *testing.T) {
+	var report types.RelayVolumeReport
+	fisherman := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, codec.GetCodec().Unmarshal(body, &report))
+	}))
+	defer fisherman.Close()
+
+	ts := newTestServicer(t)
+	bus := ts.newBusMock(t, "http://localhost")
+	relay := NewRelay(bus, ts.newSignedRelay(t, testCurrentHeight))
+
+	require.NoError(t, relay.ReportVolumeMetrics(fisherman.URL, []Relay{relay}))
+	require.Len(t, report.GetRelays(), 1)
+	require.Equal(t, relay.GetSignature(), report.GetRelays()[0].GetMeta().GetSignature())
+}
+
+func TestRelay_ReportVolumeMetrics_FishermanError(t *testing.T) {
+	fisherman := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer fisherman.Close()
+
+	ts := newTestServicer(t)
+	relay := NewRelay(ts.newBusMock(t, "http://localhost"), ts.newSignedRelay(t, testCurrentHeight))
+
+	err := relay.ReportVolumeMetrics(fisherman.URL, []Relay{relay})
+	require.Error(t, err)
+	require.Equal(t, types.CodeReportVolumeMetricsError, err.Code())
+}
+
+func (ts *testServicer) newSignedRelay(t *testing.T, height int64) *types.Relay {
+	token := &types.AAT{
+		Version:              AATVersion,
+		ApplicationPublicKey: ts.appKey.PublicKey().String(),
+		ClientPublicKey:      ts.clientKey.PublicKey().String(),
+	}
+	tokenSignBytes, err := token.SignBytes()
+	require.NoError(t, err)
+	appSignature, er := ts.appKey.Sign(tokenSignBytes)
+	require.NoError(t, er)
+	token.ApplicationSignature = hex.EncodeToString(appSignature)
+
+	relay := &types.Relay{
+		Payload: &types.RelayPayload{
+			Data:     `{"method":"eth_blockNumber"}`,
+			Method:   http.MethodPost,
+			HttpPath: "/v1/query",
+			Headers:  map[string]string{"Content-Type": "application/json"},
+		},
+		Meta: &types.RelayMeta{
+			BlockHeight:       height,
+			ServicerPublicKey: ts.servicerKey.PublicKey().String(),
+			RelayChain:        testRelayChain,
+			GeoZone:           testGeoZone,
+			Token:             token,
+		},
+	}
+	ts.signRelay(relay)
+	return relay
+}
+
+func (ts *testServicer) signRelay(relay *types.Relay) {
+	signBytes, err := relay.SignBytes()
+	if err != nil {
+		panic(err)
+	}
+	signature, er := ts.clientKey.Sign(signBytes)
+	if er != nil {
+		panic(er)
+	}
+	relay.Meta.Signature = hex.EncodeToString(signature)
+}
+
+func (ts *testServicer) newBusMock(t *testing.T, relayChainURL string) *mockModules.MockBus {
+	ctrl := gomock.NewController(t)
+
+	cfg := &configs.Config{
+		PrivateKey: ts.servicerKey.String(),
+		Servicer: &configs.ServicerConfig{
+			RelayChainUrls: map[string]string{testRelayChain: relayChainURL},
+			TimeoutMsec:    1000,
+		},
+	}
+	runtimeMgrMock := mockModules.NewMockRuntimeMgr(ctrl)
+	runtimeMgrMock.EXPECT().GetConfig().Return(cfg).AnyTimes()
+
+	readCtxMock := mockModules.NewMockPersistenceReadContext(ctrl)
+	readCtxMock.EXPECT().Close().Return(nil).AnyTimes()
+	readCtxMock.EXPECT().GetLatestBlockHeight().Return(uint64(testCurrentHeight), nil).AnyTimes()
+	readCtxMock.EXPECT().GetBlockHash(gomock.Any()).Return(hex.EncodeToString(crypto.SHA3Hash([]byte("block"))), nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(types.BlocksPerSessionParamName, gomock.Any()).Return(testBlocksPerSession, nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(types.ServiceNodesPerSessionParamName, gomock.Any()).Return(1, nil).AnyTimes()
+	readCtxMock.EXPECT().GetIntParam(types.FishermenPerSessionParamName, gomock.Any()).Return(1, nil).AnyTimes()
+	readCtxMock.EXPECT().GetApp(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ []byte, _ int64) (string, string, string, string, string, int64, int64, []string, error) {
+			appPublicKey := ts.appKey.PublicKey()
+			return appPublicKey.Address().String(), appPublicKey.String(), "1000000", ts.appMaxRelays, appPublicKey.Address().String(),
+				types.HeightNotUsed, types.HeightNotUsed, []string{testRelayChain}, nil
+		}).AnyTimes()
+	readCtxMock.EXPECT().GetAllServiceNodes(gomock.Any()).DoAndReturn(func(_ int64) ([]*coreTypes.Actor, error) {
+		serviceNodeKey := ts.servicerKey.PublicKey()
+		if !ts.servicerInSession {
+			otherKey, err := crypto.GeneratePrivateKey()
+			require.NoError(t, err)
+			serviceNodeKey = otherKey.PublicKey()
+		}
+		return []*coreTypes.Actor{{
+			ActorType:       coreTypes.ActorType_ACTOR_TYPE_SERVICENODE,
+			Address:         serviceNodeKey.Address().String(),
+			PublicKey:       serviceNodeKey.String(),
+			Chains:          []string{testRelayChain},
+			PausedHeight:    types.HeightNotUsed,
+			UnstakingHeight: types.HeightNotUsed,
+		}}, nil
+	}).AnyTimes()
+	readCtxMock.EXPECT().GetAllFishermen(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	persistenceMock := mockModules.NewMockPersistenceModule(ctrl)
+	persistenceMock.EXPECT().NewReadContext(gomock.Any()).Return(readCtxMock, nil).AnyTimes()
+	persistenceMock.EXPECT().GetRelayStore().Return(ts.store).AnyTimes()
+
+	busMock := mockModules.NewMockBus(ctrl)
+	busMock.EXPECT().GetRuntimeMgr().Return(runtimeMgrMock).AnyTimes()
+	busMock.EXPECT().GetPersistenceModule().Return(persistenceMock).AnyTimes()
+	return busMock
+}
+
+func (ts *testServicer) relayCount(t *testing.T) int {
+	count, err := ts.store.GetRelayCount(testSessionHeight, ts.appKey.PublicKey().String())
+	require.NoError(t, err)
+	return count
+}
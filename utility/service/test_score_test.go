@@ -0,0 +1,305 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	"github.com/pokt-network/pocket/shared/crypto"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNumSamples     = 8
+	testNumNullSamples = 2
+	testScoreHeight    = testClaimHeight
+)
+
+func TestTestScore_ProveAndPauseServiceNode(t *testing.T) {
+	ts := newTestServicer(t)
+	fisherman := newTestFisherman(t)
+	score, tree := ts.newTestScore(t, fisherman, testNumNullSamples)
+	require.NoError(t, score.ValidateBasic())
+
+	rwCtx := ts.newTestScoreRWContextMock(t, score, fisherman)
+	rwCtx.EXPECT().SetServiceNodePauseHeight(score.ServicerAddress, int64(testScoreHeight+2)).Return(nil).Times(1)
+	rwCtx.EXPECT().EmitEvent(eventOfType(coreTypes.EventType_EVENT_TYPE_PAUSE)).Return(nil).Times(1)
+
+	candidates, err := ts.newUtilityContext(rwCtx, testScoreHeight).GetMessageTestScoreSignerCandidates(score)
+	require.NoError(t, err)
+	require.Contains(t, candidates, score.FishermanAddress)
+
+	// a test score cannot be submitted before the session is over
+	err = ts.newUtilityContext(rwCtx, testSessionHeight+1).HandleMessageTestScore(score)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+
+	require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score))
+
+	// a servicer can only be scored once per session by a fisherman
+	err = ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score)
+	require.Error(t, err)
+	require.Equal(t, types.CodeTestScoreExistsError, err.Code())
+
+	pause := newTestScorePause(t, score, tree)
+	require.NoError(t, pause.ValidateBasic())
+
+	// a servicer cannot be paused on an unproven test score
+	err = ts.newUtilityContext(rwCtx, testScoreHeight+1).HandleMessageFishermanPauseServiceNode(pause)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+
+	proof := newTestScoreProof(t, score, tree, testBlockHash(testScoreHeight))
+	require.NoError(t, proof.ValidateBasic())
+
+	// a test score cannot be proven in the block it was submitted in
+	err = ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageProveTestScore(proof)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+
+	require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight+1).HandleMessageProveTestScore(proof))
+
+	err = ts.newUtilityContext(rwCtx, testScoreHeight+2).HandleMessageProveTestScore(proof)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+
+	require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight+2).HandleMessageFishermanPauseServiceNode(pause))
+
+	// the settled test score cannot pause the servicer again
+	err = ts.newUtilityContext(rwCtx, testScoreHeight+3).HandleMessageFishermanPauseServiceNode(pause)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+}
+
+func TestTestScore_InvalidNullSamples(t *testing.T) {
+	ts := newTestServicer(t)
+	fisherman := newTestFisherman(t)
+	score, tree := ts.newTestScore(t, fisherman, testNumNullSamples)
+
+	tests := []struct {
+		name   string
+		modify func(pause *types.MessageFishermanPauseServiceNode)
+	}{
+		{"missing null sample", func(pause *types.MessageFishermanPauseServiceNode) { pause.NullSamples = pause.NullSamples[1:] }},
+		{"duplicate null sample", func(pause *types.MessageFishermanPauseServiceNode) { pause.NullSamples[1] = pause.NullSamples[0] }},
+		{"wrong leaf index", func(pause *types.MessageFishermanPauseServiceNode) {
+			pause.NullSamples[0].LeafIndex = testNumSamples - 1
+		}},
+		{"tampered null sample", func(pause *types.MessageFishermanPauseServiceNode) {
+			pause.NullSamples[0].Relay.Payload.Data = "tampered"
+		}},
+		{"different servicer", func(pause *types.MessageFishermanPauseServiceNode) {
+			pause.NullSamples[0].Relay.Meta.ServicerPublicKey = fisherman.GetPublicKey()
+			ts.signRelay(pause.NullSamples[0].Relay)
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rwCtx := ts.newTestScoreRWContextMock(t, score, fisherman)
+			require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score))
+			require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight+1).HandleMessageProveTestScore(newTestScoreProof(t, score, tree, testBlockHash(testScoreHeight))))
+
+			pause := newTestScorePause(t, score, tree)
+			test.modify(pause)
+
+			err := ts.newUtilityContext(rwCtx, testScoreHeight+2).HandleMessageFishermanPauseServiceNode(pause)
+			require.Error(t, err)
+			require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+		})
+	}
+
+	// the null samples must be proven
+	pause := newTestScorePause(t, score, tree)
+	pause.NullSamples = nil
+	require.Error(t, pause.ValidateBasic())
+}
+
+func TestTestScore_InvalidTestScores(t *testing.T) {
+	ts := newTestServicer(t)
+	fisherman := newTestFisherman(t)
+
+	// the fisherman must be part of the session
+	score, _ := ts.newTestScore(t, fisherman, testNumNullSamples)
+	rwCtx := ts.newTestScoreRWContextMock(t, score, newTestFisherman(t))
+	err := ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score)
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+
+	// there cannot be more null samples than samples
+	score, _ = ts.newTestScore(t, fisherman, testNumSamples+1)
+	require.Error(t, score.ValidateBasic())
+}
+
+func TestTestScore_InvalidProofs(t *testing.T) {
+	ts := newTestServicer(t)
+	fisherman := newTestFisherman(t)
+	score, tree := ts.newTestScore(t, fisherman, testNumNullSamples)
+
+	tests := []struct {
+		name   string
+		modify func(proof *types.MessageProveTestScore)
+	}{
+		{"wrong sample", func(proof *types.MessageProveTestScore) { proof.LeafIndex = (proof.LeafIndex + 1) % testNumSamples }},
+		{"missing side node", func(proof *types.MessageProveTestScore) { proof.SideNodes = proof.SideNodes[1:] }},
+		{"tampered sample", func(proof *types.MessageProveTestScore) { proof.Relay.Payload.Data = "tampered" }},
+		{"different servicer", func(proof *types.MessageProveTestScore) {
+			proof.Relay.Meta.ServicerPublicKey = fisherman.GetPublicKey()
+			ts.signRelay(proof.Relay)
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rwCtx := ts.newTestScoreRWContextMock(t, score, fisherman)
+			require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score))
+
+			proof := newTestScoreProof(t, score, tree, testBlockHash(testScoreHeight))
+			test.modify(proof)
+
+			err := ts.newUtilityContext(rwCtx, testScoreHeight+1).HandleMessageProveTestScore(proof)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTestScore_NoNullSamples(t *testing.T) {
+	ts := newTestServicer(t)
+	fisherman := newTestFisherman(t)
+	score, tree := ts.newTestScore(t, fisherman, 0)
+
+	rwCtx := ts.newTestScoreRWContextMock(t, score, fisherman)
+	require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight).HandleMessageTestScore(score))
+	require.NoError(t, ts.newUtilityContext(rwCtx, testScoreHeight+1).HandleMessageProveTestScore(newTestScoreProof(t, score, tree, testBlockHash(testScoreHeight))))
+
+	// a servicer that answered every sample cannot be paused
+	err := ts.newUtilityContext(rwCtx, testScoreHeight+2).HandleMessageFishermanPauseServiceNode(&types.MessageFishermanPauseServiceNode{
+		FishermanAddress:     score.FishermanAddress,
+		ServicerAddress:      score.ServicerAddress,
+		ApplicationPublicKey: score.ApplicationPublicKey,
+		SessionHeight:        score.SessionHeight,
+		RelayChain:           score.RelayChain,
+	})
+	require.Error(t, err)
+	require.Equal(t, types.CodeInvalidTestScoreError, err.Code())
+}
+
+func newTestFisherman(t *testing.T) *coreTypes.Actor {
+	fishermanKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &coreTypes.Actor{
+		ActorType:       coreTypes.ActorType_ACTOR_TYPE_FISH,
+		Address:         fishermanKey.Address().String(),
+		PublicKey:       fishermanKey.PublicKey().String(),
+		Chains:          []string{testRelayChain},
+		PausedHeight:    types.HeightNotUsed,
+		UnstakingHeight: types.HeightNotUsed,
+	}
+}
+
+// newTestScore returns the test score of the relays sampled by the fisherman along with the tree of the samples
+func (ts *testServicer) newTestScore(t *testing.T, fisherman *coreTypes.Actor, numNullSamples uint64) (*types.MessageTestScore, *types.RelayTree) {
+	fishermanAddress, err := hex.DecodeString(fisherman.GetAddress())
+	require.NoError(t, err)
+
+	tree := types.NewRelayTree()
+	for i := 0; i < testNumSamples; i++ {
+		sample := ts.newSignedRelay(t, testSessionHeight+int64(i%testBlocksPerSession))
+		sample.Payload.Data = fmt.Sprintf(`{"method":"eth_blockNumber","id":%d}`, i)
+		ts.signRelay(sample)
+
+		relayBz, err := sample.Bytes()
+		require.NoError(t, err)
+		require.NoError(t, tree.AddRelay(relayBz))
+	}
+	return &types.MessageTestScore{
+		FishermanAddress:     fishermanAddress,
+		ServicerAddress:      ts.servicerKey.Address(),
+		ApplicationPublicKey: ts.appKey.PublicKey().String(),
+		SessionHeight:        testSessionHeight,
+		RelayChain:           testRelayChain,
+		GeoZone:              testGeoZone,
+		SamplesRoot:          tree.Root(),
+		NumNullSamples:       numNullSamples,
+	}, tree
+}
+
+func newTestScoreProof(t *testing.T, score *types.MessageTestScore, tree *types.RelayTree, scoreBlockHash string) *types.MessageProveTestScore {
+	seed, err := hex.DecodeString(scoreBlockHash)
+	require.NoError(t, err)
+	index := types.RelayProofIndex(seed, tree.Root())
+	// the relay is decoded from its bytes, which makes a copy of the sample that can be tampered with
+	relayBz, sideNodes, er := tree.Prove(index)
+	require.NoError(t, er)
+	relay, er := types.RelayFromBytes(relayBz)
+	require.NoError(t, er)
+	return &types.MessageProveTestScore{
+		FishermanAddress:     score.FishermanAddress,
+		ServicerAddress:      score.ServicerAddress,
+		ApplicationPublicKey: score.ApplicationPublicKey,
+		SessionHeight:        score.SessionHeight,
+		RelayChain:           score.RelayChain,
+		Relay:                relay,
+		LeafIndex:            index,
+		SideNodes:            sideNodes,
+	}
+}
+
+// newTestScorePause returns the message pausing the servicer of the test score, whose null samples are the first
+// leaves of the tree of the samples
+func newTestScorePause(t *testing.T, score *types.MessageTestScore, tree *types.RelayTree) *types.MessageFishermanPauseServiceNode {
+	nullSamples := make([]*types.NullSampleProof, 0, score.NumNullSamples)
+	for i := uint64(0); i < score.NumNullSamples; i++ {
+		relayBz, sideNodes, err := tree.Prove(i)
+		require.NoError(t, err)
+		relay, err := types.RelayFromBytes(relayBz)
+		require.NoError(t, err)
+		nullSamples = append(nullSamples, &types.NullSampleProof{Relay: relay, LeafIndex: i, SideNodes: sideNodes})
+	}
+	return &types.MessageFishermanPauseServiceNode{
+		FishermanAddress:     score.FishermanAddress,
+		ServicerAddress:      score.ServicerAddress,
+		ApplicationPublicKey: score.ApplicationPublicKey,
+		SessionHeight:        score.SessionHeight,
+		RelayChain:           score.RelayChain,
+		NullSamples:          nullSamples,
+	}
+}
+
+// newTestScoreRWContextMock mocks the persistence context of the test score handlers for a session whose only
+// fisherman candidate is `fisherman`. The test score only exists once inserted.
+func (ts *testServicer) newTestScoreRWContextMock(t *testing.T, score *types.MessageTestScore, fisherman *coreTypes.Actor) *mockModules.MockPersistenceRWContext {
+	rwCtx := ts.newSessionRWContextMock(t, fisherman)
+
+	scoreHeight, provenHeight, settledHeight := types.HeightNotUsed, types.HeightNotUsed, types.HeightNotUsed
+
+	rwCtx.EXPECT().GetFishermanOutputAddress(score.FishermanAddress, gomock.Any()).Return(score.FishermanAddress, nil).AnyTimes()
+	rwCtx.EXPECT().GetServiceNodePauseHeightIfExists(score.ServicerAddress, gomock.Any()).Return(types.HeightNotUsed, nil).AnyTimes()
+
+	rwCtx.EXPECT().GetTestScoreExists(score.FishermanAddress, score.ServicerAddress, score.ApplicationPublicKey, score.SessionHeight, score.RelayChain, gomock.Any()).
+		DoAndReturn(func(_, _ []byte, _ string, _ int64, _ string, _ int64) (bool, error) {
+			return scoreHeight != types.HeightNotUsed, nil
+		}).AnyTimes()
+	rwCtx.EXPECT().InsertTestScore(score.FishermanAddress, score.ServicerAddress, score.ApplicationPublicKey, score.SessionHeight, score.RelayChain, score.GeoZone, score.SamplesRoot, score.NumNullSamples).
+		DoAndReturn(func(_, _ []byte, _ string, _ int64, _, _ string, _ []byte, _ uint64) error {
+			scoreHeight = ts.height
+			return nil
+		}).MaxTimes(1)
+	rwCtx.EXPECT().GetTestScore(score.FishermanAddress, score.ServicerAddress, score.ApplicationPublicKey, score.SessionHeight, score.RelayChain, gomock.Any()).
+		DoAndReturn(func(_, _ []byte, _ string, _ int64, _ string, _ int64) (string, []byte, uint64, int64, int64, int64, error) {
+			return score.GeoZone, score.SamplesRoot, score.NumNullSamples, scoreHeight, provenHeight, settledHeight, nil
+		}).AnyTimes()
+	rwCtx.EXPECT().SetTestScoreProvenHeight(score.FishermanAddress, score.ServicerAddress, score.ApplicationPublicKey, score.SessionHeight, score.RelayChain, gomock.Any()).
+		DoAndReturn(func(_, _ []byte, _ string, _ int64, _ string, height int64) error {
+			provenHeight = height
+			return nil
+		}).MaxTimes(1)
+	rwCtx.EXPECT().SetTestScoreSettledHeight(score.FishermanAddress, score.ServicerAddress, score.ApplicationPublicKey, score.SessionHeight, score.RelayChain, gomock.Any()).
+		DoAndReturn(func(_, _ []byte, _ string, _ int64, _ string, height int64) error {
+			settledHeight = height
+			return nil
+		}).MaxTimes(1)
+	return rwCtx
+}
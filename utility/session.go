@@ -3,10 +3,13 @@ package utility
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"sort"
 
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
 	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/pokt-network/pocket/shared/modules"
 	"github.com/pokt-network/pocket/utility/types"
+	"golang.org/x/exp/slices"
 )
 
 type Session interface {
@@ -31,6 +34,7 @@ type Identifier interface {
 var _ Session = &session{}
 
 type session struct {
+	readCtx       modules.PersistenceReadContext
 	serviceNodes  []*coreTypes.Actor
 	fishermen     []*coreTypes.Actor
 	application   *coreTypes.Actor
@@ -41,6 +45,26 @@ type session struct {
 	sessionHeight int64
 }
 
+// NewSession dispatches the session that `application` is part of at `height` for the provided
+// `relayChain` and `geoZone`. The `height` is rounded down to the start of the session using the
+// `blocks_per_session` governance parameter and the 'world state' at that height is used for the selection.
+// Actors do not declare a geo zone when staking, so the candidates are only filtered by `relayChain`: the
+// `geoZone` seeds the session key, which makes the sessions of different geo zones independent selections
+// among the same candidates.
+func NewSession(readCtx modules.PersistenceReadContext, height int64, geoZone GeoZone, relayChain RelayChain, application *coreTypes.Actor) (Session, types.Error) {
+	blocksPerSession, err := readCtx.GetIntParam(types.BlocksPerSessionParamName, height)
+	if err != nil {
+		return nil, types.ErrGetParam(types.BlocksPerSessionParamName, err)
+	}
+	sessionHeight := GetSessionHeight(height, int64(blocksPerSession))
+	blockHash, err := readCtx.GetBlockHash(sessionHeight)
+	if err != nil {
+		return nil, types.ErrGetBlockHash(err)
+	}
+	s := &session{readCtx: readCtx}
+	return s.NewSession(sessionHeight, blockHash, geoZone, relayChain, application)
+}
+
 func (s *session) NewSession(sessionHeight int64, blockHash string, geoZone GeoZone, relayChain RelayChain, application *coreTypes.Actor) (session Session, err types.Error) {
 	s.sessionHeight = sessionHeight
 	s.blockHash = blockHash
@@ -51,8 +75,12 @@ func (s *session) NewSession(sessionHeight int64, blockHash string, geoZone GeoZ
 	if err != nil {
 		return
 	}
-	s.serviceNodes = s.findClosestXServiceNodes()
-	s.fishermen = s.findClosestYFishermen()
+	if s.serviceNodes, err = s.findClosestXServiceNodes(); err != nil {
+		return
+	}
+	if s.fishermen, err = s.findClosestYFishermen(); err != nil {
+		return
+	}
 	return s, nil
 }
 
@@ -69,33 +97,57 @@ func (s *session) sessionKey() ([]byte, types.Error) {
 	if err != nil {
 		return nil, types.ErrNewPublicKeyFromBytes(err)
 	}
-	return concat(sessionHeightBytes, blockHashBz, s.geoZone.Bytes(), s.relayChain.Bytes(), appPubKey.Bytes()), nil
+	return crypto.SHA3Hash(concat(sessionHeightBytes, blockHashBz, s.geoZone.Bytes(), s.relayChain.Bytes(), appPubKey.Bytes())), nil
 }
 
 // uses the current 'world state' to determine the service nodes in the session
 // 1) get an ordered list of the public keys of service nodes who are:
 //    - actively staked
-//    - staked within geo-zone
 //    - staked for relay-chain
-// 2) calls `pseudoRandomSelection(serviceNodes, numberOfNodesPerSession)`
-func (s *session) findClosestXServiceNodes() []*coreTypes.Actor {
-	// IMPORTANT:
-	// THIS IS A DEMONSTRABLE FUNCTION THAT WILL NOT BE IMPLEMENTED AS SUCH
-	// IT EXISTS IN THIS COMMIT PURELY TO COMMUNICATE THE EXPECTED BEHAVIOR
-	return nil
+// 2) calls `pseudoRandomSelection(serviceNodes, serviceNodesPerSession)`
+func (s *session) findClosestXServiceNodes() ([]*coreTypes.Actor, types.Error) {
+	serviceNodesPerSession, err := s.readCtx.GetIntParam(types.ServiceNodesPerSessionParamName, s.sessionHeight)
+	if err != nil {
+		return nil, types.ErrGetServiceNodesPerSessionAt(s.sessionHeight, err)
+	}
+	serviceNodes, err := s.readCtx.GetAllServiceNodes(s.sessionHeight)
+	if err != nil {
+		return nil, types.ErrGetAllServiceNodes(err)
+	}
+	return s.pseudoRandomSelection(s.filterSessionCandidates(serviceNodes), serviceNodesPerSession)
 }
 
 // uses the current 'world state' to determine the fishermen in the session
 // 1) get an ordered list of the public keys of fishermen who are:
 //    - actively staked
-//    - staked within geo-zone
 //    - staked for relay-chain
-// 2) calls `pseudoRandomSelection(fishermen, numberOfFishPerSession)`
-func (s *session) findClosestYFishermen() []*coreTypes.Actor {
-	// IMPORTANT:
-	// THIS IS A DEMONSTRABLE FUNCTION THAT WILL NOT BE IMPLEMENTED AS SUCH
-	// IT EXISTS IN THIS COMMIT PURELY TO COMMUNICATE THE EXPECTED BEHAVIOR
-	return nil
+// 2) calls `pseudoRandomSelection(fishermen, fishermenPerSession)`
+func (s *session) findClosestYFishermen() ([]*coreTypes.Actor, types.Error) {
+	fishermenPerSession, err := s.readCtx.GetIntParam(types.FishermenPerSessionParamName, s.sessionHeight)
+	if err != nil {
+		return nil, types.ErrGetParam(types.FishermenPerSessionParamName, err)
+	}
+	fishermen, err := s.readCtx.GetAllFishermen(s.sessionHeight)
+	if err != nil {
+		return nil, types.ErrGetAllFishermen(err)
+	}
+	return s.pseudoRandomSelection(s.filterSessionCandidates(fishermen), fishermenPerSession)
+}
+
+// filterSessionCandidates returns the actors that are actively staked (i.e. neither paused nor unstaking)
+// for the session's relay chain. There is no geo zone filter since the staking information of the actors
+// does not include one.
+func (s *session) filterSessionCandidates(actors []*coreTypes.Actor) (candidates []*coreTypes.Actor) {
+	for _, actor := range actors {
+		if actor.GetPausedHeight() != types.HeightNotUsed || actor.GetUnstakingHeight() != types.HeightNotUsed {
+			continue
+		}
+		if !slices.Contains(actor.GetChains(), s.relayChain.ID()) {
+			continue
+		}
+		candidates = append(candidates, actor)
+	}
+	return
 }
 
 // 1) passed an ordered list of the public keys of actors and number of nodes
@@ -106,11 +158,47 @@ func (s *session) findClosestYFishermen() []*coreTypes.Actor {
 // Q) why do we hash to find a newKey between every actor selection?
 // A) pseudo-random selection only works if each iteration is re-randomized
 //    or it would be subject to lexicographical proximity bias attacks
-func (s *session) pseudoRandomSelection(orderedListOfPublicKeys []string, numberOfActorsInSession int) []*coreTypes.Actor {
-	// IMPORTANT:
-	// THIS IS A DEMONSTRABLE FUNCTION THAT WILL NOT BE IMPLEMENTED AS SUCH
-	// IT EXISTS IN THIS COMMIT PURELY TO COMMUNICATE THE EXPECTED BEHAVIOR
-	return nil
+func (s *session) pseudoRandomSelection(actors []*coreTypes.Actor, numberOfActorsInSession int) ([]*coreTypes.Actor, types.Error) {
+	candidates := make([]*coreTypes.Actor, len(actors))
+	copy(candidates, actors)
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].GetPublicKey() < candidates[j].GetPublicKey()
+	})
+
+	if numberOfActorsInSession > len(candidates) {
+		numberOfActorsInSession = len(candidates)
+	}
+	selected := make([]*coreTypes.Actor, 0, numberOfActorsInSession)
+	key := s.key
+	for len(selected) < numberOfActorsInSession {
+		keyHex := hex.EncodeToString(key)
+		// index of the first candidate whose public key is lexicographically greater than or equal to `key`
+		idx := sort.Search(len(candidates), func(i int) bool {
+			return candidates[i].GetPublicKey() >= keyHex
+		})
+		// the actor directly below the key, wrapping around to the end of the list
+		if idx == 0 {
+			idx = len(candidates)
+		}
+		actor := candidates[idx-1]
+		selected = append(selected, actor)
+		candidates = append(candidates[:idx-1], candidates[idx:]...)
+
+		pubKey, err := hex.DecodeString(actor.GetPublicKey())
+		if err != nil {
+			return nil, types.ErrHexDecodeFromString(err)
+		}
+		key = crypto.SHA3Hash(concat(key, pubKey))
+	}
+	return selected, nil
+}
+
+// GetSessionHeight returns the height at which the session containing `height` started
+func GetSessionHeight(height, blocksPerSession int64) int64 {
+	if blocksPerSession <= 0 {
+		return height
+	}
+	return height - height%blocksPerSession
 }
 
 func (s *session) GetServiceNodes() []*coreTypes.Actor {
@@ -0,0 +1,169 @@
+package utility
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	mockModules "github.com/pokt-network/pocket/shared/modules/mocks"
+	"github.com/pokt-network/pocket/utility/types"
+	"github.com/stretchr/testify/require"
+)
+
+// The golden vectors pin the sessions dispatched for fixed inputs: their `expected_*` fields are the output of
+// `NewSession` itself, recorded by running `go test ./utility -run TestSession_NewSession_GoldenVectors -update`.
+// They do not validate the selection against an independent implementation, they only catch unintended changes of
+// the selection, so they must only be regenerated along with an intended change of the session protocol.
+const sessionTestVectorsPath = "testdata/session_vectors.json"
+
+var updateSessionTestVectors = flag.Bool("update", false, "regenerate the expected fields of the session golden vectors")
+
+type sessionTestActor struct {
+	PublicKey       string   `json:"public_key"`
+	Chains          []string `json:"chains"`
+	PausedHeight    int64    `json:"paused_height"`
+	UnstakingHeight int64    `json:"unstaking_height"`
+}
+
+type sessionTestVector struct {
+	Name                   string             `json:"name"`
+	Height                 int64              `json:"height"`
+	BlocksPerSession       int                `json:"blocks_per_session"`
+	ServiceNodesPerSession int                `json:"service_nodes_per_session"`
+	FishermenPerSession    int                `json:"fishermen_per_session"`
+	BlockHash              string             `json:"block_hash"`
+	GeoZone                string             `json:"geo_zone"`
+	RelayChain             string             `json:"relay_chain"`
+	Application            string             `json:"application_public_key"`
+	ServiceNodes           []sessionTestActor `json:"service_nodes"`
+	Fishermen              []sessionTestActor `json:"fishermen"`
+	ExpectedSessionHeight  int64              `json:"expected_session_height"`
+	ExpectedServiceNodes   []string           `json:"expected_service_nodes"`
+	ExpectedFishermen      []string           `json:"expected_fishermen"`
+}
+
+// testIdentifier is a minimal `Identifier` implementation used for relay chains and geo zones in tests
+type testIdentifier string
+
+func (i testIdentifier) Name() string  { return string(i) }
+func (i testIdentifier) ID() string    { return string(i) }
+func (i testIdentifier) Bytes() []byte { return []byte(i) }
+
+func TestSession_NewSession_GoldenVectors(t *testing.T) {
+	vectors := loadSessionTestVectors(t)
+	require.NotEmpty(t, vectors)
+
+	for i, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			readCtx := newSessionReadContextMock(t, vector)
+			application := &coreTypes.Actor{
+				ActorType: coreTypes.ActorType_ACTOR_TYPE_APP,
+				PublicKey: vector.Application,
+			}
+
+			s, err := NewSession(readCtx, vector.Height, testIdentifier(vector.GeoZone), testIdentifier(vector.RelayChain), application)
+			require.NoError(t, err)
+
+			if *updateSessionTestVectors {
+				vectors[i].ExpectedSessionHeight = s.GetSessionHeight()
+				vectors[i].ExpectedServiceNodes = actorPublicKeys(s.GetServiceNodes())
+				vectors[i].ExpectedFishermen = actorPublicKeys(s.GetFishermen())
+				return
+			}
+			require.Equal(t, vector.ExpectedSessionHeight, s.GetSessionHeight())
+			require.Equal(t, vector.ExpectedServiceNodes, actorPublicKeys(s.GetServiceNodes()))
+			require.Equal(t, vector.ExpectedFishermen, actorPublicKeys(s.GetFishermen()))
+			require.Equal(t, application, s.GetApplication())
+		})
+	}
+
+	if *updateSessionTestVectors {
+		bz, err := json.MarshalIndent(vectors, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(sessionTestVectorsPath, append(bz, '\n'), 0o644))
+	}
+}
+
+func TestSession_NewSession_Deterministic(t *testing.T) {
+	vectors := loadSessionTestVectors(t)
+	require.NotEmpty(t, vectors)
+	vector := vectors[0]
+
+	application := &coreTypes.Actor{PublicKey: vector.Application}
+	s1, err := NewSession(newSessionReadContextMock(t, vector), vector.Height, testIdentifier(vector.GeoZone), testIdentifier(vector.RelayChain), application)
+	require.NoError(t, err)
+
+	// Any height within the same session must dispatch the same session
+	s2, err := NewSession(newSessionReadContextMock(t, vector), vector.ExpectedSessionHeight, testIdentifier(vector.GeoZone), testIdentifier(vector.RelayChain), application)
+	require.NoError(t, err)
+
+	require.Equal(t, s1.GetSessionHeight(), s2.GetSessionHeight())
+	require.Equal(t, actorPublicKeys(s1.GetServiceNodes()), actorPublicKeys(s2.GetServiceNodes()))
+	require.Equal(t, actorPublicKeys(s1.GetFishermen()), actorPublicKeys(s2.GetFishermen()))
+}
+
+func TestSession_GetSessionHeight(t *testing.T) {
+	tests := []struct {
+		height           int64
+		blocksPerSession int64
+		expected         int64
+	}{
+		{0, 4, 0},
+		{3, 4, 0},
+		{4, 4, 4},
+		{11, 4, 8},
+		{11, 1, 11},
+		{11, 0, 11},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, GetSessionHeight(test.height, test.blocksPerSession))
+	}
+}
+
+func loadSessionTestVectors(t *testing.T) []sessionTestVector {
+	bz, err := os.ReadFile(sessionTestVectorsPath)
+	require.NoError(t, err)
+
+	var vectors []sessionTestVector
+	require.NoError(t, json.Unmarshal(bz, &vectors))
+	return vectors
+}
+
+func newSessionReadContextMock(t *testing.T, vector sessionTestVector) *mockModules.MockPersistenceReadContext {
+	ctrl := gomock.NewController(t)
+	readCtx := mockModules.NewMockPersistenceReadContext(ctrl)
+
+	sessionHeight := vector.ExpectedSessionHeight
+	readCtx.EXPECT().GetIntParam(types.BlocksPerSessionParamName, gomock.Any()).Return(vector.BlocksPerSession, nil).AnyTimes()
+	readCtx.EXPECT().GetIntParam(types.ServiceNodesPerSessionParamName, sessionHeight).Return(vector.ServiceNodesPerSession, nil).AnyTimes()
+	readCtx.EXPECT().GetIntParam(types.FishermenPerSessionParamName, sessionHeight).Return(vector.FishermenPerSession, nil).AnyTimes()
+	readCtx.EXPECT().GetBlockHash(sessionHeight).Return(vector.BlockHash, nil).AnyTimes()
+	readCtx.EXPECT().GetAllServiceNodes(sessionHeight).Return(toSessionTestActors(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, vector.ServiceNodes), nil).AnyTimes()
+	readCtx.EXPECT().GetAllFishermen(sessionHeight).Return(toSessionTestActors(coreTypes.ActorType_ACTOR_TYPE_FISH, vector.Fishermen), nil).AnyTimes()
+
+	return readCtx
+}
+
+func toSessionTestActors(actorType coreTypes.ActorType, testActors []sessionTestActor) (actors []*coreTypes.Actor) {
+	for _, a := range testActors {
+		actors = append(actors, &coreTypes.Actor{
+			ActorType:       actorType,
+			PublicKey:       a.PublicKey,
+			Chains:          a.Chains,
+			PausedHeight:    a.PausedHeight,
+			UnstakingHeight: a.UnstakingHeight,
+		})
+	}
+	return
+}
+
+func actorPublicKeys(actors []*coreTypes.Actor) []string {
+	publicKeys := make([]string, 0, len(actors))
+	for _, actor := range actors {
+		publicKeys = append(publicKeys, actor.GetPublicKey())
+	}
+	return publicKeys
+}
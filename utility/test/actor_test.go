@@ -106,6 +106,45 @@ func TestUtilityContext_HandleMessageEditStake(t *testing.T) {
 	}
 }
 
+func TestUtilityContext_HandleMessagePause(t *testing.T) {
+	for _, actorType := range actorTypes {
+		t.Run(fmt.Sprintf("%s.HandleMessagePause", actorType.String()), func(t *testing.T) {
+			ctx := NewTestingUtilityContext(t, 1)
+
+			actor := getFirstActor(t, ctx, actorType)
+			addr := actor.GetAddress()
+			addrBz, err := hex.DecodeString(addr)
+			require.NoError(t, err)
+			require.Equal(t, int64(-1), actor.GetPausedHeight())
+
+			msgPauseActor := &typesUtil.MessagePause{
+				Address:   addrBz,
+				Signer:    addrBz,
+				ActorType: actorType,
+			}
+
+			err = ctx.HandlePauseMessage(msgPauseActor)
+			require.NoError(t, err, "handle pause message")
+
+			actor = getActorByAddr(t, ctx, actorType, addr)
+			require.Equal(t, int64(1), actor.GetPausedHeight())
+
+			er := ctx.HandlePauseMessage(msgPauseActor)
+			require.Equal(t, typesUtil.ErrAlreadyPaused().Code(), er.Code(), "an actor cannot be paused twice")
+
+			// the actor cannot unpause before the minimum pause blocks have passed
+			er = ctx.HandleUnpauseMessage(&typesUtil.MessageUnpause{
+				Address:   addrBz,
+				Signer:    addrBz,
+				ActorType: actorType,
+			})
+			require.Equal(t, typesUtil.ErrNotReadyToUnpause().Code(), er.Code())
+
+			test_artifacts.CleanupTest(ctx)
+		})
+	}
+}
+
 func TestUtilityContext_HandleMessageUnpause(t *testing.T) {
 	for _, actorType := range actorTypes {
 		t.Run(fmt.Sprintf("%s.HandleMessageUnpause", actorType.String()), func(t *testing.T) {
@@ -370,6 +409,31 @@ func TestUtilityContext_GetMessageEditStakeSignerCandidates(t *testing.T) {
 	}
 }
 
+func TestUtilityContext_GetMessagePauseSignerCandidates(t *testing.T) {
+	for _, actorType := range actorTypes {
+		t.Run(fmt.Sprintf("%s.GetMessagePauseSignerCandidates", actorType.String()), func(t *testing.T) {
+			ctx := NewTestingUtilityContext(t, 0)
+			actor := getFirstActor(t, ctx, actorType)
+
+			addrBz, err := hex.DecodeString(actor.GetAddress())
+			require.NoError(t, err)
+
+			msg := &typesUtil.MessagePause{
+				Address:   addrBz,
+				ActorType: actorType,
+			}
+			candidates, err := ctx.GetMessagePauseSignerCandidates(msg)
+			require.NoError(t, err)
+
+			require.Equal(t, len(candidates), 2, "unexpected number of candidates")
+			require.Equal(t, actor.GetOutput(), hex.EncodeToString(candidates[0]), "incorrect output candidate")
+			require.Equal(t, actor.GetAddress(), hex.EncodeToString(candidates[1]), "incorrect addr candidate")
+
+			test_artifacts.CleanupTest(ctx)
+		})
+	}
+}
+
 func TestUtilityContext_GetMessageUnpauseSignerCandidates(t *testing.T) {
 	for _, actorType := range actorTypes {
 		t.Run(fmt.Sprintf("%s.GetMessageUnpauseSignerCandidates", actorType.String()), func(t *testing.T) {
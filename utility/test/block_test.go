@@ -7,6 +7,7 @@ import (
 
 	"github.com/pokt-network/pocket/runtime/test_artifacts"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	utilTypes "github.com/pokt-network/pocket/utility/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -130,3 +131,116 @@ func TestUtilityContext_EndBlock(t *testing.T) {
 
 	test_artifacts.CleanupTest(ctx)
 }
+
+func TestUtilityContext_EndBlockWithFee(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+	tx, _, _, signer := newTestingTransaction(t, ctx)
+
+	// A fee whose proposer share is not a whole number of uPOKT
+	minimumFee, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+	fee := new(big.Int).Add(minimumFee, big.NewInt(1e12+1))
+	tx.Fee = utilTypes.BigIntToString(fee)
+	require.NoError(t, tx.Sign(signer))
+	txBz, err := tx.Bytes()
+	require.NoError(t, err)
+
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	addrBz, er := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, er)
+	proposerBeforeBalance, err := ctx.GetAccountAmount(addrBz)
+	require.NoError(t, err)
+	daoBeforeBalance, err := ctx.GetPoolAmount(coreTypes.Pools_POOLS_DAO.FriendlyName())
+	require.NoError(t, err)
+
+	require.NoError(t, ctx.SetProposalBlock("", addrBz, [][]byte{txBz}))
+	_, er = ctx.ApplyBlock()
+	require.NoError(t, er)
+
+	proposerCutPercentage, err := ctx.GetProposerPercentageOfFees()
+	require.NoError(t, err)
+	expectedProposerReward := new(big.Int).Mul(fee, big.NewInt(int64(proposerCutPercentage)))
+	expectedProposerReward.Quo(expectedProposerReward, big.NewInt(100))
+
+	proposerAfterBalance, err := ctx.GetAccountAmount(addrBz)
+	require.NoError(t, err)
+	require.Equal(t, expectedProposerReward, new(big.Int).Sub(proposerAfterBalance, proposerBeforeBalance))
+	daoAfterBalance, err := ctx.GetPoolAmount(coreTypes.Pools_POOLS_DAO.FriendlyName())
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(fee, expectedProposerReward), new(big.Int).Sub(daoAfterBalance, daoBeforeBalance))
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_ApplyBlockEmitsEvents(t *testing.T) {
+	height := int64(1)
+	ctx := NewTestingUtilityContext(t, height)
+	tx, _, amountSent, signer := newTestingTransaction(t, ctx)
+
+	txBz, er := tx.Bytes()
+	require.NoError(t, er)
+	txHash, er := tx.Hash()
+	require.NoError(t, er)
+
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	proposerAddr, err := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, err)
+
+	require.NoError(t, ctx.SetProposalBlock("", proposerAddr, [][]byte{txBz}))
+	_, err = ctx.ApplyBlock()
+	require.NoError(t, err)
+	feeBig, er := ctx.GetMessageSendFee()
+	require.NoError(t, er)
+	require.NoError(t, ctx.Commit([]byte("placeholderQuorumCert")))
+
+	events, err := testPersistenceMod.GetEventsByHeight(height)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	for i, event := range events {
+		require.Equal(t, height, event.Height)
+		require.Equal(t, int32(i), event.Index)
+	}
+
+	fee, transfer, proposerReward, daoReward := events[0], events[1], events[2], events[3]
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_FEE, fee.Type)
+	require.Equal(t, coreTypes.BlockPhase_BLOCK_PHASE_DELIVER_TX, fee.Phase)
+	require.Equal(t, txHash, fee.TxHash)
+	require.Equal(t, signer.Address().String(), fee.Address)
+	require.Equal(t, coreTypes.Pools_POOLS_FEE_COLLECTOR.FriendlyName(), fee.Pool)
+	require.Equal(t, feeBig.String(), fee.Amount)
+
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_TRANSFER, transfer.Type)
+	require.Equal(t, coreTypes.BlockPhase_BLOCK_PHASE_DELIVER_TX, transfer.Phase)
+	require.Equal(t, txHash, transfer.TxHash)
+	require.Equal(t, signer.Address().String(), transfer.Address)
+	require.Equal(t, amountSent.String(), transfer.Amount)
+
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_PROPOSER_REWARD, proposerReward.Type)
+	require.Equal(t, coreTypes.BlockPhase_BLOCK_PHASE_END_BLOCK, proposerReward.Phase)
+	require.Empty(t, proposerReward.TxHash)
+	require.Equal(t, proposer.GetAddress(), proposerReward.Address)
+
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_DAO_REWARD, daoReward.Type)
+	require.Equal(t, coreTypes.Pools_POOLS_DAO.FriendlyName(), daoReward.Pool)
+
+	// the proposer's share and the DAO's share add up to the fee
+	proposerAmount, ok := new(big.Int).SetString(proposerReward.Amount, 10)
+	require.True(t, ok)
+	daoAmount, ok := new(big.Int).SetString(daoReward.Amount, 10)
+	require.True(t, ok)
+	require.Equal(t, feeBig, new(big.Int).Add(proposerAmount, daoAmount))
+
+	// the signer sees both the fee and the transfer, the recipient only the transfer
+	signerEvents, err := testPersistenceMod.GetEventsByAddress(signer.Address())
+	require.NoError(t, err)
+	require.Len(t, signerEvents, 2)
+	require.Equal(t, fee.Index, signerEvents[0].Index)
+	require.Equal(t, transfer.Index, signerEvents[1].Index)
+
+	recipientAddr, err := hex.DecodeString(transfer.Counterparty)
+	require.NoError(t, err)
+	recipientEvents, err := testPersistenceMod.GetEventsByAddress(recipientAddr)
+	require.NoError(t, err)
+	require.Len(t, recipientEvents, 1)
+	require.Equal(t, coreTypes.EventType_EVENT_TYPE_TRANSFER, recipientEvents[0].Type)
+}
@@ -615,6 +615,50 @@ func TestUtilityContext_GetValidatorUnstakingBlocks(t *testing.T) {
 	test_artifacts.CleanupTest(ctx)
 }
 
+func TestUtilityContext_GetMessageClaimFee(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+	defaultParams := DefaultTestingParams(t)
+	defaultParam := defaultParams.GetMessageClaimFee()
+	gotParam, err := ctx.GetMessageClaimFee()
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, typesUtil.BigIntToString(gotParam))
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_GetMessageProofFee(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+	defaultParams := DefaultTestingParams(t)
+	defaultParam := defaultParams.GetMessageProofFee()
+	gotParam, err := ctx.GetMessageProofFee()
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, typesUtil.BigIntToString(gotParam))
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_GetRelaysToTokensMultiplier(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+	defaultParams := DefaultTestingParams(t)
+	defaultParam := defaultParams.GetRelaysToTokensMultiplier()
+	gotParam, err := ctx.GetRelaysToTokensMultiplier()
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, typesUtil.BigIntToString(gotParam))
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_GetClaimExpirationBlocks(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+	defaultParams := DefaultTestingParams(t)
+	defaultParam := int64(defaultParams.GetClaimExpirationBlocks())
+	gotParam, err := ctx.GetClaimExpirationBlocks()
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, gotParam)
+
+	test_artifacts.CleanupTest(ctx)
+}
+
 func TestUtilityContext_HandleMessageChangeParameter(t *testing.T) {
 	cdc := codec.GetCodec()
 	ctx := NewTestingUtilityContext(t, 0)
@@ -865,6 +909,26 @@ func TestUtilityContext_GetParamOwner(t *testing.T) {
 	gotParam, err = ctx.GetParamOwner(typesUtil.MessageChangeParameterFee)
 	require.NoError(t, err)
 	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetMessageClaimFeeOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.MessageClaimFee)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetMessageProofFeeOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.MessageProofFee)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetRelaysToTokensMultiplierOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.RelaysToTokensMultiplierParamName)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetClaimExpirationBlocksOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.ClaimExpirationBlocksParamName)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetFishermenPerSessionOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.FishermenPerSessionParamName)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
 	// owners
 	defaultParam = defaultParams.GetAclOwner()
 	gotParam, err = ctx.GetParamOwner(typesUtil.BlocksPerSessionOwner)
@@ -1075,6 +1139,26 @@ func TestUtilityContext_GetParamOwner(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
 	defaultParam = defaultParams.GetAclOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.MessageClaimFeeOwner)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetAclOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.MessageProofFeeOwner)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetAclOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.RelaysToTokensMultiplierOwner)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetAclOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.ClaimExpirationBlocksOwner)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetAclOwner()
+	gotParam, err = ctx.GetParamOwner(typesUtil.FishermenPerSessionOwner)
+	require.NoError(t, err)
+	require.Equal(t, defaultParam, hex.EncodeToString(gotParam))
+	defaultParam = defaultParams.GetAclOwner()
 	gotParam, err = ctx.GetParamOwner(typesUtil.MessageChangeParameterFeeOwner)
 	require.NoError(t, err)
 	defaultParamBz, err := hex.DecodeString(defaultParam)
@@ -34,7 +34,6 @@ var (
 	defaultUnstaking   = int64(2017)
 	defaultNonceString = utilTypes.BigIntToString(test_artifacts.DefaultAccountAmount)
 
-	testNonce           = "defaultNonceString"
 	testSchema          = "test_schema"
 	testMessageSendType = "MessageSend"
 )
@@ -54,8 +53,8 @@ func NewTestingMempool(_ *testing.T) utilTypes.Mempool {
 }
 
 func TestMain(m *testing.M) {
-	pool, resource, dbUrl := test_artifacts.SetupPostgresDocker()
-	runtimeCfg := newTestRuntimeConfig(dbUrl)
+	databaseCfg, cleanupDatabase := test_artifacts.SetupTestDatabase()
+	runtimeCfg := newTestRuntimeConfig(databaseCfg)
 	bus, err := runtime.CreateBus(runtimeCfg)
 	if err != nil {
 		log.Fatalf("Error creating bus: %s", err)
@@ -65,7 +64,7 @@ func TestMain(m *testing.M) {
 	testPersistenceMod = newTestPersistenceModule(bus)
 
 	exitCode := m.Run()
-	test_artifacts.CleanupPostgresDocker(m, pool, resource)
+	cleanupDatabase()
 	os.Exit(exitCode)
 }
 
@@ -99,10 +98,12 @@ func NewTestingUtilityContext(t *testing.T, height int64) utility.UtilityContext
 	}
 }
 
-func newTestRuntimeConfig(databaseUrl string) *runtime.Manager {
+func newTestRuntimeConfig(databaseCfg *configs.PersistenceConfig) *runtime.Manager {
 	cfg := &configs.Config{
 		Persistence: &configs.PersistenceConfig{
-			PostgresUrl:       databaseUrl,
+			SqlDriver:         databaseCfg.SqlDriver,
+			PostgresUrl:       databaseCfg.PostgresUrl,
+			SqlitePath:        databaseCfg.SqlitePath,
 			NodeSchema:        testSchema,
 			BlockStorePath:    "",
 			TxIndexerPath:     "",
@@ -116,6 +117,8 @@ func newTestRuntimeConfig(databaseUrl string) *runtime.Manager {
 		Utility: &configs.UtilityConfig{
 			MaxMempoolTransactionBytes: 1000000,
 			MaxMempoolTransactions:     1000,
+			MempoolType:                utilTypes.PriorityMempoolType,
+			MempoolTransactionTtlMsec:  3600000,
 		},
 	}
 	genesisState, _ := test_artifacts.NewGenesisState(
@@ -167,10 +170,11 @@ func newTestPersistenceModule(bus modules.Bus) modules.PersistenceModule {
 
 func requireValidTestingTxResults(t *testing.T, tx *utilTypes.Transaction, txResults []modules.TxResult) {
 	for _, txResult := range txResults {
-		msg, err := tx.GetMessage()
-		sendMsg, ok := msg.(*utilTypes.MessageSend)
-		require.True(t, ok)
+		msgs, err := tx.Messages()
 		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		sendMsg, ok := msgs[0].(*utilTypes.MessageSend)
+		require.True(t, ok)
 		require.Equal(t, int32(0), txResult.GetResultCode())
 		require.Equal(t, "", txResult.GetError())
 		require.Equal(t, testMessageSendType, txResult.GetMessageType())
@@ -13,6 +13,7 @@ import (
 	typesUtil "github.com/pokt-network/pocket/utility/types"
 	utilTypes "github.com/pokt-network/pocket/utility/types"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
@@ -37,6 +38,109 @@ func TestUtilityContext_AnteHandleMessage(t *testing.T) {
 	test_artifacts.CleanupTest(ctx)
 }
 
+func TestUtilityContext_AnteHandleMessageWithFee(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+
+	tx, startingBalance, _, signer := newTestingTransaction(t, ctx)
+	minimumFee, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+
+	// A fee below the minimum fee of the message is refused
+	tx.Fee = typesUtil.BigIntToString(new(big.Int).Sub(minimumFee, big.NewInt(1)))
+	require.NoError(t, tx.Sign(signer))
+	_, _, err = ctx.AnteHandleMessage(tx)
+	require.Equal(t, typesUtil.CodeFeeBelowMinimumError, err.Code())
+
+	// A fee above the minimum fee of the message is paid in full
+	fee := new(big.Int).Add(minimumFee, big.NewInt(1234))
+	tx.Fee = typesUtil.BigIntToString(fee)
+	require.NoError(t, tx.Sign(signer))
+	_, _, err = ctx.AnteHandleMessage(tx)
+	require.NoError(t, err)
+
+	expectedAfterBalance := big.NewInt(0).Sub(startingBalance, fee)
+	amount, err := ctx.GetAccountAmount(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, expectedAfterBalance, amount, "unexpected after balance")
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_AnteHandleMessageWithSequence(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+
+	tx, _, _, signer := newTestingTransaction(t, ctx)
+	_, _, err := ctx.AnteHandleMessage(tx)
+	require.NoError(t, err)
+	sequence, err := ctx.GetAccountSequence(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence, "unexpected sequence after the first transaction")
+
+	// The transaction cannot be replayed
+	_, _, err = ctx.AnteHandleMessage(tx)
+	require.Equal(t, typesUtil.CodeInvalidSequenceError, err.Code())
+
+	// Nor can a transaction skip a sequence
+	tx.Sequence = 2
+	require.NoError(t, tx.Sign(signer))
+	_, _, err = ctx.AnteHandleMessage(tx)
+	require.Equal(t, typesUtil.CodeInvalidSequenceError, err.Code())
+
+	tx.Sequence = 1
+	require.NoError(t, tx.Sign(signer))
+	_, _, err = ctx.AnteHandleMessage(tx)
+	require.NoError(t, err)
+	sequence, err = ctx.GetAccountSequence(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), sequence, "unexpected sequence after the second transaction")
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_AnteHandleMessageWithMultisig(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+
+	members := make([]crypto.PrivateKey, 3)
+	publicKeys := make([]crypto.PublicKey, len(members))
+	for i := range members {
+		member, err := crypto.GeneratePrivateKey()
+		require.NoError(t, err)
+		members[i], publicKeys[i] = member, member.PublicKey()
+	}
+	multisigPublicKey, er := crypto.NewMultisigPublicKey(2, publicKeys)
+	require.NoError(t, er)
+	multisigAddr := multisigPublicKey.Address()
+	startingBalance := new(big.Int).Set(test_artifacts.DefaultAccountAmount)
+	require.NoError(t, ctx.SetAccountAmount(multisigAddr, startingBalance))
+
+	tx := &typesUtil.Transaction{
+		Msgs:              []*anypb.Any{newTestingSendMessageAny(t, multisigAddr, newTestingRecipient(t), defaultSendAmount)},
+		MultisigPublicKey: multisigPublicKey.Bytes(),
+	}
+
+	// A transaction signed by less than the threshold of the members is refused
+	require.NoError(t, tx.SignMultisig(members[0]))
+	_, _, err := ctx.AnteHandleMessage(tx)
+	require.Equal(t, typesUtil.CodeMultisigThresholdError, err.Code())
+
+	require.NoError(t, tx.SignMultisig(members[2]))
+	_, signerString, err := ctx.AnteHandleMessage(tx)
+	require.NoError(t, err)
+	require.Equal(t, multisigAddr.String(), signerString)
+	feeBig, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+
+	expectedAfterBalance := big.NewInt(0).Sub(startingBalance, feeBig)
+	amount, err := ctx.GetAccountAmount(multisigAddr)
+	require.NoError(t, err)
+	require.Equal(t, expectedAfterBalance, amount, "unexpected after balance")
+	sequence, err := ctx.GetAccountSequence(multisigAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence)
+
+	test_artifacts.CleanupTest(ctx)
+}
+
 func TestUtilityContext_ApplyTransaction(t *testing.T) {
 	ctx := NewTestingUtilityContext(t, 0)
 
@@ -57,6 +161,60 @@ func TestUtilityContext_ApplyTransaction(t *testing.T) {
 	test_artifacts.CleanupTest(ctx)
 }
 
+func TestUtilityContext_ApplyTransactionWithMessages(t *testing.T) {
+	ctx := NewTestingUtilityContext(t, 0)
+
+	tx, startingBalance, amount, signer := newTestingTransaction(t, ctx)
+	recipient1, recipient2 := newTestingRecipient(t), newTestingRecipient(t)
+	tx.Msgs = []*anypb.Any{
+		newTestingSendMessageAny(t, signer.Address(), recipient1, amount),
+		newTestingSendMessageAny(t, signer.Address(), recipient2, amount),
+	}
+	require.NoError(t, tx.Sign(signer))
+
+	// Every message is applied, and the minimum fee is paid for each of them
+	txResult, err := ctx.ApplyTransaction(0, tx)
+	require.NoError(t, err)
+	require.Equal(t, int32(0), txResult.GetResultCode())
+	require.Equal(t, []string{recipient1.String(), recipient2.String()}, txResult.GetRecipientAddrs())
+	feeBig, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+
+	expectedAmountSubtracted := new(big.Int).Mul(new(big.Int).Add(amount, feeBig), big.NewInt(2))
+	expectedAfterBalance := new(big.Int).Sub(startingBalance, expectedAmountSubtracted)
+	signerAmount, err := ctx.GetAccountAmount(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, expectedAfterBalance, signerAmount, "unexpected after balance")
+
+	// A failing message reverts the previous ones, but the fee is paid and the sequence is used
+	tx.Msgs = []*anypb.Any{
+		newTestingSendMessageAny(t, signer.Address(), recipient1, amount),
+		newTestingSendMessageAny(t, signer.Address(), recipient2, startingBalance),
+	}
+	tx.Sequence = 1
+	require.NoError(t, tx.Sign(signer))
+	txResult, err = ctx.ApplyTransaction(1, tx)
+	require.NoError(t, err)
+	require.Equal(t, int32(typesUtil.CodeInsufficientAmountError), txResult.GetResultCode())
+	messageResults := txResult.(*typesUtil.DefaultTxResult).GetMessageResults()
+	require.Len(t, messageResults, 2)
+	require.Equal(t, int32(0), messageResults[0].GetResultCode())
+	require.Equal(t, int32(typesUtil.CodeInsufficientAmountError), messageResults[1].GetResultCode())
+
+	expectedAfterBalance.Sub(expectedAfterBalance, new(big.Int).Mul(feeBig, big.NewInt(2)))
+	signerAmount, err = ctx.GetAccountAmount(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, expectedAfterBalance, signerAmount, "unexpected balance after the failing transaction")
+	recipientAmount, err := ctx.GetAccountAmount(recipient1)
+	require.NoError(t, err)
+	require.Equal(t, amount, recipientAmount, "the first message of the failing transaction was not reverted")
+	sequence, err := ctx.GetAccountSequence(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), sequence)
+
+	test_artifacts.CleanupTest(ctx)
+}
+
 func TestUtilityContext_CheckTransaction(t *testing.T) {
 	mockBusInTestModules(t)
 
@@ -75,6 +233,81 @@ func TestUtilityContext_CheckTransaction(t *testing.T) {
 	test_artifacts.CleanupTest(ctx)
 }
 
+func TestUtilityContext_CheckTransactionWithUsedSequence(t *testing.T) {
+	mockBusInTestModules(t)
+
+	ctx := NewTestingUtilityContext(t, 1)
+	tx, _, _, signer := newTestingTransaction(t, ctx)
+
+	// The first sequence of the signer is committed
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	proposerAddr, er := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, er)
+	require.NoError(t, ctx.SetAccountSequence(signer.Address(), 1))
+	require.NoError(t, ctx.SetProposalBlock("", proposerAddr, nil))
+	_, er = ctx.Context.ComputeStateHash()
+	require.NoError(t, er)
+	require.NoError(t, ctx.Commit([]byte("placeholderQuorumCert")))
+
+	txBz, err := tx.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, typesUtil.ErrSequenceAlreadyUsed(0, 1).Error(), testUtilityMod.CheckTransaction(txBz).Error())
+
+	// The transactions ahead of the sequence of the signer wait in the mempool
+	tx.Sequence = 2
+	require.NoError(t, tx.Sign(signer))
+	txBz, err = tx.Bytes()
+	require.NoError(t, err)
+	require.NoError(t, testUtilityMod.CheckTransaction(txBz))
+}
+
+func TestUtilityContext_CommitRemovesTransactionsFromMempool(t *testing.T) {
+	mockBusInTestModules(t)
+
+	ctx := NewTestingUtilityContext(t, 1)
+	tx, _, _, _ := newTestingTransaction(t, ctx)
+
+	txBz, err := tx.Bytes()
+	require.NoError(t, err)
+	require.NoError(t, testUtilityMod.CheckTransaction(txBz))
+
+	// The transaction is committed in a block proposed by another node
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	proposerAddr, er := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, er)
+	require.NoError(t, ctx.SetProposalBlock("", proposerAddr, [][]byte{txBz}))
+	_, er = ctx.Context.ComputeStateHash()
+	require.NoError(t, er)
+	require.NoError(t, ctx.Commit([]byte("placeholderQuorumCert")))
+
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	require.False(t, ctx.Mempool.Contains(hash))
+	require.True(t, ctx.Mempool.IsEmpty())
+}
+
+func TestUtilityModule_EstimateFee(t *testing.T) {
+	mockBusInTestModules(t)
+
+	ctx := NewTestingUtilityContext(t, 0)
+	tx, _, _, _ := newTestingTransaction(t, ctx)
+	minimumFee, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+
+	// Without any transaction in the mempool or the recent blocks, the minimum fee is enough
+	txBz, err := tx.Bytes()
+	require.NoError(t, err)
+	estimatedMinimumFee, estimatedFee, er := testUtilityMod.EstimateFee(txBz)
+	require.NoError(t, er)
+	require.Equal(t, typesUtil.BigIntToString(minimumFee), estimatedMinimumFee)
+	require.Equal(t, estimatedMinimumFee, estimatedFee)
+
+	_, _, er = testUtilityMod.EstimateFee([]byte("not a transaction"))
+	require.Error(t, er)
+
+	test_artifacts.CleanupTest(ctx)
+}
+
 func TestUtilityContext_GetSignerCandidates(t *testing.T) {
 	ctx := NewTestingUtilityContext(t, 0)
 	accs := GetAllTestingAccounts(t, ctx)
@@ -115,6 +348,97 @@ func TestUtilityContext_CreateAndApplyBlock(t *testing.T) {
 	test_artifacts.CleanupTest(ctx)
 }
 
+func TestUtilityContext_CreateAndApplyBlock_RevertsInvalidTransactions(t *testing.T) {
+	mockBusInTestModules(t)
+
+	ctx := NewTestingUtilityContext(t, 1)
+	firstTx, startingBalance, amountSent, firstSigner := newTestingTransaction(t, ctx)
+	invalidTx, _, _, invalidSigner := newTestingTransaction(t, ctx)
+	lastTx, _, _, lastSigner := newTestingTransaction(t, ctx)
+
+	txsBz := make([][]byte, 0)
+	for _, tx := range []*typesUtil.Transaction{firstTx, invalidTx, lastTx} {
+		txBz, er := tx.Bytes()
+		require.NoError(t, er)
+		require.NoError(t, testUtilityMod.CheckTransaction(txBz))
+		txsBz = append(txsBz, txBz)
+	}
+
+	// the invalid signer can no longer afford the fee once its transaction is in the mempool, so the transaction
+	// fails the ante handler
+	require.NoError(t, ctx.SetAccountAmount(invalidSigner.Address(), big.NewInt(0)))
+
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	proposerAddr, er := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, er)
+
+	appHash, txs, er := ctx.CreateAndApplyProposalBlock(proposerAddr, 10000)
+	require.NoError(t, er)
+	require.NotEmpty(t, appHash)
+	require.Equal(t, [][]byte{txsBz[0], txsBz[2]}, txs, "only the valid transactions should be part of the block")
+
+	feeBig, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+	expectedAfterBalance := big.NewInt(0).Sub(startingBalance, big.NewInt(0).Add(amountSent, feeBig))
+	for _, signer := range []crypto.PrivateKey{firstSigner, lastSigner} {
+		amount, err := ctx.GetAccountAmount(signer.Address())
+		require.NoError(t, err)
+		require.Equal(t, expectedAfterBalance, amount)
+	}
+
+	// the writes of the invalid transaction are reverted
+	amount, err := ctx.GetAccountAmount(invalidSigner.Address())
+	require.NoError(t, err)
+	require.Zero(t, amount.Sign())
+	sequence, err := ctx.GetAccountSequence(invalidSigner.Address())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), sequence)
+
+	test_artifacts.CleanupTest(ctx)
+}
+
+func TestUtilityContext_CreateAndApplyBlock_IncludesTransactionsWithFailedMessages(t *testing.T) {
+	mockBusInTestModules(t)
+
+	ctx := NewTestingUtilityContext(t, 1)
+	tx, startingBalance, amount, signer := newTestingTransaction(t, ctx)
+	recipient1, recipient2 := newTestingRecipient(t), newTestingRecipient(t)
+	tx.Msgs = []*anypb.Any{
+		newTestingSendMessageAny(t, signer.Address(), recipient1, amount),
+		newTestingSendMessageAny(t, signer.Address(), recipient2, startingBalance),
+	}
+	require.NoError(t, tx.Sign(signer))
+	txBz, er := tx.Bytes()
+	require.NoError(t, er)
+	require.NoError(t, testUtilityMod.CheckTransaction(txBz))
+
+	proposer := getFirstActor(t, ctx, coreTypes.ActorType_ACTOR_TYPE_VAL)
+	proposerAddr, er := hex.DecodeString(proposer.GetAddress())
+	require.NoError(t, er)
+
+	// the second message fails, but the transaction is part of the block
+	appHash, txs, er := ctx.CreateAndApplyProposalBlock(proposerAddr, 10000)
+	require.NoError(t, er)
+	require.NotEmpty(t, appHash)
+	require.Equal(t, [][]byte{txBz}, txs)
+
+	// its fee is charged and its sequence is used, but its first message is reverted
+	feeBig, err := ctx.GetMessageSendFee()
+	require.NoError(t, err)
+	expectedAfterBalance := new(big.Int).Sub(startingBalance, new(big.Int).Mul(feeBig, big.NewInt(2)))
+	signerAmount, err := ctx.GetAccountAmount(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, expectedAfterBalance, signerAmount)
+	sequence, err := ctx.GetAccountSequence(signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence)
+	recipientAmount, err := ctx.GetAccountAmount(recipient1)
+	require.NoError(t, err)
+	require.Zero(t, recipientAmount.Sign(), "the first message of the transaction was not reverted")
+
+	test_artifacts.CleanupTest(ctx)
+}
+
 func TestUtilityContext_HandleMessage(t *testing.T) {
 	ctx := NewTestingUtilityContext(t, 0)
 	accs := GetAllTestingAccounts(t, ctx)
@@ -163,10 +487,22 @@ func newTestingTransaction(t *testing.T, ctx utility.UtilityContext) (transactio
 	require.NoError(t, err)
 
 	transaction = &typesUtil.Transaction{
-		Msg:   any,
-		Nonce: testNonce,
+		Msgs: []*anypb.Any{any},
 	}
 	require.NoError(t, transaction.Sign(signer))
 
 	return
 }
+
+func newTestingRecipient(t *testing.T) crypto.Address {
+	recipientAddr, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+	return recipientAddr
+}
+
+func newTestingSendMessageAny(t *testing.T, fromAddress, toAddress crypto.Address, amount *big.Int) *anypb.Any {
+	msg := NewTestingSendMessage(t, fromAddress, toAddress, utilTypes.BigIntToString(amount))
+	any, err := codec.GetCodec().ToAny(&msg)
+	require.NoError(t, err)
+	return any
+}
@@ -0,0 +1,190 @@
+package utility
+
+import (
+	"fmt"
+
+	coreTypes "github.com/pokt-network/pocket/shared/core/types"
+	typesUtil "github.com/pokt-network/pocket/utility/types"
+)
+
+/*
+`test_score.go` contains the on-chain side of the fisherman's quality of service lifecycle:
+
+ 1. During a session, the fisherman of the session samples the servicers with relays on behalf of the application.
+    Once the session is over, it submits a `MessageTestScore` per servicer with the root of a relay tree built over
+    the sampled relays (whose sum is the number of samples) and the number of samples left unanswered.
+ 2. The hash of the block the test score was committed in pseudo-randomly selects a sample, which the fisherman
+    must answer with a `MessageProveTestScore` in a later block.
+ 3. A proven test score with null samples allows the fisherman to pause the servicer with a
+    `MessageFishermanPauseServiceNode`, which must prove every null sample against the samples root. The test score
+    is then kept as settled so it cannot pause the servicer again.
+
+Proving the null samples binds them to samples actually sent to the servicer during the session, but that they were
+left unanswered still rests on the word of the fisherman, which is permissioned by the DAO.
+*/
+
+func (u *UtilityContext) HandleMessageTestScore(message *typesUtil.MessageTestScore) typesUtil.Error {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return err
+	}
+	// ensure the session is over so the fisherman can no longer sample the servicer
+	if err := u.validateSessionOver(message.SessionHeight, height, typesUtil.ErrInvalidTestScore); err != nil {
+		return err
+	}
+	exists, er := store.GetTestScoreExists(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height)
+	if er != nil {
+		return typesUtil.ErrGetExists(er)
+	}
+	if exists {
+		return typesUtil.ErrTestScoreExists()
+	}
+	// ensure both the servicer tested and the fisherman testing were part of the session
+	session, _, err := u.getServicerSession(message, message.GeoZone, typesUtil.ErrInvalidTestScore)
+	if err != nil {
+		return err
+	}
+	if !containsActorAddress(session.GetFishermen(), message.FishermanAddress) {
+		return typesUtil.ErrInvalidTestScore("the fisherman was not part of the session")
+	}
+	if er := store.InsertTestScore(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, message.GeoZone, message.SamplesRoot, message.NumNullSamples); er != nil {
+		return typesUtil.ErrInsert(er)
+	}
+	return nil
+}
+
+func (u *UtilityContext) HandleMessageProveTestScore(message *typesUtil.MessageProveTestScore) typesUtil.Error {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return err
+	}
+	geoZone, samplesRoot, _, scoreHeight, provenHeight, _, err := u.getTestScore(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain)
+	if err != nil {
+		return err
+	}
+	if provenHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrInvalidTestScore("the test score is already proven")
+	}
+	// the sample to prove is selected using the hash of the block the test score was committed in
+	if height <= scoreHeight {
+		return typesUtil.ErrInvalidTestScore("the proof must be submitted after the test score is committed")
+	}
+	index, err := u.getRelayProofIndex(scoreHeight, samplesRoot)
+	if err != nil {
+		return err
+	}
+	if message.LeafIndex != index {
+		return typesUtil.ErrInvalidTestScore(fmt.Sprintf("expected a proof of sample %d, got %d", index, message.LeafIndex))
+	}
+	// ensure the sample proven was sent to the servicer during the tested session
+	if err := u.validateSessionRelay(message.Relay, message, geoZone, typesUtil.ErrInvalidTestScore); err != nil {
+		return err
+	}
+	relayBz, err := message.Relay.Bytes()
+	if err != nil {
+		return err
+	}
+	if !typesUtil.VerifyRelayProof(samplesRoot, message.LeafIndex, relayBz, message.SideNodes) {
+		return typesUtil.ErrInvalidTestScore("the merkle proof does not match the samples root")
+	}
+	if er := store.SetTestScoreProvenHeight(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height); er != nil {
+		return typesUtil.ErrSetTestScoreProvenHeight(er)
+	}
+	return nil
+}
+
+func (u *UtilityContext) HandleMessageFishermanPauseServiceNode(message *typesUtil.MessageFishermanPauseServiceNode) typesUtil.Error {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return err
+	}
+	geoZone, samplesRoot, numNullSamples, _, provenHeight, settledHeight, err := u.getTestScore(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain)
+	if err != nil {
+		return err
+	}
+	if provenHeight == typesUtil.HeightNotUsed {
+		return typesUtil.ErrInvalidTestScore("the test score is not proven")
+	}
+	if settledHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrInvalidTestScore("the test score is already settled")
+	}
+	if numNullSamples == 0 {
+		return typesUtil.ErrInvalidTestScore("the servicer answered every sample")
+	}
+	if err := u.validateNullSamples(message, geoZone, samplesRoot, numNullSamples); err != nil {
+		return err
+	}
+	pauseHeight, err := u.GetPauseHeight(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, message.ServicerAddress)
+	if err != nil {
+		return err
+	}
+	if pauseHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrAlreadyPaused()
+	}
+	if err := u.SetActorPauseHeight(coreTypes.ActorType_ACTOR_TYPE_SERVICENODE, message.ServicerAddress, height); err != nil {
+		return err
+	}
+	if er := store.SetTestScoreSettledHeight(message.FishermanAddress, message.ServicerAddress, message.ApplicationPublicKey, message.SessionHeight, message.RelayChain, height); er != nil {
+		return typesUtil.ErrSetTestScoreSettledHeight(er)
+	}
+	return nil
+}
+
+// validateNullSamples ensures the message proves as many distinct null samples as the test score reported, each being
+// a sample sent to the servicer during the tested session
+func (u *UtilityContext) validateNullSamples(message *typesUtil.MessageFishermanPauseServiceNode, geoZone string, samplesRoot []byte, numNullSamples uint64) typesUtil.Error {
+	if uint64(len(message.NullSamples)) != numNullSamples {
+		return typesUtil.ErrInvalidTestScore(fmt.Sprintf("expected proofs of %d null samples, got %d", numNullSamples, len(message.NullSamples)))
+	}
+	leafIndices := make(map[uint64]struct{}, len(message.NullSamples))
+	for _, nullSample := range message.NullSamples {
+		if _, ok := leafIndices[nullSample.LeafIndex]; ok {
+			return typesUtil.ErrInvalidTestScore(fmt.Sprintf("duplicate proof of null sample %d", nullSample.LeafIndex))
+		}
+		leafIndices[nullSample.LeafIndex] = struct{}{}
+		if err := u.validateSessionRelay(nullSample.Relay, message, geoZone, typesUtil.ErrInvalidTestScore); err != nil {
+			return err
+		}
+		relayBz, err := nullSample.Relay.Bytes()
+		if err != nil {
+			return err
+		}
+		if !typesUtil.VerifyRelayProof(samplesRoot, nullSample.LeafIndex, relayBz, nullSample.SideNodes) {
+			return typesUtil.ErrInvalidTestScore(fmt.Sprintf("the merkle proof of null sample %d does not match the samples root", nullSample.LeafIndex))
+		}
+	}
+	return nil
+}
+
+func (u *UtilityContext) GetMessageTestScoreSignerCandidates(msg *typesUtil.MessageTestScore) ([][]byte, typesUtil.Error) {
+	return u.getOperatorSignerCandidates(coreTypes.ActorType_ACTOR_TYPE_FISH, msg.FishermanAddress)
+}
+
+func (u *UtilityContext) GetMessageProveTestScoreSignerCandidates(msg *typesUtil.MessageProveTestScore) ([][]byte, typesUtil.Error) {
+	return u.getOperatorSignerCandidates(coreTypes.ActorType_ACTOR_TYPE_FISH, msg.FishermanAddress)
+}
+
+func (u *UtilityContext) GetMessageFishermanPauseServiceNodeSignerCandidates(msg *typesUtil.MessageFishermanPauseServiceNode) ([][]byte, typesUtil.Error) {
+	return u.getOperatorSignerCandidates(coreTypes.ActorType_ACTOR_TYPE_FISH, msg.FishermanAddress)
+}
+
+func (u *UtilityContext) getTestScore(fishermanAddress, servicerAddress []byte, appPublicKey string, sessionHeight int64, relayChain string) (geoZone string, samplesRoot []byte, numNullSamples uint64, scoreHeight, provenHeight, settledHeight int64, err typesUtil.Error) {
+	store, height, err := u.GetStoreAndHeight()
+	if err != nil {
+		return
+	}
+	exists, er := store.GetTestScoreExists(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain, height)
+	if er != nil {
+		err = typesUtil.ErrGetExists(er)
+		return
+	}
+	if !exists {
+		err = typesUtil.ErrTestScoreNotFound()
+		return
+	}
+	geoZone, samplesRoot, numNullSamples, scoreHeight, provenHeight, settledHeight, er = store.GetTestScore(fishermanAddress, servicerAddress, appPublicKey, sessionHeight, relayChain, height)
+	if er != nil {
+		err = typesUtil.ErrTestScoreNotFound()
+	}
+	return
+}
@@ -3,6 +3,7 @@ package utility
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
 
 	"github.com/pokt-network/pocket/shared/codec"
 	coreTypes "github.com/pokt-network/pocket/shared/core/types"
@@ -18,15 +19,6 @@ func (u *utilityModule) CheckTransaction(txProtoBytes []byte) error {
 		return typesUtil.ErrDuplicateTransaction()
 	}
 
-	// Is the tx already indexed (on disk)?
-	persistenceModule := u.GetBus().GetPersistenceModule()
-	if txExists, err := persistenceModule.TransactionExists(txHash); err != nil {
-		return err
-	} else if txExists {
-		// TODO: non-ordered nonce requires non-pruned tx indexer
-		return typesUtil.ErrTransactionAlreadyCommitted()
-	}
-
 	// Can the tx bytes be decoded as a protobuf?
 	transaction := &typesUtil.Transaction{}
 	if err := codec.GetCodec().Unmarshal(txProtoBytes, transaction); err != nil {
@@ -38,33 +30,113 @@ func (u *utilityModule) CheckTransaction(txProtoBytes []byte) error {
 		return err
 	}
 
+	// Is the sequence of the tx not already used (i.e. is the tx not already committed)?
+	if err := u.checkTransactionSequence(transaction); err != nil {
+		return err
+	}
+
 	// Store the tx in the mempool
 	return u.Mempool.AddTransaction(txProtoBytes)
 }
 
+// `checkTransactionSequence` rejects the transactions whose sequence is lower than the sequence of their signer at the
+// latest height, while the higher ones are accepted so the signer can send several transactions ahead of a block
+func (u *utilityModule) checkTransactionSequence(tx *typesUtil.Transaction) typesUtil.Error {
+	address, err := tx.SignerAddress()
+	if err != nil {
+		return err
+	}
+	readCtx, er := u.GetBus().GetPersistenceModule().NewReadContext(-1)
+	if er != nil {
+		return typesUtil.ErrGetHeight(er)
+	}
+	defer readCtx.Close()
+	latestHeight, er := readCtx.GetLatestBlockHeight()
+	if er != nil {
+		return typesUtil.ErrGetHeight(er)
+	}
+	sequence, er := readCtx.GetAccountSequence(address, int64(latestHeight))
+	if er != nil {
+		return typesUtil.ErrGetAccountSequence(er)
+	}
+	if tx.Sequence < sequence {
+		return typesUtil.ErrSequenceAlreadyUsed(tx.Sequence, sequence)
+	}
+	return nil
+}
+
 func (u *UtilityContext) ApplyTransaction(index int, tx *typesUtil.Transaction) (modules.TxResult, typesUtil.Error) {
-	msg, signer, err := u.AnteHandleMessage(tx)
+	txHash, err := tx.Hash()
+	if err != nil {
+		return nil, err
+	}
+	u.blockPhase, u.txHash = coreTypes.BlockPhase_BLOCK_PHASE_DELIVER_TX, txHash
+	msgs, signer, err := u.AnteHandleMessage(tx)
+	if err != nil {
+		return nil, err
+	}
+	messageResults, err := u.handleMessages(txHash, msgs)
 	if err != nil {
 		return nil, err
 	}
-	return tx.ToTxResult(u.Height, index, signer, msg.GetMessageRecipient(), msg.GetMessageName(), u.HandleMessage(msg))
+	return tx.ToTxResult(u.Height, index, signer, messageResults)
+}
+
+// `handleMessages` applies the messages of a transaction in order on top of a save point, which is reverted if any
+// of them fails so either all of them or none of them are applied. The fee and the sequence of the signer, set
+// before by the ante handler, are not reverted.
+func (u *UtilityContext) handleMessages(txHash string, msgs []typesUtil.Message) ([]*typesUtil.MessageResult, typesUtil.Error) {
+	if err := u.NewSavePoint(crypto.SHA3Hash([]byte("messages" + txHash))); err != nil {
+		return nil, err
+	}
+	messageResults := make([]*typesUtil.MessageResult, 0, len(msgs))
+	for _, msg := range msgs {
+		err := u.HandleMessage(msg)
+		messageResults = append(messageResults, typesUtil.NewMessageResult(msg, err))
+		if err != nil {
+			if err := u.RevertLastSavePoint(); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return messageResults, nil
 }
 
 // CLEANUP: Exposed for testing purposes only
-func (u *UtilityContext) AnteHandleMessage(tx *typesUtil.Transaction) (msg typesUtil.Message, signer string, err typesUtil.Error) {
-	msg, err = tx.Message()
+func (u *UtilityContext) AnteHandleMessage(tx *typesUtil.Transaction) (msgs []typesUtil.Message, signer string, err typesUtil.Error) {
+	msgs, err = tx.Messages()
 	if err != nil {
 		return nil, "", err
 	}
-	fee, err := u.GetFee(msg, msg.GetActorType())
+	minimumFee := big.NewInt(0)
+	for _, msg := range msgs {
+		msgFee, err := u.GetFee(msg, msg.GetActorType())
+		if err != nil {
+			return nil, "", err
+		}
+		minimumFee.Add(minimumFee, msgFee)
+	}
+	fee, err := tx.FeeAmount(minimumFee)
 	if err != nil {
 		return nil, "", err
 	}
-	pubKey, er := crypto.NewPublicKeyFromBytes(tx.Signature.PublicKey)
-	if er != nil {
-		return nil, "", typesUtil.ErrNewPublicKeyFromBytes(er)
+	// The signatures are verified again since the transactions of the mempool are not validated when proposing a
+	// block, and a multisig signer must have signed with at least the threshold of its members
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, "", err
+	}
+	address, err := tx.SignerAddress()
+	if err != nil {
+		return nil, "", err
+	}
+	sequence, err := u.GetAccountSequence(address)
+	if err != nil {
+		return nil, "", err
+	}
+	if tx.Sequence != sequence {
+		return nil, "", typesUtil.ErrInvalidSequence(tx.Sequence, sequence)
 	}
-	address := pubKey.Address()
 	accountAmount, err := u.GetAccountAmount(address)
 	if err != nil {
 		return nil, "", typesUtil.ErrGetAccountAmount(err)
@@ -73,29 +145,46 @@ func (u *UtilityContext) AnteHandleMessage(tx *typesUtil.Transaction) (msg types
 	if accountAmount.Sign() == -1 {
 		return nil, "", typesUtil.ErrInsufficientAmount(address.String())
 	}
-	signerCandidates, err := u.GetSignerCandidates(msg)
-	if err != nil {
-		return nil, "", err
-	}
-	var isValidSigner bool
-	for _, candidate := range signerCandidates {
-		if bytes.Equal(candidate, address) {
-			isValidSigner = true
-			signer = hex.EncodeToString(candidate)
-			break
+	// The signer must be a signer candidate of every message
+	for _, msg := range msgs {
+		signerCandidates, err := u.GetSignerCandidates(msg)
+		if err != nil {
+			return nil, "", err
+		}
+		var isValidSigner bool
+		for _, candidate := range signerCandidates {
+			if bytes.Equal(candidate, address) {
+				isValidSigner = true
+				break
+			}
+		}
+		if !isValidSigner {
+			return nil, "", typesUtil.ErrInvalidSigner()
 		}
 	}
-	if !isValidSigner {
-		return nil, signer, typesUtil.ErrInvalidSigner()
-	}
+	signer = address.String()
 	if err := u.SetAccountAmount(address, accountAmount); err != nil {
 		return nil, signer, err
 	}
-	if err := u.AddPoolAmount(coreTypes.Pools_POOLS_FEE_COLLECTOR.FriendlyName(), fee); err != nil {
+	if err := u.SetAccountSequence(address, sequence+1); err != nil {
+		return nil, signer, err
+	}
+	feePoolName := coreTypes.Pools_POOLS_FEE_COLLECTOR.FriendlyName()
+	if err := u.AddPoolAmount(feePoolName, fee); err != nil {
 		return nil, "", err
 	}
-	msg.SetSigner(address)
-	return msg, signer, nil
+	if err := u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:    coreTypes.EventType_EVENT_TYPE_FEE,
+		Address: signer,
+		Pool:    feePoolName,
+		Amount:  typesUtil.BigIntToString(fee),
+	}); err != nil {
+		return nil, "", err
+	}
+	for _, msg := range msgs {
+		msg.SetSigner(address)
+	}
+	return msgs, signer, nil
 }
 
 func (u *UtilityContext) HandleMessage(msg typesUtil.Message) (err typesUtil.Error) {
@@ -110,10 +199,22 @@ func (u *UtilityContext) HandleMessage(msg typesUtil.Message) (err typesUtil.Err
 		return u.HandleEditStakeMessage(x)
 	case *typesUtil.MessageUnstake:
 		return u.HandleUnstakeMessage(x)
+	case *typesUtil.MessagePause:
+		return u.HandlePauseMessage(x)
 	case *typesUtil.MessageUnpause:
 		return u.HandleUnpauseMessage(x)
 	case *typesUtil.MessageChangeParameter:
 		return u.HandleMessageChangeParameter(x)
+	case *typesUtil.MessageClaim:
+		return u.HandleMessageClaim(x)
+	case *typesUtil.MessageProof:
+		return u.HandleMessageProof(x)
+	case *typesUtil.MessageTestScore:
+		return u.HandleMessageTestScore(x)
+	case *typesUtil.MessageProveTestScore:
+		return u.HandleMessageProveTestScore(x)
+	case *typesUtil.MessageFishermanPauseServiceNode:
+		return u.HandleMessageFishermanPauseServiceNode(x)
 	default:
 		return typesUtil.ErrUnknownMessage(x)
 	}
@@ -145,7 +246,12 @@ func (u *UtilityContext) HandleMessageSend(message *typesUtil.MessageSend) types
 	if err = u.SetAccountAmount(message.FromAddress, fromAccountAmount); err != nil {
 		return err
 	}
-	return nil
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:         coreTypes.EventType_EVENT_TYPE_TRANSFER,
+		Address:      hex.EncodeToString(message.FromAddress),
+		Counterparty: hex.EncodeToString(message.ToAddress),
+		Amount:       typesUtil.BigIntToString(amount),
+	})
 }
 
 func (u *UtilityContext) HandleStakeMessage(message *typesUtil.MessageStake) typesUtil.Error {
@@ -207,7 +313,14 @@ func (u *UtilityContext) HandleStakeMessage(message *typesUtil.MessageStake) typ
 	if er != nil {
 		return typesUtil.ErrInsert(er)
 	}
-	return nil
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:         coreTypes.EventType_EVENT_TYPE_STAKE,
+		ActorType:    message.ActorType,
+		Address:      publicKey.Address().String(),
+		Counterparty: hex.EncodeToString(message.Signer),
+		Pool:         coreTypes.Pools_POOLS_APP_STAKE.FriendlyName(),
+		Amount:       typesUtil.BigIntToString(amount),
+	})
 }
 
 func (u *UtilityContext) HandleEditStakeMessage(message *typesUtil.MessageEditStake) typesUtil.Error {
@@ -270,7 +383,14 @@ func (u *UtilityContext) HandleEditStakeMessage(message *typesUtil.MessageEditSt
 	if er != nil {
 		return typesUtil.ErrInsert(er)
 	}
-	return nil
+	return u.emitEvent(&coreTypes.StateChangeEvent{
+		Type:         coreTypes.EventType_EVENT_TYPE_EDIT_STAKE,
+		ActorType:    message.ActorType,
+		Address:      hex.EncodeToString(message.Address),
+		Counterparty: hex.EncodeToString(message.Signer),
+		Pool:         coreTypes.Pools_POOLS_APP_STAKE.FriendlyName(),
+		Amount:       typesUtil.BigIntToString(amount),
+	})
 }
 
 func (u *UtilityContext) HandleUnstakeMessage(message *typesUtil.MessageUnstake) typesUtil.Error {
@@ -290,6 +410,32 @@ func (u *UtilityContext) HandleUnstakeMessage(message *typesUtil.MessageUnstake)
 	return nil
 }
 
+// HandlePauseMessage allows a staked actor to voluntarily pause itself (e.g. before maintenance). The pause height
+// set is what `*_minimum_pause_blocks` is enforced against when the actor unpauses.
+func (u *UtilityContext) HandlePauseMessage(message *typesUtil.MessagePause) typesUtil.Error {
+	if status, err := u.GetActorStatus(message.ActorType, message.Address); err != nil || status != int32(typesUtil.StakeStatus_Staked) {
+		if status != int32(typesUtil.StakeStatus_Staked) {
+			return typesUtil.ErrInvalidStatus(status, int32(typesUtil.StakeStatus_Staked))
+		}
+		return err
+	}
+	pausedHeight, err := u.GetPauseHeight(message.ActorType, message.Address)
+	if err != nil {
+		return err
+	}
+	if pausedHeight != typesUtil.HeightNotUsed {
+		return typesUtil.ErrAlreadyPaused()
+	}
+	latestHeight, err := u.GetLatestBlockHeight()
+	if err != nil {
+		return err
+	}
+	if err = u.SetActorPauseHeight(message.ActorType, message.Address, latestHeight); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (u *UtilityContext) HandleUnpauseMessage(message *typesUtil.MessageUnpause) typesUtil.Error {
 	pausedHeight, err := u.GetPauseHeight(message.ActorType, message.Address)
 	if err != nil {
@@ -363,10 +509,22 @@ func (u *UtilityContext) GetSignerCandidates(msg typesUtil.Message) ([][]byte, t
 		return u.GetMessageStakeSignerCandidates(x)
 	case *typesUtil.MessageUnstake:
 		return u.GetMessageUnstakeSignerCandidates(x)
+	case *typesUtil.MessagePause:
+		return u.GetMessagePauseSignerCandidates(x)
 	case *typesUtil.MessageUnpause:
 		return u.GetMessageUnpauseSignerCandidates(x)
 	case *typesUtil.MessageChangeParameter:
 		return u.GetMessageChangeParameterSignerCandidates(x)
+	case *typesUtil.MessageClaim:
+		return u.GetMessageClaimSignerCandidates(x)
+	case *typesUtil.MessageProof:
+		return u.GetMessageProofSignerCandidates(x)
+	case *typesUtil.MessageTestScore:
+		return u.GetMessageTestScoreSignerCandidates(x)
+	case *typesUtil.MessageProveTestScore:
+		return u.GetMessageProveTestScoreSignerCandidates(x)
+	case *typesUtil.MessageFishermanPauseServiceNode:
+		return u.GetMessageFishermanPauseServiceNodeSignerCandidates(x)
 	default:
 		return nil, typesUtil.ErrUnknownMessage(x)
 	}
@@ -405,6 +563,17 @@ func (u *UtilityContext) GetMessageUnstakeSignerCandidates(msg *typesUtil.Messag
 	return candidates, nil
 }
 
+func (u *UtilityContext) GetMessagePauseSignerCandidates(msg *typesUtil.MessagePause) ([][]byte, typesUtil.Error) {
+	output, err := u.GetActorOutputAddress(msg.ActorType, msg.Address)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([][]byte, 0)
+	candidates = append(candidates, output)
+	candidates = append(candidates, msg.Address)
+	return candidates, nil
+}
+
 func (u *UtilityContext) GetMessageUnpauseSignerCandidates(msg *typesUtil.MessageUnpause) ([][]byte, typesUtil.Error) {
 	output, err := u.GetActorOutputAddress(msg.ActorType, msg.Address)
 	if err != nil {
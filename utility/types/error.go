@@ -162,6 +162,44 @@ const (
 	CodeGetHeightError                    Code = 129
 	CodeUnknownActorType                  Code = 130
 	CodeUnknownMessageType                Code = 131
+	CodeInvalidRelayError                 Code = 132
+	CodeUnsupportedRelayChainError        Code = 133
+	CodeInvalidSessionHeightError         Code = 134
+	CodeInvalidAATError                   Code = 135
+	CodeServicerNotInSessionError         Code = 136
+	CodeOverServicedError                 Code = 137
+	CodeRelayStoreError                   Code = 138
+	CodeRelayExecutionError               Code = 139
+	CodeReportVolumeMetricsError          Code = 140
+	CodeNewPrivateKeyError                Code = 141
+	CodeRelayTreeError                    Code = 142
+	CodeInvalidClaimError                 Code = 143
+	CodeInvalidProofError                 Code = 144
+	CodeClaimExistsError                  Code = 145
+	CodeClaimNotFoundError                Code = 146
+	CodeDeleteError                       Code = 147
+	CodeInvalidTestScoreError             Code = 148
+	CodeTestScoreExistsError              Code = 149
+	CodeTestScoreNotFoundError            Code = 150
+	CodeSetTestScoreProvenHeightError     Code = 151
+	CodeEmitEventError                    Code = 152
+	CodeTransactionUnderpricedError       Code = 153
+	CodeMempoolFullError                  Code = 154
+	CodeFeeBelowMinimumError              Code = 155
+	CodeInvalidSequenceError              Code = 156
+	CodeSequenceAlreadyUsedError          Code = 157
+	CodeGetAccountSequenceError           Code = 158
+	CodeSetAccountSequenceError           Code = 159
+	CodeEmptyMessagesError                Code = 160
+	CodeInvalidMultisigPublicKeyError     Code = 161
+	CodeMultisigThresholdError            Code = 162
+	CodeNotMultisigMemberError            Code = 163
+	CodeUnexpectedSignatureError          Code = 164
+	CodeCombineDifferentTransactionsError Code = 165
+	CodeRelayResponseSignError            Code = 166
+	CodeSetClaimProvenHeightError         Code = 167
+	CodeInvalidGeoZoneError               Code = 168
+	CodeSetTestScoreSettledHeightError    Code = 169
 
 	GetStakedTokensError              = "an error occurred getting the validator staked tokens"
 	SetValidatorStakedTokensError     = "an error occurred setting the validator staked tokens"
@@ -289,6 +327,44 @@ const (
 	NegativeAmountError               = "the amount is negative"
 	UnknownActorTypeError             = "the actor type is not recognized"
 	UnknownMessageTypeError           = "the message being by the utility message is not recognized"
+	InvalidRelayError                 = "the relay is not valid"
+	UnsupportedRelayChainError        = "the relay chain is not supported by this servicer"
+	InvalidSessionHeightError         = "the relay is not for the current session"
+	InvalidAATError                   = "the application authentication token is not valid"
+	ServicerNotInSessionError         = "the servicer is not part of the session"
+	OverServicedError                 = "the application has exceeded its maximum relays for this session"
+	RelayStoreError                   = "an error occurred interacting with the relay store"
+	RelayExecutionError               = "an error occurred executing the relay"
+	ReportVolumeMetricsError          = "an error occurred reporting the volume metrics to the fisherman"
+	NewPrivateKeyError                = "an error occurred creating the private key"
+	RelayTreeError                    = "an error occurred interacting with the relay tree"
+	InvalidClaimError                 = "the claim is not valid"
+	InvalidProofError                 = "the proof is not valid"
+	ClaimExistsError                  = "a claim already exists for this session"
+	ClaimNotFoundError                = "no claim was found for this session"
+	DeleteError                       = "an error occurred deleting from persistence"
+	InvalidTestScoreError             = "the test score is not valid"
+	TestScoreExistsError              = "a test score already exists for this servicer and session"
+	TestScoreNotFoundError            = "no test score was found for this servicer and session"
+	SetTestScoreProvenHeightError     = "an error occurred setting the test score proven height"
+	EmitEventError                    = "an error occurred emitting the state change event"
+	TransactionUnderpricedError       = "the fee per byte of the transaction is not higher than the one of the transaction with the same signer and sequence in the mempool"
+	MempoolFullError                  = "the mempool is full and the fee per byte of the transaction is too low to evict another one"
+	FeeBelowMinimumError              = "the fee of the transaction is below the minimum fee of its message"
+	InvalidSequenceError              = "the sequence of the transaction is not the sequence of its signer"
+	SequenceAlreadyUsedError          = "the sequence of the transaction was already used by its signer"
+	GetAccountSequenceError           = "an error occurred getting the account sequence"
+	SetAccountSequenceError           = "an error occurred setting the account sequence"
+	EmptyMessagesError                = "the transaction has no message"
+	InvalidMultisigPublicKeyError     = "the multisig public key is not valid"
+	MultisigThresholdError            = "the transaction is not signed by the threshold of the members of its multisig signer"
+	NotMultisigMemberError            = "the public key is not a member of the multisig public key"
+	UnexpectedSignatureError          = "the transaction of a multisig signer has the signature of a single signer"
+	CombineDifferentTransactionsError = "the signatures of different transactions cannot be combined"
+	RelayResponseSignError            = "an error occurred signing the relay response"
+	SetClaimProvenHeightError         = "an error occurred setting the claim proven height"
+	InvalidGeoZoneError               = "the geo zone is not valid"
+	SetTestScoreSettledHeightError    = "an error occurred setting the test score settled height"
 )
 
 func ErrUnknownParam(paramName string) Error {
@@ -795,3 +871,155 @@ func ErrUnknownActorType(actorType string) Error {
 func ErrUnknownMessageType(messageType any) Error {
 	return NewError(CodeUnknownMessageType, fmt.Sprintf("%s: %v", UnknownMessageTypeError, messageType))
 }
+
+func ErrInvalidRelay(reason string) Error {
+	return NewError(CodeInvalidRelayError, fmt.Sprintf("%s: %s", InvalidRelayError, reason))
+}
+
+func ErrUnsupportedRelayChain(relayChain string) Error {
+	return NewError(CodeUnsupportedRelayChainError, fmt.Sprintf("%s: %s", UnsupportedRelayChainError, relayChain))
+}
+
+func ErrInvalidSessionHeight(relayHeight, currentHeight int64) Error {
+	return NewError(CodeInvalidSessionHeightError, fmt.Sprintf("%s: relay height %d, current height %d", InvalidSessionHeightError, relayHeight, currentHeight))
+}
+
+func ErrInvalidAAT(reason string) Error {
+	return NewError(CodeInvalidAATError, fmt.Sprintf("%s: %s", InvalidAATError, reason))
+}
+
+func ErrServicerNotInSession() Error {
+	return NewError(CodeServicerNotInSessionError, ServicerNotInSessionError)
+}
+
+func ErrOverServiced(maxRelays int64) Error {
+	return NewError(CodeOverServicedError, fmt.Sprintf("%s: %d", OverServicedError, maxRelays))
+}
+
+func ErrRelayStore(err error) Error {
+	return NewError(CodeRelayStoreError, fmt.Sprintf("%s: %s", RelayStoreError, err.Error()))
+}
+
+func ErrRelayExecution(err error) Error {
+	return NewError(CodeRelayExecutionError, fmt.Sprintf("%s: %s", RelayExecutionError, err.Error()))
+}
+
+func ErrReportVolumeMetrics(err error) Error {
+	return NewError(CodeReportVolumeMetricsError, fmt.Sprintf("%s: %s", ReportVolumeMetricsError, err.Error()))
+}
+
+func ErrNewPrivateKey(err error) Error {
+	return NewError(CodeNewPrivateKeyError, fmt.Sprintf("%s: %s", NewPrivateKeyError, err.Error()))
+}
+
+func ErrRelayTree(err error) Error {
+	return NewError(CodeRelayTreeError, fmt.Sprintf("%s: %s", RelayTreeError, err.Error()))
+}
+
+func ErrInvalidClaim(reason string) Error {
+	return NewError(CodeInvalidClaimError, fmt.Sprintf("%s: %s", InvalidClaimError, reason))
+}
+
+func ErrInvalidProof(reason string) Error {
+	return NewError(CodeInvalidProofError, fmt.Sprintf("%s: %s", InvalidProofError, reason))
+}
+
+func ErrClaimExists() Error {
+	return NewError(CodeClaimExistsError, ClaimExistsError)
+}
+
+func ErrClaimNotFound() Error {
+	return NewError(CodeClaimNotFoundError, ClaimNotFoundError)
+}
+
+func ErrDelete(err error) Error {
+	return NewError(CodeDeleteError, fmt.Sprintf("%s: %s", DeleteError, err.Error()))
+}
+
+func ErrInvalidTestScore(reason string) Error {
+	return NewError(CodeInvalidTestScoreError, fmt.Sprintf("%s: %s", InvalidTestScoreError, reason))
+}
+
+func ErrTestScoreExists() Error {
+	return NewError(CodeTestScoreExistsError, TestScoreExistsError)
+}
+
+func ErrTestScoreNotFound() Error {
+	return NewError(CodeTestScoreNotFoundError, TestScoreNotFoundError)
+}
+
+func ErrSetTestScoreProvenHeight(err error) Error {
+	return NewError(CodeSetTestScoreProvenHeightError, fmt.Sprintf("%s: %s", SetTestScoreProvenHeightError, err.Error()))
+}
+
+func ErrEmitEvent(err error) Error {
+	return NewError(CodeEmitEventError, fmt.Sprintf("%s: %s", EmitEventError, err.Error()))
+}
+
+func ErrTransactionUnderpriced() Error {
+	return NewError(CodeTransactionUnderpricedError, TransactionUnderpricedError)
+}
+
+func ErrMempoolFull() Error {
+	return NewError(CodeMempoolFullError, MempoolFullError)
+}
+
+func ErrFeeBelowMinimum(fee, minimumFee string) Error {
+	return NewError(CodeFeeBelowMinimumError, fmt.Sprintf("%s: %s < %s", FeeBelowMinimumError, fee, minimumFee))
+}
+
+func ErrInvalidSequence(sequence, expectedSequence uint64) Error {
+	return NewError(CodeInvalidSequenceError, fmt.Sprintf("%s: %d, expected %d", InvalidSequenceError, sequence, expectedSequence))
+}
+
+func ErrSequenceAlreadyUsed(sequence, accountSequence uint64) Error {
+	return NewError(CodeSequenceAlreadyUsedError, fmt.Sprintf("%s: %d < %d", SequenceAlreadyUsedError, sequence, accountSequence))
+}
+
+func ErrGetAccountSequence(err error) Error {
+	return NewError(CodeGetAccountSequenceError, fmt.Sprintf("%s: %s", GetAccountSequenceError, err.Error()))
+}
+
+func ErrSetAccountSequence(err error) Error {
+	return NewError(CodeSetAccountSequenceError, fmt.Sprintf("%s: %s", SetAccountSequenceError, err.Error()))
+}
+
+func ErrEmptyMessages() Error {
+	return NewError(CodeEmptyMessagesError, EmptyMessagesError)
+}
+
+func ErrInvalidMultisigPublicKey(err error) Error {
+	return NewError(CodeInvalidMultisigPublicKeyError, fmt.Sprintf("%s: %s", InvalidMultisigPublicKeyError, err.Error()))
+}
+
+func ErrMultisigThreshold(numSignatures, threshold int) Error {
+	return NewError(CodeMultisigThresholdError, fmt.Sprintf("%s: %d signatures, expected %d", MultisigThresholdError, numSignatures, threshold))
+}
+
+func ErrNotMultisigMember(publicKey string) Error {
+	return NewError(CodeNotMultisigMemberError, fmt.Sprintf("%s: %s", NotMultisigMemberError, publicKey))
+}
+
+func ErrUnexpectedSignature() Error {
+	return NewError(CodeUnexpectedSignatureError, UnexpectedSignatureError)
+}
+
+func ErrCombineDifferentTransactions() Error {
+	return NewError(CodeCombineDifferentTransactionsError, CombineDifferentTransactionsError)
+}
+
+func ErrRelayResponseSign(err error) Error {
+	return NewError(CodeRelayResponseSignError, fmt.Sprintf("%s: %s", RelayResponseSignError, err.Error()))
+}
+
+func ErrSetClaimProvenHeight(err error) Error {
+	return NewError(CodeSetClaimProvenHeightError, fmt.Sprintf("%s: %s", SetClaimProvenHeightError, err.Error()))
+}
+
+func ErrInvalidGeoZone(reason string) Error {
+	return NewError(CodeInvalidGeoZoneError, fmt.Sprintf("%s: %s", InvalidGeoZoneError, reason))
+}
+
+func ErrSetTestScoreSettledHeight(err error) Error {
+	return NewError(CodeSetTestScoreSettledHeightError, fmt.Sprintf("%s: %s", SetTestScoreSettledHeightError, err.Error()))
+}
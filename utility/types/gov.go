@@ -1,5 +1,7 @@
 package types
 
+import coreTypes "github.com/pokt-network/pocket/shared/core/types"
+
 const (
 	BlocksPerSessionParamName = "blocks_per_session"
 
@@ -63,6 +65,11 @@ const (
 	MessagePauseServiceNodeFee          = "message_pause_service_node_fee"
 	MessageUnpauseServiceNodeFee        = "message_unpause_service_node_fee"
 	MessageChangeParameterFee           = "message_change_parameter_fee"
+	MessageClaimFee                     = "message_claim_fee"
+	MessageProofFee                     = "message_proof_fee"
+	RelaysToTokensMultiplierParamName   = "relays_to_tokens_multiplier"
+	ClaimExpirationBlocksParamName      = "claim_expiration_blocks"
+	FishermenPerSessionParamName        = "fishermen_per_session"
 
 	AclOwner                                 = "acl_owner"
 	BlocksPerSessionOwner                    = "blocks_per_session_owner"
@@ -119,4 +126,59 @@ const (
 	MessagePauseServiceNodeFeeOwner          = "message_pause_service_node_fee_owner"
 	MessageUnpauseServiceNodeFeeOwner        = "message_unpause_service_node_fee_owner"
 	MessageChangeParameterFeeOwner           = "message_change_parameter_fee_owner"
+	MessageClaimFeeOwner                     = "message_claim_fee_owner"
+	MessageProofFeeOwner                     = "message_proof_fee_owner"
+	RelaysToTokensMultiplierOwner            = "relays_to_tokens_multiplier_owner"
+	ClaimExpirationBlocksOwner               = "claim_expiration_blocks_owner"
+	FishermenPerSessionOwner                 = "fishermen_per_session_owner"
 )
+
+// `GetMessageFeeParamName` returns the name of the governance param of the fee of `msg`, which depends on the actor
+// type of the staking messages
+func GetMessageFeeParamName(msg Message, actorType coreTypes.ActorType) (string, Error) {
+	switch x := msg.(type) {
+	case *MessageDoubleSign:
+		return MessageDoubleSignFee, nil
+	case *MessageSend:
+		return MessageSendFee, nil
+	case *MessageStake:
+		return getActorMessageFeeParamName(actorType, MessageStakeAppFee, MessageStakeFishermanFee, MessageStakeServiceNodeFee, MessageStakeValidatorFee)
+	case *MessageEditStake:
+		return getActorMessageFeeParamName(actorType, MessageEditStakeAppFee, MessageEditStakeFishermanFee, MessageEditStakeServiceNodeFee, MessageEditStakeValidatorFee)
+	case *MessageUnstake:
+		return getActorMessageFeeParamName(actorType, MessageUnstakeAppFee, MessageUnstakeFishermanFee, MessageUnstakeServiceNodeFee, MessageUnstakeValidatorFee)
+	case *MessagePause:
+		return getActorMessageFeeParamName(actorType, MessagePauseAppFee, MessagePauseFishermanFee, MessagePauseServiceNodeFee, MessagePauseValidatorFee)
+	case *MessageUnpause:
+		return getActorMessageFeeParamName(actorType, MessageUnpauseAppFee, MessageUnpauseFishermanFee, MessageUnpauseServiceNodeFee, MessageUnpauseValidatorFee)
+	case *MessageChangeParameter:
+		return MessageChangeParameterFee, nil
+	case *MessageClaim:
+		return MessageClaimFee, nil
+	case *MessageProof:
+		return MessageProofFee, nil
+	case *MessageTestScore:
+		return MessageTestScoreFee, nil
+	case *MessageProveTestScore:
+		return MessageProveTestScoreFee, nil
+	case *MessageFishermanPauseServiceNode:
+		return MessageFishermanPauseServiceNodeFee, nil
+	default:
+		return "", ErrUnknownMessage(x)
+	}
+}
+
+func getActorMessageFeeParamName(actorType coreTypes.ActorType, appFee, fishermanFee, serviceNodeFee, validatorFee string) (string, Error) {
+	switch actorType {
+	case coreTypes.ActorType_ACTOR_TYPE_APP:
+		return appFee, nil
+	case coreTypes.ActorType_ACTOR_TYPE_FISH:
+		return fishermanFee, nil
+	case coreTypes.ActorType_ACTOR_TYPE_SERVICENODE:
+		return serviceNodeFee, nil
+	case coreTypes.ActorType_ACTOR_TYPE_VAL:
+		return validatorFee, nil
+	default:
+		return "", ErrUnknownActorType(actorType.String())
+	}
+}
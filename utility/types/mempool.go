@@ -18,6 +18,7 @@ type Mempool interface {
 	IsEmpty() bool
 	TxsBytes() uint64 // Returns the total sum of all transactions' sizes (in bytes) stored in the mempool
 	PopTransaction() (tx []byte, err Error)
+	Transactions() [][]byte // Returns all the transactions stored in the mempool, in no particular order
 }
 
 var _ Mempool = &FIFOMempool{}
@@ -85,16 +86,12 @@ func (f *FIFOMempool) RemoveTransaction(tx []byte) Error {
 	f.l.Lock()
 	defer f.l.Unlock()
 
-	var toRemove *list.Element
-	for e := f.txQueue.Front(); e.Next() != nil; {
-		if bytes.Equal(tx, e.Value.([]byte)) {
-			toRemove = e
-			break
-		}
+	if _, ok := f.txSet[crypto.GetHashStringFromBytes(tx)]; !ok {
+		return nil
 	}
-	if toRemove != nil {
-		_, err := f.removeTransaction(toRemove)
-		if err != nil {
+	for e := f.txQueue.Front(); e != nil; e = e.Next() {
+		if bytes.Equal(tx, e.Value.([]byte)) {
+			_, err := f.removeTransaction(e)
 			return err
 		}
 	}
@@ -102,8 +99,8 @@ func (f *FIFOMempool) RemoveTransaction(tx []byte) Error {
 }
 
 func (f *FIFOMempool) PopTransaction() ([]byte, Error) {
-	f.l.RLock()
-	defer f.l.RUnlock()
+	f.l.Lock()
+	defer f.l.Unlock()
 
 	tx, err := f.popTransaction()
 	if err != nil {
@@ -143,6 +140,17 @@ func (f *FIFOMempool) TxsBytes() uint64 {
 	return f.txBytes
 }
 
+func (f *FIFOMempool) Transactions() [][]byte {
+	f.l.RLock()
+	defer f.l.RUnlock()
+
+	txs := make([][]byte, 0, f.size)
+	for e := f.txQueue.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.([]byte))
+	}
+	return txs
+}
+
 func (f *FIFOMempool) popTransaction() ([]byte, Error) {
 	return f.removeTransaction(f.txQueue.Front())
 }
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIFOMempool_RemoveTransaction(t *testing.T) {
+	mempool := NewMempool(testingMempoolMaxTxBytes, 100)
+	signer := newTestingSigner(t)
+
+	txs := [][]byte{
+		newTestingMempoolTx(t, signer, 1, 1000),
+		newTestingMempoolTx(t, signer, 2, 1000),
+		newTestingMempoolTx(t, signer, 3, 1000),
+	}
+	for _, tx := range txs {
+		require.NoError(t, mempool.AddTransaction(tx))
+	}
+
+	// Neither the first transaction of the queue nor the only one left
+	require.NoError(t, mempool.RemoveTransaction(txs[1]))
+	require.False(t, mempool.Contains(TransactionHash(txs[1])))
+	require.NoError(t, mempool.RemoveTransaction(txs[2]))
+	require.NoError(t, mempool.RemoveTransaction(txs[2]))
+	require.Equal(t, uint32(1), mempool.Size())
+	require.Equal(t, uint64(len(txs[0])), mempool.TxsBytes())
+
+	tx, err := mempool.PopTransaction()
+	require.NoError(t, err)
+	require.Equal(t, txs[0], tx)
+	require.True(t, mempool.IsEmpty())
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"log"
 	"net/url"
 	"strconv"
@@ -54,9 +55,15 @@ var _ Message = &MessageSend{}
 var _ Message = &MessageStake{}
 var _ Message = &MessageEditStake{}
 var _ Message = &MessageUnstake{}
+var _ Message = &MessagePause{}
 var _ Message = &MessageUnpause{}
 var _ Message = &MessageChangeParameter{}
 var _ Message = &MessageDoubleSign{}
+var _ Message = &MessageClaim{}
+var _ Message = &MessageProof{}
+var _ Message = &MessageTestScore{}
+var _ Message = &MessageProveTestScore{}
+var _ Message = &MessageFishermanPauseServiceNode{}
 
 func (msg *MessageSend) GetActorType() coreTypes.ActorType {
 	return coreTypes.ActorType_ACTOR_TYPE_UNSPECIFIED // there's no actor type for message send, so return zero to allow fee retrieval
@@ -130,34 +137,157 @@ func (msg *MessageChangeParameter) ValidateBasic() Error {
 	return nil
 }
 
+func (msg *MessageClaim) ValidateBasic() Error {
+	if err := ValidateSessionRelays(msg); err != nil {
+		return err
+	}
+	if len(msg.MerkleRoot) != RelayTreeRootLen {
+		return ErrInvalidClaim(fmt.Sprintf("invalid merkle root length %d", len(msg.MerkleRoot)))
+	}
+	if RelayTreeRootSum(msg.MerkleRoot) == 0 {
+		return ErrInvalidClaim("no relays claimed")
+	}
+	return nil
+}
+
+func (msg *MessageProof) ValidateBasic() Error {
+	if err := ValidateSessionRelays(msg); err != nil {
+		return err
+	}
+	if msg.Relay == nil {
+		return ErrInvalidProof("missing relay")
+	}
+	if len(msg.SideNodes) > RelayTreeRootLen*8 {
+		return ErrInvalidProof(fmt.Sprintf("too many side nodes %d", len(msg.SideNodes)))
+	}
+	return nil
+}
+
+func (msg *MessageTestScore) ValidateBasic() Error {
+	if err := ValidateAddress(msg.FishermanAddress); err != nil {
+		return err
+	}
+	if err := ValidateSessionRelays(msg); err != nil {
+		return err
+	}
+	if len(msg.SamplesRoot) != RelayTreeRootLen {
+		return ErrInvalidTestScore(fmt.Sprintf("invalid samples root length %d", len(msg.SamplesRoot)))
+	}
+	numSamples := RelayTreeRootSum(msg.SamplesRoot)
+	if numSamples == 0 {
+		return ErrInvalidTestScore("no samples taken")
+	}
+	if msg.NumNullSamples > numSamples {
+		return ErrInvalidTestScore(fmt.Sprintf("%d null samples out of %d samples", msg.NumNullSamples, numSamples))
+	}
+	return nil
+}
+
+func (msg *MessageProveTestScore) ValidateBasic() Error {
+	if err := ValidateAddress(msg.FishermanAddress); err != nil {
+		return err
+	}
+	if err := ValidateSessionRelays(msg); err != nil {
+		return err
+	}
+	if msg.Relay == nil {
+		return ErrInvalidTestScore("missing sampled relay")
+	}
+	if len(msg.SideNodes) > RelayTreeRootLen*8 {
+		return ErrInvalidTestScore(fmt.Sprintf("too many side nodes %d", len(msg.SideNodes)))
+	}
+	return nil
+}
+
+func (msg *MessageFishermanPauseServiceNode) ValidateBasic() Error {
+	if err := ValidateAddress(msg.FishermanAddress); err != nil {
+		return err
+	}
+	if err := ValidateSessionRelays(msg); err != nil {
+		return err
+	}
+	if len(msg.NullSamples) == 0 {
+		return ErrInvalidTestScore("missing null samples")
+	}
+	for _, nullSample := range msg.NullSamples {
+		if nullSample.GetRelay() == nil {
+			return ErrInvalidTestScore("missing null sample relay")
+		}
+		if len(nullSample.SideNodes) > RelayTreeRootLen*8 {
+			return ErrInvalidTestScore(fmt.Sprintf("too many side nodes %d", len(nullSample.SideNodes)))
+		}
+	}
+	return nil
+}
+
 func (msg *MessageSend) GetMessageName() string            { return getMessageType(msg) }
 func (msg *MessageUnstake) GetMessageName() string         { return getMessageType(msg) }
+func (msg *MessagePause) GetMessageName() string           { return getMessageType(msg) }
 func (msg *MessageUnpause) GetMessageName() string         { return getMessageType(msg) }
 func (msg *MessageEditStake) GetMessageName() string       { return getMessageType(msg) }
 func (msg *MessageStake) GetMessageName() string           { return getMessageType(msg) }
 func (msg *MessageChangeParameter) GetMessageName() string { return getMessageType(msg) }
 func (msg *MessageDoubleSign) GetMessageName() string      { return getMessageType(msg) }
+func (msg *MessageClaim) GetMessageName() string           { return getMessageType(msg) }
+func (msg *MessageProof) GetMessageName() string           { return getMessageType(msg) }
+func (msg *MessageTestScore) GetMessageName() string       { return getMessageType(msg) }
+func (msg *MessageProveTestScore) GetMessageName() string  { return getMessageType(msg) }
+func (msg *MessageFishermanPauseServiceNode) GetMessageName() string {
+	return getMessageType(msg)
+}
 
 func (msg *MessageSend) GetMessageRecipient() string            { return hex.EncodeToString(msg.ToAddress) }
 func (msg *MessageUnstake) GetMessageRecipient() string         { return "" }
+func (msg *MessagePause) GetMessageRecipient() string           { return "" }
 func (msg *MessageUnpause) GetMessageRecipient() string         { return "" }
 func (msg *MessageEditStake) GetMessageRecipient() string       { return "" }
 func (msg *MessageStake) GetMessageRecipient() string           { return "" }
 func (msg *MessageChangeParameter) GetMessageRecipient() string { return "" }
 func (msg *MessageDoubleSign) GetMessageRecipient() string      { return "" }
+func (msg *MessageClaim) GetMessageRecipient() string           { return "" }
+func (msg *MessageProof) GetMessageRecipient() string           { return "" }
+func (msg *MessageTestScore) GetMessageRecipient() string       { return "" }
+func (msg *MessageProveTestScore) GetMessageRecipient() string  { return "" }
+func (msg *MessageFishermanPauseServiceNode) GetMessageRecipient() string {
+	return hex.EncodeToString(msg.ServicerAddress)
+}
 
 func (msg *MessageUnstake) ValidateBasic() Error { return ValidateAddress(msg.Address) }
+func (msg *MessagePause) ValidateBasic() Error   { return ValidateAddress(msg.Address) }
 func (msg *MessageUnpause) ValidateBasic() Error { return ValidateAddress(msg.Address) }
 
 func (msg *MessageStake) SetSigner(signer []byte)                   { msg.Signer = signer }
 func (msg *MessageEditStake) SetSigner(signer []byte)               { msg.Signer = signer }
 func (msg *MessageUnstake) SetSigner(signer []byte)                 { msg.Signer = signer }
+func (msg *MessagePause) SetSigner(signer []byte)                   { msg.Signer = signer }
 func (msg *MessageUnpause) SetSigner(signer []byte)                 { msg.Signer = signer }
 func (msg *MessageDoubleSign) SetSigner(signer []byte)              { msg.ReporterAddress = signer }
 func (msg *MessageSend) SetSigner(signer []byte)                    { /*no op*/ }
 func (msg *MessageChangeParameter) SetSigner(signer []byte)         { msg.Signer = signer }
+func (msg *MessageClaim) SetSigner(signer []byte)                   { msg.Signer = signer }
+func (msg *MessageProof) SetSigner(signer []byte)                   { msg.Signer = signer }
+func (msg *MessageTestScore) SetSigner(signer []byte)               { msg.Signer = signer }
+func (msg *MessageProveTestScore) SetSigner(signer []byte)          { msg.Signer = signer }
 func (x *MessageChangeParameter) GetActorType() coreTypes.ActorType { return -1 }
 func (x *MessageDoubleSign) GetActorType() coreTypes.ActorType      { return -1 }
+func (msg *MessageFishermanPauseServiceNode) SetSigner(signer []byte) {
+	msg.Signer = signer
+}
+func (x *MessageClaim) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_SERVICENODE
+}
+func (x *MessageProof) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_SERVICENODE
+}
+func (x *MessageTestScore) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
+func (x *MessageProveTestScore) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
+func (x *MessageFishermanPauseServiceNode) GetActorType() coreTypes.ActorType {
+	return coreTypes.ActorType_ACTOR_TYPE_FISH
+}
 
 func (msg *MessageStake) GetCanonicalBytes() []byte           { return getCanonicalBytes(msg) }
 func (msg *MessageEditStake) GetCanonicalBytes() []byte       { return getCanonicalBytes(msg) }
@@ -165,7 +295,15 @@ func (msg *MessageDoubleSign) GetCanonicalBytes() []byte      { return getCanoni
 func (msg *MessageSend) GetCanonicalBytes() []byte            { return getCanonicalBytes(msg) }
 func (msg *MessageChangeParameter) GetCanonicalBytes() []byte { return getCanonicalBytes(msg) }
 func (msg *MessageUnstake) GetCanonicalBytes() []byte         { return getCanonicalBytes(msg) }
+func (msg *MessagePause) GetCanonicalBytes() []byte           { return getCanonicalBytes(msg) }
 func (msg *MessageUnpause) GetCanonicalBytes() []byte         { return getCanonicalBytes(msg) }
+func (msg *MessageClaim) GetCanonicalBytes() []byte           { return getCanonicalBytes(msg) }
+func (msg *MessageProof) GetCanonicalBytes() []byte           { return getCanonicalBytes(msg) }
+func (msg *MessageTestScore) GetCanonicalBytes() []byte       { return getCanonicalBytes(msg) }
+func (msg *MessageProveTestScore) GetCanonicalBytes() []byte  { return getCanonicalBytes(msg) }
+func (msg *MessageFishermanPauseServiceNode) GetCanonicalBytes() []byte {
+	return getCanonicalBytes(msg)
+}
 
 // helpers
 
@@ -279,6 +417,7 @@ func getMessageType(msg Message) string {
 
 const (
 	RelayChainLength = 4 // pre-determined length that strikes a balance between combination possibilities & storage
+	GeoZoneLength    = 4 // like the relay chains, the geo zones are identified by a fixed length alphanumeric id
 )
 
 type RelayChain string
@@ -295,6 +434,20 @@ func (rc *RelayChain) Validate() Error {
 	return nil
 }
 
+type GeoZone string
+
+func (gz GeoZone) Validate() Error {
+	if len(gz) != GeoZoneLength {
+		return ErrInvalidGeoZone(fmt.Sprintf("expected %d characters, got %d", GeoZoneLength, len(gz)))
+	}
+	for _, c := range gz {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			return ErrInvalidGeoZone(fmt.Sprintf("invalid character %q", c))
+		}
+	}
+	return nil
+}
+
 type MessageStaker interface {
 	GetActorType() coreTypes.ActorType
 	GetAmount() string
@@ -315,6 +468,39 @@ func ValidateStaker(msg MessageStaker) Error {
 	return ValidateServiceUrl(msg.GetActorType(), msg.GetServiceUrl())
 }
 
+// MessageSessionRelays is the session identifying part shared by the claim, proof & test score messages
+type MessageSessionRelays interface {
+	GetServicerAddress() []byte
+	GetApplicationPublicKey() string
+	GetSessionHeight() int64
+	GetRelayChain() string
+}
+
+func ValidateSessionRelays(msg MessageSessionRelays) Error {
+	if err := ValidateAddress(msg.GetServicerAddress()); err != nil {
+		return err
+	}
+	appPublicKey, err := hex.DecodeString(msg.GetApplicationPublicKey())
+	if err != nil {
+		return ErrHexDecodeFromString(err)
+	}
+	if err := ValidatePublicKey(appPublicKey); err != nil {
+		return err
+	}
+	if msg.GetSessionHeight() < 0 {
+		return ErrInvalidBlockHeight()
+	}
+	relayChain := RelayChain(msg.GetRelayChain())
+	if err := relayChain.Validate(); err != nil {
+		return err
+	}
+	// the claims & test scores carry the geo zone of their session, which is persisted along with them
+	if msg, ok := msg.(interface{ GetGeoZone() string }); ok {
+		return GeoZone(msg.GetGeoZone()).Validate()
+	}
+	return nil
+}
+
 func getCanonicalBytes(msg Message) []byte {
 	bz, err := codec.GetCodec().Marshal(msg)
 	if err != nil {
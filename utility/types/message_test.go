@@ -245,6 +245,22 @@ func TestMessageUnstake_ValidateBasic(t *testing.T) {
 	require.Equal(t, ErrEmptyAddress().Code(), er.Code())
 }
 
+func TestMessagePause_ValidateBasic(t *testing.T) {
+	addr, err := crypto.GenerateAddress()
+	require.NoError(t, err)
+
+	msg := MessagePause{
+		Address: addr,
+	}
+	er := msg.ValidateBasic()
+	require.NoError(t, er)
+
+	msgMissingAddress := proto.Clone(&msg).(*MessagePause)
+	msgMissingAddress.Address = nil
+	er = msgMissingAddress.ValidateBasic()
+	require.Equal(t, ErrEmptyAddress().Code(), er.Code())
+}
+
 func TestMessageUnpause_ValidateBasic(t *testing.T) {
 	addr, err := crypto.GenerateAddress()
 	require.NoError(t, err)
@@ -276,3 +292,31 @@ func TestRelayChain_Validate(t *testing.T) {
 	err = relayChainEmpty.Validate()
 	require.Equal(t, expectedError.Code(), err.Code())
 }
+
+func TestGeoZone_Validate(t *testing.T) {
+	require.NoError(t, GeoZone("0001").Validate())
+	require.NoError(t, GeoZone("eu01").Validate())
+
+	for _, geoZone := range []string{"", "001", "00001", "0'01", "0 01", "é01"} {
+		err := GeoZone(geoZone).Validate()
+		require.Error(t, err, geoZone)
+		require.Equal(t, CodeInvalidGeoZoneError, err.Code())
+	}
+}
+
+func TestMessageClaim_ValidateBasic_GeoZone(t *testing.T) {
+	appPublicKey, err := crypto.GeneratePublicKey()
+	require.NoError(t, err)
+	msg := &MessageClaim{
+		ServicerAddress:      appPublicKey.Address(),
+		ApplicationPublicKey: appPublicKey.String(),
+		RelayChain:           "0001",
+		GeoZone:              "0001",
+		MerkleRoot:           NewRelayTree().Root(),
+	}
+	// a valid geo zone leaves the empty merkle root as the only invalid field
+	require.Equal(t, CodeInvalidClaimError, msg.ValidateBasic().Code())
+
+	msg.GeoZone = "'); DROP TABLE claim; --"
+	require.Equal(t, CodeInvalidGeoZoneError, msg.ValidateBasic().Code())
+}
@@ -0,0 +1,346 @@
+package types
+
+import (
+	"container/heap"
+	"container/list"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pokt-network/pocket/shared/crypto"
+)
+
+/*
+	The PriorityMempool reaps the transactions with the highest fee per byte first, while the transactions of
+	a same signer are always reaped in the order of their sequences.
+
+	A transaction with the same signer and sequence as one already in the mempool replaces it if it pays a higher fee
+	per byte (i.e. replace-by-fee), and is rejected otherwise. When the mempool is full, the transaction with the
+	lowest fee per byte among the last transactions of every signer is evicted, and the transactions older than the
+	TTL are evicted before every operation. A new transaction which would be evicted itself is rejected, leaving the
+	mempool as it was.
+*/
+
+const (
+	FIFOMempoolType     = "fifo"
+	PriorityMempoolType = "priority"
+)
+
+// `TxFeeFunc` returns the fee paid by a transaction, which its priority in the mempool is derived from
+type TxFeeFunc func(tx *Transaction) (*big.Int, Error)
+
+var _ Mempool = &PriorityMempool{}
+
+type PriorityMempool struct {
+	l          sync.Mutex
+	txs        map[string]*mempoolTx    // the transactions by hash
+	signers    map[string]*mempoolQueue // the transactions of every signer, ordered by sequence
+	queues     mempoolQueueHeap         // the queues ordered by the priority of their first transaction
+	arrivals   *list.List               // the transactions ordered by arrival, used to evict the expired ones
+	seq        uint64                   // the arrival sequence number of the last transaction
+	txBytes    uint64                   // current sum of all transactions' sizes (in bytes)
+	maxTxBytes uint64                   // maximum total size of all txs allowed in the mempool
+	maxTxs     uint32                   // maximum number of transactions allowed in the mempool
+	ttl        time.Duration            // 0 if the transactions never expire
+	clock      clock.Clock
+	getFee     TxFeeFunc
+}
+
+type mempoolTx struct {
+	bz       []byte
+	hash     string
+	signer   string
+	sequence uint64
+	fee      *big.Int
+	seq      uint64
+	addedAt  time.Time
+	arrival  *list.Element
+}
+
+type mempoolQueue struct {
+	txs       []*mempoolTx
+	heapIndex int
+}
+
+func NewPriorityMempool(maxTransactionBytes uint64, maxTransactions uint32, ttl time.Duration, clock clock.Clock, getFee TxFeeFunc) Mempool {
+	return &PriorityMempool{
+		txs:        make(map[string]*mempoolTx),
+		signers:    make(map[string]*mempoolQueue),
+		queues:     make(mempoolQueueHeap, 0),
+		arrivals:   list.New(),
+		maxTxBytes: maxTransactionBytes,
+		maxTxs:     maxTransactions,
+		ttl:        ttl,
+		clock:      clock,
+		getFee:     getFee,
+	}
+}
+
+func (p *PriorityMempool) AddTransaction(txBz []byte) Error {
+	// The transaction is decoded and its fee retrieved before locking the mempool
+	tx, err := TransactionFromBytes(txBz)
+	if err != nil {
+		return err
+	}
+	signer, err := tx.SignerAddress()
+	if err != nil {
+		return err
+	}
+	fee, err := p.getFee(tx)
+	if err != nil {
+		return err
+	}
+
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.evictExpired()
+
+	hash := crypto.GetHashStringFromBytes(txBz)
+	if _, ok := p.txs[hash]; ok {
+		return ErrDuplicateTransaction()
+	}
+
+	p.seq++
+	newTx := &mempoolTx{
+		bz:       txBz,
+		hash:     hash,
+		signer:   signer.String(),
+		sequence: tx.GetSequence(),
+		fee:      fee,
+		seq:      p.seq,
+		addedAt:  p.clock.Now(),
+	}
+
+	// Replace-by-fee
+	var removedTxs []*mempoolTx
+	if queue, ok := p.signers[newTx.signer]; ok {
+		if i := queue.search(newTx.sequence); i < len(queue.txs) && queue.txs[i].sequence == newTx.sequence {
+			if !hasHigherFeePerByte(newTx, queue.txs[i]) {
+				return ErrTransactionUnderpriced()
+			}
+			removedTxs = append(removedTxs, queue.txs[i])
+			p.removeTransaction(queue.txs[i])
+		}
+	}
+	p.insertTransaction(newTx)
+
+	for uint32(len(p.txs)) > p.maxTxs || p.txBytes > p.maxTxBytes {
+		evicted := p.lowestPriorityTransaction()
+		p.removeTransaction(evicted)
+		if evicted == newTx {
+			// The mempool is left as it was, including the transaction the new one was replacing, if any
+			for _, tx := range removedTxs {
+				p.insertTransaction(tx)
+			}
+			return ErrMempoolFull()
+		}
+		removedTxs = append(removedTxs, evicted)
+	}
+	return nil
+}
+
+func (p *PriorityMempool) Contains(hash string) bool {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	_, ok := p.txs[hash]
+	return ok
+}
+
+func (p *PriorityMempool) RemoveTransaction(txBz []byte) Error {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	if tx, ok := p.txs[crypto.GetHashStringFromBytes(txBz)]; ok {
+		p.removeTransaction(tx)
+	}
+	return nil
+}
+
+func (p *PriorityMempool) PopTransaction() ([]byte, Error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.evictExpired()
+
+	if len(p.queues) == 0 {
+		return nil, nil
+	}
+	tx := p.queues[0].txs[0]
+	p.removeTransaction(tx)
+	return tx.bz, nil
+}
+
+func (p *PriorityMempool) Clear() {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	p.txs = make(map[string]*mempoolTx)
+	p.signers = make(map[string]*mempoolQueue)
+	p.queues = make(mempoolQueueHeap, 0)
+	p.arrivals = list.New()
+	p.txBytes = 0
+}
+
+func (p *PriorityMempool) Size() uint32 {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.evictExpired()
+
+	return uint32(len(p.txs))
+}
+
+func (p *PriorityMempool) IsEmpty() bool {
+	return p.Size() == 0
+}
+
+func (p *PriorityMempool) TxsBytes() uint64 {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.evictExpired()
+
+	return p.txBytes
+}
+
+func (p *PriorityMempool) Transactions() [][]byte {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.evictExpired()
+
+	txs := make([][]byte, 0, len(p.txs))
+	for _, tx := range p.txs {
+		txs = append(txs, tx.bz)
+	}
+	return txs
+}
+
+func (p *PriorityMempool) insertTransaction(tx *mempoolTx) {
+	// A transaction restored after being removed is inserted back in its place in the order of arrival
+	e := p.arrivals.Back()
+	for e != nil && e.Value.(*mempoolTx).seq > tx.seq {
+		e = e.Prev()
+	}
+	if e == nil {
+		tx.arrival = p.arrivals.PushFront(tx)
+	} else {
+		tx.arrival = p.arrivals.InsertAfter(tx, e)
+	}
+	p.txs[tx.hash] = tx
+	p.txBytes += uint64(len(tx.bz))
+
+	queue, ok := p.signers[tx.signer]
+	if !ok {
+		queue = &mempoolQueue{txs: []*mempoolTx{tx}}
+		p.signers[tx.signer] = queue
+		heap.Push(&p.queues, queue)
+		return
+	}
+	i := queue.search(tx.sequence)
+	queue.txs = append(queue.txs, nil)
+	copy(queue.txs[i+1:], queue.txs[i:])
+	queue.txs[i] = tx
+	if i == 0 {
+		heap.Fix(&p.queues, queue.heapIndex)
+	}
+}
+
+func (p *PriorityMempool) removeTransaction(tx *mempoolTx) {
+	p.arrivals.Remove(tx.arrival)
+	delete(p.txs, tx.hash)
+	p.txBytes -= uint64(len(tx.bz))
+
+	queue := p.signers[tx.signer]
+	i := queue.search(tx.sequence)
+	queue.txs = append(queue.txs[:i], queue.txs[i+1:]...)
+	switch {
+	case len(queue.txs) == 0:
+		heap.Remove(&p.queues, queue.heapIndex)
+		delete(p.signers, tx.signer)
+	case i == 0:
+		heap.Fix(&p.queues, queue.heapIndex)
+	}
+}
+
+// `lowestPriorityTransaction` returns the transaction with the lowest fee per byte among the last transactions of
+// every signer, so evicting it never leaves a gap in the sequences of a signer
+func (p *PriorityMempool) lowestPriorityTransaction() *mempoolTx {
+	var lowest *mempoolTx
+	for _, queue := range p.signers {
+		last := queue.txs[len(queue.txs)-1]
+		if lowest == nil || hasHigherPriority(lowest, last) {
+			lowest = last
+		}
+	}
+	return lowest
+}
+
+func (p *PriorityMempool) evictExpired() {
+	if p.ttl == 0 {
+		return
+	}
+	now := p.clock.Now()
+	for e := p.arrivals.Front(); e != nil; e = p.arrivals.Front() {
+		tx := e.Value.(*mempoolTx)
+		if now.Sub(tx.addedAt) < p.ttl {
+			return
+		}
+		p.removeTransaction(tx)
+	}
+}
+
+// `search` returns the index of the transaction with `sequence` in the queue, or the index it would be inserted at
+func (q *mempoolQueue) search(sequence uint64) int {
+	return sort.Search(len(q.txs), func(i int) bool {
+		return q.txs[i].sequence >= sequence
+	})
+}
+
+// `mempoolQueueHeap` is a max heap of the queues of the signers, which implements `heap.Interface`
+type mempoolQueueHeap []*mempoolQueue
+
+func (h mempoolQueueHeap) Len() int { return len(h) }
+
+func (h mempoolQueueHeap) Less(i, j int) bool {
+	return hasHigherPriority(h[i].txs[0], h[j].txs[0])
+}
+
+func (h mempoolQueueHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *mempoolQueueHeap) Push(x any) {
+	queue := x.(*mempoolQueue)
+	queue.heapIndex = len(*h)
+	*h = append(*h, queue)
+}
+
+func (h *mempoolQueueHeap) Pop() any {
+	old := *h
+	n := len(old)
+	queue := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return queue
+}
+
+// `hasHigherPriority` orders the transactions by fee per byte, and by arrival for equal fees per byte
+func hasHigherPriority(tx1, tx2 *mempoolTx) bool {
+	if cmp := compareFeesPerByte(tx1, tx2); cmp != 0 {
+		return cmp > 0
+	}
+	return tx1.seq < tx2.seq
+}
+
+func hasHigherFeePerByte(tx1, tx2 *mempoolTx) bool {
+	return compareFeesPerByte(tx1, tx2) > 0
+}
+
+// `compareFeesPerByte` compares fee1/len1 to fee2/len2 as fee1*len2 to fee2*len1 to avoid any rounding
+func compareFeesPerByte(tx1, tx2 *mempoolTx) int {
+	lhs := new(big.Int).Mul(tx1.fee, big.NewInt(int64(len(tx2.bz))))
+	rhs := new(big.Int).Mul(tx2.fee, big.NewInt(int64(len(tx1.bz))))
+	return lhs.Cmp(rhs)
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	testingMempoolMaxTxBytes = uint64(1000000)
+	testingMempoolTTL        = time.Hour
+)
+
+func TestPriorityMempool_ReapOrder(t *testing.T) {
+	mempool := NewPriorityMempool(testingMempoolMaxTxBytes, 100, testingMempoolTTL, clock.NewMock(), testingTxFee)
+	signer1, signer2 := newTestingSigner(t), newTestingSigner(t)
+
+	// The transactions of a same signer are reaped in the order of their sequences, whatever their fees
+	signer1Tx9 := newTestingMempoolTx(t, signer1, 9, 1000)
+	signer1Tx10 := newTestingMempoolTx(t, signer1, 10, 9000)
+	signer2Tx1 := newTestingMempoolTx(t, signer2, 1, 5000)
+	for _, tx := range [][]byte{signer1Tx10, signer2Tx1, signer1Tx9} {
+		require.NoError(t, mempool.AddTransaction(tx))
+	}
+	require.Equal(t, uint32(3), mempool.Size())
+
+	for _, expectedTx := range [][]byte{signer2Tx1, signer1Tx9, signer1Tx10} {
+		tx, err := mempool.PopTransaction()
+		require.NoError(t, err)
+		require.Equal(t, expectedTx, tx)
+	}
+	require.True(t, mempool.IsEmpty())
+	require.Zero(t, mempool.TxsBytes())
+}
+
+func TestPriorityMempool_ReplaceByFee(t *testing.T) {
+	mempool := NewPriorityMempool(testingMempoolMaxTxBytes, 100, testingMempoolTTL, clock.NewMock(), testingTxFee)
+	signer := newTestingSigner(t)
+
+	tx := newTestingMempoolTx(t, signer, 1, 2000)
+	require.NoError(t, mempool.AddTransaction(tx))
+	require.Equal(t, ErrDuplicateTransaction().Code(), mempool.AddTransaction(tx).Code())
+
+	underpricedTx := newTestingMempoolTx(t, signer, 1, 1000)
+	require.Equal(t, ErrTransactionUnderpriced().Code(), mempool.AddTransaction(underpricedTx).Code())
+	require.False(t, mempool.Contains(TransactionHash(underpricedTx)))
+
+	replacementTx := newTestingMempoolTx(t, signer, 1, 3000)
+	require.NoError(t, mempool.AddTransaction(replacementTx))
+	require.True(t, mempool.Contains(TransactionHash(replacementTx)))
+	require.False(t, mempool.Contains(TransactionHash(tx)))
+	require.Equal(t, uint32(1), mempool.Size())
+	require.Equal(t, uint64(len(replacementTx)), mempool.TxsBytes())
+}
+
+func TestPriorityMempool_ReplaceByFeeWhenFull(t *testing.T) {
+	mockClock := clock.NewMock()
+	signer := newTestingSigner(t)
+	tx := newTestingMempoolTx(t, signer, 1, 1000)
+	otherTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 50000)
+	mempool := NewPriorityMempool(uint64(len(tx)+len(otherTx)), 100, testingMempoolTTL, mockClock, testingTxFee)
+
+	require.NoError(t, mempool.AddTransaction(tx))
+	mockClock.Add(testingMempoolTTL / 2)
+	require.NoError(t, mempool.AddTransaction(otherTx))
+
+	// The replacement pays a higher fee per byte than the transaction it replaces but is larger, and has the lowest
+	// priority once the mempool is full, so it is rejected and the transaction it was replacing is kept
+	replacementTx := newTestingMempoolTx(t, signer, 1, 10000)
+	require.Greater(t, len(replacementTx), len(tx))
+	require.Equal(t, ErrMempoolFull().Code(), mempool.AddTransaction(replacementTx).Code())
+	require.False(t, mempool.Contains(TransactionHash(replacementTx)))
+	require.True(t, mempool.Contains(TransactionHash(tx)))
+	require.True(t, mempool.Contains(TransactionHash(otherTx)))
+	require.Equal(t, uint32(2), mempool.Size())
+	require.Equal(t, uint64(len(tx)+len(otherTx)), mempool.TxsBytes())
+
+	// The restored transaction keeps its place in the order of arrival, so it still expires first
+	mockClock.Add(testingMempoolTTL / 2)
+	require.Equal(t, uint32(1), mempool.Size())
+	require.False(t, mempool.Contains(TransactionHash(tx)))
+	require.True(t, mempool.Contains(TransactionHash(otherTx)))
+}
+
+func TestPriorityMempool_EvictLowestPriorityWhenFull(t *testing.T) {
+	mempool := NewPriorityMempool(testingMempoolMaxTxBytes, 2, testingMempoolTTL, clock.NewMock(), testingTxFee)
+
+	highFeeTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 5000)
+	lowFeeTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 1000)
+	mediumFeeTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 3000)
+	for _, tx := range [][]byte{highFeeTx, lowFeeTx, mediumFeeTx} {
+		require.NoError(t, mempool.AddTransaction(tx))
+	}
+	require.Equal(t, uint32(2), mempool.Size())
+	require.False(t, mempool.Contains(TransactionHash(lowFeeTx)))
+
+	// The transaction itself is rejected if it has the lowest priority
+	rejectedTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 1000)
+	require.Equal(t, ErrMempoolFull().Code(), mempool.AddTransaction(rejectedTx).Code())
+	require.False(t, mempool.Contains(TransactionHash(rejectedTx)))
+	require.True(t, mempool.Contains(TransactionHash(highFeeTx)))
+	require.True(t, mempool.Contains(TransactionHash(mediumFeeTx)))
+}
+
+func TestPriorityMempool_EvictExpired(t *testing.T) {
+	mockClock := clock.NewMock()
+	mempool := NewPriorityMempool(testingMempoolMaxTxBytes, 100, testingMempoolTTL, mockClock, testingTxFee)
+
+	expiredTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 1000)
+	require.NoError(t, mempool.AddTransaction(expiredTx))
+	mockClock.Add(testingMempoolTTL / 2)
+	tx := newTestingMempoolTx(t, newTestingSigner(t), 1, 1000)
+	require.NoError(t, mempool.AddTransaction(tx))
+	mockClock.Add(testingMempoolTTL / 2)
+
+	require.Equal(t, uint32(1), mempool.Size())
+	require.False(t, mempool.Contains(TransactionHash(expiredTx)))
+	require.True(t, mempool.Contains(TransactionHash(tx)))
+}
+
+func TestPriorityMempool_RemoveTransaction(t *testing.T) {
+	mempool := NewPriorityMempool(testingMempoolMaxTxBytes, 100, testingMempoolTTL, clock.NewMock(), testingTxFee)
+	signer := newTestingSigner(t)
+
+	committedTx := newTestingMempoolTx(t, signer, 1, 1000)
+	nextTx := newTestingMempoolTx(t, signer, 2, 1000)
+	otherTx := newTestingMempoolTx(t, newTestingSigner(t), 1, 5000)
+	for _, tx := range [][]byte{committedTx, nextTx, otherTx} {
+		require.NoError(t, mempool.AddTransaction(tx))
+	}
+
+	require.NoError(t, mempool.RemoveTransaction(committedTx))
+	require.NoError(t, mempool.RemoveTransaction(committedTx))
+	require.False(t, mempool.Contains(TransactionHash(committedTx)))
+	require.Equal(t, uint32(2), mempool.Size())
+
+	for _, expectedTx := range [][]byte{otherTx, nextTx} {
+		tx, err := mempool.PopTransaction()
+		require.NoError(t, err)
+		require.Equal(t, expectedTx, tx)
+	}
+}
+
+func newTestingSigner(t *testing.T) crypto.PrivateKey {
+	privateKey, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	return privateKey
+}
+
+// `newTestingMempoolTx` returns a transaction whose fee, according to `testingTxFee`, is its amount
+func newTestingMempoolTx(t *testing.T, signer crypto.PrivateKey, sequence uint64, fee int64) []byte {
+	anyMsg, err := codec.GetCodec().ToAny(&MessageSend{
+		FromAddress: signer.Address(),
+		ToAddress:   testingToAddr,
+		Amount:      fmt.Sprintf("%d", fee),
+	})
+	require.NoError(t, err)
+	tx := &Transaction{
+		Msgs:      []*anypb.Any{anyMsg},
+		Signature: &Signature{PublicKey: signer.PublicKey().Bytes()},
+		Sequence:  sequence,
+	}
+	bz, err := tx.Bytes()
+	require.NoError(t, err)
+	return bz
+}
+
+func testingTxFee(tx *Transaction) (*big.Int, Error) {
+	msgs, err := tx.Messages()
+	if err != nil {
+		return nil, err
+	}
+	return StringToBigInt(msgs[0].(*MessageSend).Amount)
+}
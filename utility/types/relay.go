@@ -0,0 +1,119 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pokt-network/pocket/shared/codec"
+	"github.com/pokt-network/pocket/shared/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+// AATVersion is the only supported version of the Application Authentication Token
+const AATVersion = "0.0.1"
+
+func RelayFromBytes(relayBz []byte) (*Relay, Error) {
+	relay := &Relay{}
+	if err := codec.GetCodec().Unmarshal(relayBz, relay); err != nil {
+		return nil, ErrProtoUnmarshal(err)
+	}
+	return relay, nil
+}
+
+func (r *Relay) Bytes() ([]byte, Error) {
+	bz, err := codec.GetCodec().Marshal(r)
+	if err != nil {
+		return nil, ErrProtoMarshal(err)
+	}
+	return bz, nil
+}
+
+// Hash returns the SHA3 hash of the relay's bytes which is used for volume tracking
+func (r *Relay) Hash() ([]byte, Error) {
+	bz, err := r.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.SHA3Hash(bz), nil
+}
+
+// SignBytes returns the bytes signed by the client: the relay with an empty signature
+func (r *Relay) SignBytes() ([]byte, Error) {
+	relay := proto.Clone(r).(*Relay)
+	if relay.Meta != nil {
+		relay.Meta.Signature = ""
+	}
+	bz, err := codec.GetCodec().Marshal(relay)
+	if err != nil {
+		return nil, ErrProtoMarshal(err)
+	}
+	return bz, nil
+}
+
+// SignBytes returns the bytes signed by the application: the token with an empty signature
+func (a *AAT) SignBytes() ([]byte, Error) {
+	token := proto.Clone(a).(*AAT)
+	token.ApplicationSignature = ""
+	bz, err := codec.GetCodec().Marshal(token)
+	if err != nil {
+		return nil, ErrProtoMarshal(err)
+	}
+	return bz, nil
+}
+
+// SignBytes returns the bytes signed by the servicer: the response with an empty signature
+func (r *RelayResponse) SignBytes() ([]byte, Error) {
+	response := proto.Clone(r).(*RelayResponse)
+	response.ServicerSignature = ""
+	bz, err := codec.GetCodec().Marshal(response)
+	if err != nil {
+		return nil, ErrProtoMarshal(err)
+	}
+	return bz, nil
+}
+
+// ValidateSignatures verifies the AAT signature chain: the application signs the token authorizing the
+// client, and the client signs the relay.
+func (r *Relay) ValidateSignatures() Error {
+	meta := r.GetMeta()
+	token := meta.GetToken()
+	if token == nil {
+		return ErrInvalidAAT("missing token")
+	}
+	if token.GetVersion() != AATVersion {
+		return ErrInvalidAAT(fmt.Sprintf("unsupported version %s", token.GetVersion()))
+	}
+	appPublicKey, err := crypto.NewPublicKey(token.GetApplicationPublicKey())
+	if err != nil {
+		return ErrInvalidAAT(err.Error())
+	}
+	clientPublicKey, err := crypto.NewPublicKey(token.GetClientPublicKey())
+	if err != nil {
+		return ErrInvalidAAT(err.Error())
+	}
+
+	tokenSignBytes, er := token.SignBytes()
+	if er != nil {
+		return er
+	}
+	if !verifyHexSignature(appPublicKey, tokenSignBytes, token.GetApplicationSignature()) {
+		return ErrInvalidAAT("invalid application signature")
+	}
+
+	relaySignBytes, er := r.SignBytes()
+	if er != nil {
+		return er
+	}
+	if !verifyHexSignature(clientPublicKey, relaySignBytes, meta.GetSignature()) {
+		return ErrSignatureVerificationFailed()
+	}
+	return nil
+}
+
+func verifyHexSignature(publicKey crypto.PublicKey, msg []byte, signatureHex string) bool {
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return false
+	}
+	return publicKey.Verify(msg, signature)
+}
@@ -0,0 +1,250 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+	"sort"
+
+	"github.com/celestiaorg/smt"
+	"github.com/pokt-network/pocket/shared/crypto"
+)
+
+/*
+`relay_tree.go` implements the sparse Merkle sum tree a servicer builds over the relays it serviced
+during a session in order to claim (and later prove) its work volume on chain.
+
+The tree reuses `celestiaorg/smt` with a custom hasher whose digests are suffixed with an 8 byte sum.
+Leaves are keyed by the hash of their relay, so a relay added more than once is a single leaf, and weighted by
+the number of relays they represent (always 1), so the sum trailing the root is the total number of distinct
+relays being claimed. Because every inner node digest commits to the digests (and therefore the sums) of its
+children, a proof verified against the root also verifies the sums along the path.
+
+The index of a leaf is its rank in the order of the leaf paths. Since the leaves preceding a leaf are the ones
+under its left side nodes, a proof also verifies the index of its leaf: the sum of its left side nodes.
+*/
+
+const (
+	relayTreeHashLen  = sha256.Size
+	relayTreeSumLen   = 8
+	RelayTreeRootLen  = relayTreeHashLen + relayTreeSumLen
+	relayTreeValueLen = relayTreeSumLen + sha256.Size // the weight of the leaf followed by the SHA3-256 hash of the relay
+	relayTreeNodeLen  = 1 + 2*RelayTreeRootLen        // the length of both the leaf and the inner node data (prefix + 2 digests)
+
+	relayTreeLeafPrefix = byte(0)
+	relayTreeNodePrefix = byte(1)
+
+	relayWeight = uint64(1)
+)
+
+var errRelayTreeSumOverflow = errors.New("the sum of the relay tree overflows")
+
+type RelayTree struct {
+	tree   *smt.SparseMerkleTree
+	hasher *relaySumHasher
+	relays map[string][]byte // the relays of the leaves keyed by the path of the leaf
+}
+
+// NewRelayTree returns an empty in memory relay tree; it only lives as long as the session's claim & proof lifecycle
+func NewRelayTree() *RelayTree {
+	hasher := newRelaySumHasher()
+	return &RelayTree{
+		tree:   smt.NewSparseMerkleTree(smt.NewSimpleMap(), smt.NewSimpleMap(), hasher),
+		hasher: hasher,
+		relays: make(map[string][]byte),
+	}
+}
+
+// AddRelay adds the relay as a leaf of the tree, unless the tree already has it
+func (t *RelayTree) AddRelay(relayBz []byte) Error {
+	key := relayTreeKey(relayBz)
+	path := string(relayTreePath(key))
+	if _, ok := t.relays[path]; ok {
+		return nil
+	}
+	if _, err := t.tree.Update(key, relayTreeValue(relayBz)); err != nil {
+		return ErrRelayTree(err)
+	}
+	if t.hasher.overflow {
+		return ErrRelayTree(errRelayTreeSumOverflow)
+	}
+	t.relays[path] = relayBz
+	return nil
+}
+
+func (t *RelayTree) Root() []byte {
+	return t.tree.Root()
+}
+
+// Prove returns the relay of the leaf at `index` along with the side nodes proving its membership
+func (t *RelayTree) Prove(index uint64) ([]byte, [][]byte, Error) {
+	if index >= uint64(len(t.relays)) {
+		return nil, nil, ErrRelayTree(fmt.Errorf("leaf index %d out of range for %d leaves", index, len(t.relays)))
+	}
+	paths := make([]string, 0, len(t.relays))
+	for path := range t.relays {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	relayBz := t.relays[paths[index]]
+	proof, err := t.tree.Prove(relayTreeKey(relayBz))
+	if err != nil {
+		return nil, nil, ErrRelayTree(err)
+	}
+	return relayBz, proof.SideNodes, nil
+}
+
+// ProveRelay returns the index of the leaf of the relay along with the side nodes proving its membership
+func (t *RelayTree) ProveRelay(relayBz []byte) (uint64, [][]byte, Error) {
+	path := string(relayTreePath(relayTreeKey(relayBz)))
+	if _, ok := t.relays[path]; !ok {
+		return 0, nil, ErrRelayTree(errors.New("the relay is not a leaf of the tree"))
+	}
+	var index uint64
+	for p := range t.relays {
+		if p < path {
+			index++
+		}
+	}
+	proof, err := t.tree.Prove(relayTreeKey(relayBz))
+	if err != nil {
+		return 0, nil, ErrRelayTree(err)
+	}
+	return index, proof.SideNodes, nil
+}
+
+// VerifyRelayProof verifies that the relay is the leaf at `index` of the relay tree with the `root` provided
+func VerifyRelayProof(root []byte, index uint64, relayBz []byte, sideNodes [][]byte) bool {
+	hasher := newRelaySumHasher()
+	key := relayTreeKey(relayBz)
+	proof := smt.SparseMerkleProof{SideNodes: sideNodes}
+	if !smt.VerifyProof(proof, root, key, relayTreeValue(relayBz), hasher) || hasher.overflow {
+		return false
+	}
+	numPrecedingLeaves, ok := relayTreeLeftSum(relayTreePath(key), sideNodes)
+	return ok && numPrecedingLeaves == index
+}
+
+// RelayTreeRootSum returns the total number of relays committed to by a relay tree root
+func RelayTreeRootSum(root []byte) uint64 {
+	if len(root) != RelayTreeRootLen {
+		return 0
+	}
+	return binary.BigEndian.Uint64(root[relayTreeHashLen:])
+}
+
+// RelayProofIndex pseudo-randomly selects the leaf a servicer must prove for its claim. The seed is expected
+// to be the hash of the block the claim was committed in so it is unknown to the servicer when claiming.
+func RelayProofIndex(seed, root []byte) uint64 {
+	numRelays := RelayTreeRootSum(root)
+	if numRelays == 0 {
+		return 0
+	}
+	hash := crypto.SHA3Hash(append(append([]byte{}, seed...), root...))
+	return binary.BigEndian.Uint64(hash[:8]) % numRelays
+}
+
+func relayTreeKey(relayBz []byte) []byte {
+	return crypto.SHA3Hash(relayBz)
+}
+
+// relayTreePath is the path of the leaf of `key` in the tree, whose bits select the side of each node from the root
+func relayTreePath(key []byte) []byte {
+	hasher := newRelaySumHasher()
+	hasher.Write(key)
+	return hasher.Sum(nil)
+}
+
+// relayTreeLeftSum returns the sum of the side nodes on the left of the path, i.e. the number of leaves preceding
+// the leaf of the path. The side nodes are ordered from the leaf to the root, as in the proofs of `smt`.
+func relayTreeLeftSum(path []byte, sideNodes [][]byte) (uint64, bool) {
+	var sum uint64
+	for i, sideNode := range sideNodes {
+		depth := len(sideNodes) - 1 - i
+		if path[depth/8]&(1<<(7-depth%8)) == 0 {
+			continue
+		}
+		var carry uint64
+		sum, carry = bits.Add64(sum, RelayTreeRootSum(sideNode), 0)
+		if carry != 0 {
+			return 0, false
+		}
+	}
+	return sum, true
+}
+
+// relayTreeValue is the weight of the leaf followed by the hash of the relay
+func relayTreeValue(relayBz []byte) []byte {
+	value := make([]byte, relayTreeSumLen, relayTreeValueLen)
+	binary.BigEndian.PutUint64(value, relayWeight)
+	return append(value, crypto.SHA3Hash(relayBz)...)
+}
+
+var _ hash.Hash = &relaySumHasher{}
+
+// relaySumHasher is a sha256 hasher whose digests are suffixed with the sum of the data being hashed.
+// The sum is derived from the structure of the data the tree hashes:
+//   - leaf values: the weight prefix of the value
+//   - leaves: the sum of the value digest
+//   - inner nodes: the sum of the left and right child digests
+//   - keys: zero
+//
+// The sum of an inner node whose children sums overflow is zero and flags the hasher, which is not reset with its
+// data, so the trees and proofs hashed with it can be rejected.
+type relaySumHasher struct {
+	data     []byte
+	overflow bool
+}
+
+func newRelaySumHasher() *relaySumHasher {
+	return &relaySumHasher{}
+}
+
+func (h *relaySumHasher) Write(p []byte) (int, error) {
+	h.data = append(h.data, p...)
+	return len(p), nil
+}
+
+func (h *relaySumHasher) Sum(b []byte) []byte {
+	digest := sha256.Sum256(h.data)
+	sum := make([]byte, relayTreeSumLen)
+	binary.BigEndian.PutUint64(sum, h.sum())
+	return append(append(b, digest[:]...), sum...)
+}
+
+func (h *relaySumHasher) sum() uint64 {
+	switch len(h.data) {
+	case relayTreeValueLen:
+		return binary.BigEndian.Uint64(h.data[:relayTreeSumLen])
+	case relayTreeNodeLen:
+		rightSum := binary.BigEndian.Uint64(h.data[relayTreeNodeLen-relayTreeSumLen:])
+		switch h.data[0] {
+		case relayTreeLeafPrefix:
+			return rightSum
+		case relayTreeNodePrefix:
+			leftSum := binary.BigEndian.Uint64(h.data[1+relayTreeHashLen : 1+RelayTreeRootLen])
+			sum, carry := bits.Add64(leftSum, rightSum, 0)
+			if carry != 0 {
+				h.overflow = true
+				return 0
+			}
+			return sum
+		}
+	}
+	return 0
+}
+
+func (h *relaySumHasher) Reset() {
+	h.data = nil
+}
+
+func (h *relaySumHasher) Size() int {
+	return RelayTreeRootLen
+}
+
+func (h *relaySumHasher) BlockSize() int {
+	return sha256.BlockSize
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayTree_RootSum(t *testing.T) {
+	tree := NewRelayTree()
+	require.Zero(t, RelayTreeRootSum(tree.Root()))
+
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, tree.AddRelay(testRelayBytes(i)))
+		require.Len(t, tree.Root(), RelayTreeRootLen)
+		require.Equal(t, uint64(i), RelayTreeRootSum(tree.Root()))
+	}
+}
+
+func TestRelayTree_ProveAndVerify(t *testing.T) {
+	numRelays := 25
+	tree := newTestRelayTree(t, numRelays)
+	root := tree.Root()
+
+	provenRelays := make(map[string]struct{})
+	for i := 0; i < numRelays; i++ {
+		relayBz, sideNodes, err := tree.Prove(uint64(i))
+		require.NoError(t, err)
+		require.True(t, VerifyRelayProof(root, uint64(i), relayBz, sideNodes), "proof of leaf %d", i)
+		provenRelays[string(relayBz)] = struct{}{}
+
+		// the proof must not verify for a different relay or leaf
+		require.False(t, VerifyRelayProof(root, uint64(i), testRelayBytes(numRelays), sideNodes))
+		require.False(t, VerifyRelayProof(root, uint64(i+1), relayBz, sideNodes))
+	}
+	require.Len(t, provenRelays, numRelays, "every leaf index must prove a different relay")
+
+	_, _, err := tree.Prove(uint64(numRelays))
+	require.Error(t, err)
+}
+
+func TestRelayTree_ProveRelay(t *testing.T) {
+	numRelays := 10
+	tree := newTestRelayTree(t, numRelays)
+	root := tree.Root()
+
+	for i := 0; i < numRelays; i++ {
+		index, sideNodes, err := tree.ProveRelay(testRelayBytes(i))
+		require.NoError(t, err)
+		require.True(t, VerifyRelayProof(root, index, testRelayBytes(i), sideNodes), "proof of relay %d", i)
+
+		relayBz, _, err := tree.Prove(index)
+		require.NoError(t, err)
+		require.Equal(t, testRelayBytes(i), relayBz)
+	}
+
+	_, _, err := tree.ProveRelay(testRelayBytes(numRelays))
+	require.Error(t, err)
+}
+
+func TestRelayTree_DuplicateRelays(t *testing.T) {
+	tree := newTestRelayTree(t, 5)
+	root := tree.Root()
+
+	// a relay added more than once is a single leaf, and therefore counted once
+	for i := 0; i < 5; i++ {
+		require.NoError(t, tree.AddRelay(testRelayBytes(0)))
+	}
+	require.Equal(t, root, tree.Root())
+	require.Equal(t, uint64(5), RelayTreeRootSum(tree.Root()))
+}
+
+func TestRelayTree_SumOverflow(t *testing.T) {
+	maxSumDigest := make([]byte, RelayTreeRootLen)
+	binary.BigEndian.PutUint64(maxSumDigest[relayTreeHashLen:], math.MaxUint64)
+	oneSumDigest := make([]byte, RelayTreeRootLen)
+	binary.BigEndian.PutUint64(oneSumDigest[relayTreeHashLen:], 1)
+
+	hasher := newRelaySumHasher()
+	hasher.Write(append(append([]byte{relayTreeNodePrefix}, maxSumDigest...), oneSumDigest...))
+	require.Zero(t, RelayTreeRootSum(hasher.Sum(nil)))
+	require.True(t, hasher.overflow)
+
+	// the overflow outlives the data of the hasher so the digests computed with it can be rejected
+	hasher.Reset()
+	require.True(t, hasher.overflow)
+
+	// the left side nodes of a proof cannot overflow the index of its leaf either
+	path := make([]byte, RelayTreeRootLen)
+	path[0] = 0xC0 // the first two side nodes from the root are on the left
+	_, ok := relayTreeLeftSum(path, [][]byte{oneSumDigest, maxSumDigest})
+	require.False(t, ok)
+}
+
+func TestRelayTree_ProofDoesNotVerifyAgainstInflatedRoot(t *testing.T) {
+	tree := newTestRelayTree(t, 5)
+	relayBz, sideNodes, err := tree.Prove(0)
+	require.NoError(t, err)
+
+	// claim more relays than there are in the tree by tampering with the sum of the root
+	inflatedRoot := append([]byte{}, tree.Root()...)
+	inflatedRoot[RelayTreeRootLen-1]++
+	require.Equal(t, uint64(6), RelayTreeRootSum(inflatedRoot))
+	require.False(t, VerifyRelayProof(inflatedRoot, 0, relayBz, sideNodes))
+}
+
+func TestRelayTree_RelayProofIndex(t *testing.T) {
+	tree := newTestRelayTree(t, 7)
+	root := tree.Root()
+
+	for i := 0; i < 50; i++ {
+		seed := []byte(fmt.Sprintf("block_hash_%d", i))
+		index := RelayProofIndex(seed, root)
+		require.Less(t, index, uint64(7))
+		require.Equal(t, index, RelayProofIndex(seed, root), "the selection must be deterministic")
+	}
+	require.Zero(t, RelayProofIndex([]byte("seed"), NewRelayTree().Root()))
+}
+
+func newTestRelayTree(t *testing.T, numRelays int) *RelayTree {
+	tree := NewRelayTree()
+	for i := 0; i < numRelays; i++ {
+		require.NoError(t, tree.AddRelay(testRelayBytes(i)))
+	}
+	return tree
+}
+
+func testRelayBytes(i int) []byte {
+	return []byte(fmt.Sprintf("relay_%d", i))
+}
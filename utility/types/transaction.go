@@ -2,11 +2,11 @@ package types
 
 import (
 	"bytes"
+	"math/big"
 
 	"github.com/pokt-network/pocket/shared/codec"
 	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/pokt-network/pocket/shared/modules"
-	"google.golang.org/protobuf/proto"
 )
 
 func TransactionFromBytes(transaction []byte) (*Transaction, Error) {
@@ -18,11 +18,44 @@ func TransactionFromBytes(transaction []byte) (*Transaction, Error) {
 }
 
 func (tx *Transaction) ValidateBasic() Error {
-	if tx.Nonce == "" {
-		return ErrEmptyNonce()
+	if tx.Fee != "" {
+		fee, err := StringToBigInt(tx.Fee)
+		if err != nil {
+			return err
+		}
+		if fee.Sign() == -1 {
+			return ErrNegativeAmountError()
+		}
 	}
-	if _, err := codec.GetCodec().FromAny(tx.Msg); err != nil {
-		return ErrProtoFromAny(err)
+	if len(tx.Msgs) == 0 {
+		return ErrEmptyMessages()
+	}
+	for _, anyMsg := range tx.Msgs {
+		if _, err := codec.GetCodec().FromAny(anyMsg); err != nil {
+			return ErrProtoFromAny(err)
+		}
+	}
+	msgs, er := tx.Messages()
+	if er != nil {
+		return er
+	}
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return tx.VerifySignatures()
+}
+
+// `VerifySignatures` verifies the signature of the signer, or the signatures of at least the threshold of the members
+// of the signer if it is a multisig account
+func (tx *Transaction) VerifySignatures() Error {
+	signBytes, err := tx.SignBytes()
+	if err != nil {
+		return err
+	}
+	if len(tx.MultisigPublicKey) != 0 {
+		return tx.verifyMultisigSignatures(signBytes)
 	}
 	if tx.Signature == nil || tx.Signature.Signature == nil {
 		return ErrEmptySignature()
@@ -30,34 +63,87 @@ func (tx *Transaction) ValidateBasic() Error {
 	if tx.Signature.PublicKey == nil {
 		return ErrEmptyPublicKey()
 	}
-	publicKey, err := crypto.NewPublicKeyFromBytes(tx.Signature.PublicKey)
-	if err != nil {
-		return ErrNewPublicKeyFromBytes(err)
-	}
-	signBytes, err := tx.SignBytes()
-	if err != nil {
-		return ErrProtoMarshal(err)
+	publicKey, er := crypto.NewPublicKeyFromBytes(tx.Signature.PublicKey)
+	if er != nil {
+		return ErrNewPublicKeyFromBytes(er)
 	}
 	if ok := publicKey.Verify(signBytes, tx.Signature.Signature); !ok {
 		return ErrSignatureVerificationFailed()
 	}
-	if _, err := tx.Message(); err != nil {
-		return err
+	return nil
+}
+
+func (tx *Transaction) verifyMultisigSignatures(signBytes []byte) Error {
+	// The signature of a single signer is refused so the transaction cannot be altered without invalidating it
+	if tx.Signature != nil {
+		return ErrUnexpectedSignature()
+	}
+	multisigPublicKey, er := crypto.NewMultisigPublicKeyFromBytes(tx.MultisigPublicKey)
+	if er != nil {
+		return ErrInvalidMultisigPublicKey(er)
+	}
+	if len(tx.Signatures) < multisigPublicKey.Threshold() {
+		return ErrMultisigThreshold(len(tx.Signatures), multisigPublicKey.Threshold())
+	}
+	publicKeys, signatures := make([][]byte, len(tx.Signatures)), make([][]byte, len(tx.Signatures))
+	for i, signature := range tx.Signatures {
+		publicKeys[i], signatures[i] = signature.GetPublicKey(), signature.GetSignature()
+	}
+	if ok := multisigPublicKey.Verify(signBytes, publicKeys, signatures); !ok {
+		return ErrSignatureVerificationFailed()
 	}
 	return nil
 }
 
-func (tx *Transaction) Message() (Message, Error) {
-	codec := codec.GetCodec()
-	msg, er := codec.FromAny(tx.Msg)
+// `SignerAddress` returns the address of the signer of the transaction, which is the address of its multisig public key
+// if it is signed by a multisig account
+func (tx *Transaction) SignerAddress() (crypto.Address, Error) {
+	if len(tx.MultisigPublicKey) != 0 {
+		multisigPublicKey, er := crypto.NewMultisigPublicKeyFromBytes(tx.MultisigPublicKey)
+		if er != nil {
+			return nil, ErrInvalidMultisigPublicKey(er)
+		}
+		return multisigPublicKey.Address(), nil
+	}
+	publicKey, er := crypto.NewPublicKeyFromBytes(tx.GetSignature().GetPublicKey())
 	if er != nil {
-		return nil, ErrProtoMarshal(er)
+		return nil, ErrNewPublicKeyFromBytes(er)
+	}
+	return publicKey.Address(), nil
+}
+
+// `Messages` decodes the messages of the transaction, in the order they are applied
+func (tx *Transaction) Messages() ([]Message, Error) {
+	codec := codec.GetCodec()
+	messages := make([]Message, len(tx.Msgs))
+	for i, anyMsg := range tx.Msgs {
+		msg, er := codec.FromAny(anyMsg)
+		if er != nil {
+			return nil, ErrProtoMarshal(er)
+		}
+		message, ok := msg.(Message)
+		if !ok {
+			return nil, ErrDecodeMessage()
+		}
+		messages[i] = message
+	}
+	return messages, nil
+}
+
+// `FeeAmount` returns the fee paid by the transaction given the minimum fee of its messages (i.e. the sum of the
+// minimum fees of every message), which is paid if the fee of the transaction is not specified
+func (tx *Transaction) FeeAmount(minimumFee *big.Int) (*big.Int, Error) {
+	if tx.Fee == "" {
+		return minimumFee, nil
+	}
+	fee, err := StringToBigInt(tx.Fee)
+	if err != nil {
+		return nil, err
 	}
-	message, ok := msg.(Message)
-	if !ok {
-		return nil, ErrDecodeMessage()
+	if fee.Cmp(minimumFee) < 0 {
+		return nil, ErrFeeBelowMinimum(tx.Fee, BigIntToString(minimumFee))
 	}
-	return message, nil
+	return fee, nil
 }
 
 func (tx *Transaction) Sign(privateKey crypto.PrivateKey) Error {
@@ -77,6 +163,64 @@ func (tx *Transaction) Sign(privateKey crypto.PrivateKey) Error {
 	return nil
 }
 
+// `SignMultisig` adds the signature of `privateKey`, a member of the multisig public key of the transaction, to its
+// signatures, replacing the previous signature of the member if any
+func (tx *Transaction) SignMultisig(privateKey crypto.PrivateKey) Error {
+	multisigPublicKey, er := crypto.NewMultisigPublicKeyFromBytes(tx.MultisigPublicKey)
+	if er != nil {
+		return ErrInvalidMultisigPublicKey(er)
+	}
+	publicKey := privateKey.PublicKey()
+	if !multisigPublicKey.IsMember(publicKey.Bytes()) {
+		return ErrNotMultisigMember(publicKey.String())
+	}
+	bz, err := tx.SignBytes()
+	if err != nil {
+		return err
+	}
+	signature, er := privateKey.Sign(bz)
+	if er != nil {
+		return ErrTransactionSign(er)
+	}
+	tx.addSignature(&Signature{
+		PublicKey: publicKey.Bytes(),
+		Signature: signature,
+	})
+	return nil
+}
+
+// `CombineSignatures` adds the member signatures of `txs`, the same transaction partially signed by other members of
+// its multisig public key, to the signatures of the transaction
+func (tx *Transaction) CombineSignatures(txs ...*Transaction) Error {
+	signBytes, err := tx.SignBytes()
+	if err != nil {
+		return err
+	}
+	for _, other := range txs {
+		otherSignBytes, err := other.SignBytes()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(signBytes, otherSignBytes) {
+			return ErrCombineDifferentTransactions()
+		}
+		for _, signature := range other.Signatures {
+			tx.addSignature(signature)
+		}
+	}
+	return nil
+}
+
+func (tx *Transaction) addSignature(signature *Signature) {
+	for i, existing := range tx.Signatures {
+		if bytes.Equal(existing.PublicKey, signature.PublicKey) {
+			tx.Signatures[i] = signature
+			return
+		}
+	}
+	tx.Signatures = append(tx.Signatures, signature)
+}
+
 func (tx *Transaction) Hash() (string, Error) {
 	b, err := tx.Bytes()
 	if err != nil {
@@ -89,6 +233,7 @@ func (tx *Transaction) SignBytes() ([]byte, Error) {
 	// transaction := proto.Clone(tx).(*Transaction)
 	transaction := *tx
 	transaction.Signature = nil
+	transaction.Signatures = nil
 	bz, err := codec.GetCodec().Marshal(&transaction)
 	if err != nil {
 		return nil, ErrProtoMarshal(err)
@@ -136,30 +281,61 @@ func (x *DefaultTxResult) HashFromBytes(bz []byte) ([]byte, error) {
 	return crypto.SHA3Hash(bz), nil
 }
 
-func (tx *Transaction) ToTxResult(height int64, index int, signer, recipient, msgType string, error Error) (*DefaultTxResult, Error) {
+func (x *DefaultTxResult) GetRecipientAddrs() []string {
+	if len(x.MessageResults) == 0 {
+		return []string{x.RecipientAddr}
+	}
+	recipients := make([]string, len(x.MessageResults))
+	for i, messageResult := range x.MessageResults {
+		recipients[i] = messageResult.RecipientAddr
+	}
+	return recipients
+}
+
+func (x *DefaultTxResult) GetMessageTypes() []string {
+	if len(x.MessageResults) == 0 {
+		return []string{x.MessageType}
+	}
+	messageTypes := make([]string, len(x.MessageResults))
+	for i, messageResult := range x.MessageResults {
+		messageTypes[i] = messageResult.MessageType
+	}
+	return messageTypes
+}
+
+// `ToTxResult` returns the result of the transaction given the results of its messages, which failed with the error
+// of the last one if it is not nil
+func (tx *Transaction) ToTxResult(height int64, index int, signer string, messageResults []*MessageResult) (*DefaultTxResult, Error) {
 	txBytes, err := tx.Bytes()
 	if err != nil {
 		return nil, err
 	}
-	code, errString := int32(0), ""
-	if error != nil {
-		code = int32(error.Code())
-		errString = err.Error()
-	}
-	return &DefaultTxResult{
-		Tx:            txBytes,
-		Height:        height,
-		Index:         int32(index),
-		ResultCode:    code,
-		Error:         errString,
-		SignerAddr:    signer,
-		RecipientAddr: recipient,
-		MessageType:   msgType,
-	}, nil
-}
-
-func (tx *Transaction) GetMessage() (proto.Message, error) {
-	return codec.GetCodec().FromAny(tx.Msg)
+	txResult := &DefaultTxResult{
+		Tx:             txBytes,
+		Height:         height,
+		Index:          int32(index),
+		SignerAddr:     signer,
+		MessageResults: messageResults,
+	}
+	if len(messageResults) > 0 {
+		txResult.RecipientAddr = messageResults[0].RecipientAddr
+		txResult.MessageType = messageResults[0].MessageType
+		lastResult := messageResults[len(messageResults)-1]
+		txResult.ResultCode, txResult.Error = lastResult.ResultCode, lastResult.Error
+	}
+	return txResult, nil
+}
+
+func NewMessageResult(msg Message, err Error) *MessageResult {
+	messageResult := &MessageResult{
+		MessageType:   msg.GetMessageName(),
+		RecipientAddr: msg.GetMessageRecipient(),
+	}
+	if err != nil {
+		messageResult.ResultCode = int32(err.Code())
+		messageResult.Error = err.Error()
+	}
+	return messageResult
 }
 
 func TransactionHash(transactionProtoBytes []byte) string {
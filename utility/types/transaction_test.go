@@ -2,11 +2,13 @@ package types
 
 import (
 	"github.com/pokt-network/pocket/shared/codec"
+	"math/big"
 	"testing"
 
 	"github.com/pokt-network/pocket/shared/crypto"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
@@ -32,8 +34,8 @@ func NewUnsignedTestingTransaction(t *testing.T) Transaction {
 	require.NoError(t, err)
 
 	return Transaction{
-		Msg:   anyMsg,
-		Nonce: BigIntToString(RandBigInt()),
+		Msgs:     []*anypb.Any{anyMsg},
+		Sequence: RandBigInt().Uint64(),
 	}
 }
 
@@ -55,10 +57,12 @@ func TestTransactionBytesAndFromBytes(t *testing.T) {
 	require.Equal(t, proto.Clone(&tx), proto.Clone(tx2), "transaction mismatch")
 }
 
-func TestTransaction_Message(t *testing.T) {
+func TestTransaction_Messages(t *testing.T) {
 	tx := NewUnsignedTestingTransaction(t)
-	msg, err := tx.Message()
+	msgs, err := tx.Messages()
 	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	msg := msgs[0]
 
 	expected := NewTestingMsg(t)
 	require.NotEqual(t, expected, msg)
@@ -84,6 +88,24 @@ func TestTransaction_Sign(t *testing.T) {
 	require.True(t, verified, "signature should be verified")
 }
 
+func TestTransaction_FeeAmount(t *testing.T) {
+	tx := NewUnsignedTestingTransaction(t)
+	minimumFee := big.NewInt(10000)
+
+	fee, err := tx.FeeAmount(minimumFee)
+	require.NoError(t, err)
+	require.Equal(t, minimumFee, fee)
+
+	tx.Fee = "20000"
+	fee, err = tx.FeeAmount(minimumFee)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20000), fee)
+
+	tx.Fee = "9999"
+	_, err = tx.FeeAmount(minimumFee)
+	require.Equal(t, CodeFeeBelowMinimumError, err.Code())
+}
+
 func TestTransaction_ValidateBasic(t *testing.T) {
 	tx := NewUnsignedTestingTransaction(t)
 	err := tx.Sign(testingSenderPrivateKey)
@@ -92,16 +114,33 @@ func TestTransaction_ValidateBasic(t *testing.T) {
 	er := tx.ValidateBasic()
 	require.NoError(t, er)
 
-	txNoNonce := proto.Clone(&tx).(*Transaction)
-	txNoNonce.Nonce = ""
-	er = txNoNonce.ValidateBasic()
-	require.Equal(t, ErrEmptyNonce().Code(), er.Code())
+	txNegativeFee := proto.Clone(&tx).(*Transaction)
+	txNegativeFee.Fee = "-1"
+	er = txNegativeFee.ValidateBasic()
+	require.Equal(t, ErrNegativeAmountError().Code(), er.Code())
+
+	txInvalidFee := proto.Clone(&tx).(*Transaction)
+	txInvalidFee.Fee = "one"
+	er = txInvalidFee.ValidateBasic()
+	require.Equal(t, ErrStringToBigInt().Code(), er.Code())
 
 	txInvalidMessageAny := proto.Clone(&tx).(*Transaction)
-	txInvalidMessageAny.Msg = nil
+	txInvalidMessageAny.Msgs = []*anypb.Any{nil}
 	er = txInvalidMessageAny.ValidateBasic()
 	require.Equal(t, ErrProtoFromAny(er).Code(), er.Code())
 
+	txNoMessage := proto.Clone(&tx).(*Transaction)
+	txNoMessage.Msgs = nil
+	er = txNoMessage.ValidateBasic()
+	require.Equal(t, ErrEmptyMessages().Code(), er.Code())
+
+	txInvalidMessage := proto.Clone(&tx).(*Transaction)
+	invalidMsg, anyErr := codec.GetCodec().ToAny(&MessageSend{})
+	require.NoError(t, anyErr)
+	txInvalidMessage.Msgs = append(txInvalidMessage.Msgs, invalidMsg)
+	er = txInvalidMessage.ValidateBasic()
+	require.Equal(t, ErrEmptyAddress().Code(), er.Code())
+
 	txEmptySig := proto.Clone(&tx).(*Transaction)
 	txEmptySig.Signature = nil
 	er = txEmptySig.ValidateBasic()
@@ -124,3 +163,60 @@ func TestTransaction_ValidateBasic(t *testing.T) {
 	require.Equal(t, ErrSignatureVerificationFailed().Code(), er.Code())
 
 }
+
+func TestTransaction_Multisig(t *testing.T) {
+	members := make([]crypto.PrivateKey, 3)
+	publicKeys := make([]crypto.PublicKey, len(members))
+	for i := range members {
+		member, err := crypto.GeneratePrivateKey()
+		require.NoError(t, err)
+		members[i], publicKeys[i] = member, member.PublicKey()
+	}
+	multisigPublicKey, err := crypto.NewMultisigPublicKey(2, publicKeys)
+	require.NoError(t, err)
+
+	tx := NewUnsignedTestingTransaction(t)
+	tx.MultisigPublicKey = multisigPublicKey.Bytes()
+	signer, er := tx.SignerAddress()
+	require.NoError(t, er)
+	require.Equal(t, multisigPublicKey.Address(), signer)
+
+	// Every member signs its own copy of the transaction
+	partiallySignedTxs := make([]*Transaction, len(members))
+	for i, member := range members {
+		partiallySignedTxs[i] = proto.Clone(&tx).(*Transaction)
+		require.NoError(t, partiallySignedTxs[i].SignMultisig(member))
+	}
+	er = partiallySignedTxs[0].ValidateBasic()
+	require.Equal(t, CodeMultisigThresholdError, er.Code())
+
+	outsider, err := crypto.GeneratePrivateKey()
+	require.NoError(t, err)
+	er = proto.Clone(&tx).(*Transaction).SignMultisig(outsider)
+	require.Equal(t, CodeNotMultisigMemberError, er.Code())
+
+	// The signatures of the threshold of the members are combined
+	combinedTx := proto.Clone(partiallySignedTxs[0]).(*Transaction)
+	require.NoError(t, combinedTx.CombineSignatures(partiallySignedTxs[2]))
+	require.Len(t, combinedTx.Signatures, 2)
+	require.NoError(t, combinedTx.ValidateBasic())
+
+	// The signature of a member is not counted twice
+	require.NoError(t, combinedTx.CombineSignatures(partiallySignedTxs[2]))
+	require.Len(t, combinedTx.Signatures, 2)
+
+	otherTx := proto.Clone(partiallySignedTxs[1]).(*Transaction)
+	otherTx.Sequence++
+	er = combinedTx.CombineSignatures(otherTx)
+	require.Equal(t, CodeCombineDifferentTransactionsError, er.Code())
+
+	txWithSignature := proto.Clone(combinedTx).(*Transaction)
+	txWithSignature.Signature = &Signature{PublicKey: publicKeys[0].Bytes(), Signature: combinedTx.Signatures[0].Signature}
+	er = txWithSignature.ValidateBasic()
+	require.Equal(t, CodeUnexpectedSignatureError, er.Code())
+
+	txInvalidSignature := proto.Clone(combinedTx).(*Transaction)
+	txInvalidSignature.Signatures[1].Signature = []byte("signature")
+	er = txInvalidSignature.ValidateBasic()
+	require.Equal(t, CodeSignatureVerificationFailedError, er.Code())
+}